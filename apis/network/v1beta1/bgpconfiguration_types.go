@@ -44,6 +44,30 @@ type BGPConfigurationSpec struct {
 	// gets queried using the FRRConfiguration.spec.NodeSelector `kubernetes.io/hostname: worker-0`. In case a more
 	// specific
 	FRRNodeConfigurationSelector []FRRNodeConfigurationSelectorType `json:"frrNodeConfigurationSelector,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// NeighborOverrides - BFD profile and BGP timer settings which get merged into the neighbors
+	// of the FRRConfigurations generated for the pods. The neighbors of the node FRRConfiguration
+	// are used as they are if not set.
+	NeighborOverrides *BGPNeighborOverrideType `json:"neighborOverrides,omitempty"`
+}
+
+// BGPNeighborOverrideType -
+type BGPNeighborOverrideType struct {
+	// +kubebuilder:validation:Optional
+	// BFDProfile - name of a BFD profile to enable for the generated neighbors. The profile must
+	// be defined in the spec.bgp.bfdProfiles of the node FRRConfiguration.
+	BFDProfile string `json:"bfdProfile,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// HoldTime - BGP hold time for the generated neighbors, per RFC4271. Must be 0s or
+	// between 3s and 65535s. Requires KeepaliveTime to be set.
+	HoldTime *metav1.Duration `json:"holdTime,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// KeepaliveTime - BGP keepalive time for the generated neighbors, per RFC4271. Must be lower
+	// than HoldTime. Requires HoldTime to be set.
+	KeepaliveTime *metav1.Duration `json:"keepaliveTime,omitempty"`
 }
 
 // BGPConfigurationStatus defines the observed state of BGPConfiguration
@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// bgpMinHoldTime - a hold time other than 0 must at least be 3s, per RFC4271
+	bgpMinHoldTime = 3 * time.Second
+	// bgpMaxTimer - upper limit FRR accepts for the hold and keepalive timer
+	bgpMaxTimer = 65535 * time.Second
+
+	errTimerWholeSeconds  = "must be a whole number of seconds"
+	errTimerOutOfRange    = "must be between %s and %s"
+	errHoldTimeOutOfRange = "must be 0s or between %s and %s"
+	errTimerPairRequired  = "holdTime and keepaliveTime must be set together"
+	errKeepaliveNotLower  = "keepaliveTime %s must be lower than holdTime %s"
+	errKeepaliveHoldTime0 = "keepaliveTime must be 0s when holdTime is 0s"
+)
+
+// log is for logging in this package.
+var bgpconfigurationlog = logf.Log.WithName("bgpconfiguration-resource")
+
+var _ webhook.Validator = &BGPConfiguration{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *BGPConfiguration) ValidateCreate() (admission.Warnings, error) {
+	bgpconfigurationlog.Info("validate create", "name", r.Name)
+
+	allErrs := r.Spec.ValidateNeighborOverrides(field.NewPath("spec"))
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(GroupVersion.WithKind("BGPConfiguration").GroupKind(), r.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *BGPConfiguration) ValidateUpdate(_ runtime.Object) (admission.Warnings, error) {
+	bgpconfigurationlog.Info("validate update", "name", r.Name)
+
+	// only run the validations on update if the object won't get updated
+	// to be deleted (remove finalizer).
+	if !r.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+
+	allErrs := r.Spec.ValidateNeighborOverrides(field.NewPath("spec"))
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(GroupVersion.WithKind("BGPConfiguration").GroupKind(), r.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *BGPConfiguration) ValidateDelete() (admission.Warnings, error) {
+	bgpconfigurationlog.Info("validate delete", "name", r.Name)
+
+	return nil, nil
+}
+
+// ValidateNeighborOverrides - validates the NeighborOverrides so that only combinations
+// get passed to the generated FRRConfigurations which frr-k8s accepts.
+func (spec *BGPConfigurationSpec) ValidateNeighborOverrides(basePath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	o := spec.NeighborOverrides
+	if o == nil {
+		return allErrs
+	}
+	path := basePath.Child("neighborOverrides")
+
+	if o.HoldTime == nil && o.KeepaliveTime != nil {
+		allErrs = append(allErrs, field.Required(path.Child("holdTime"), errTimerPairRequired))
+		return allErrs
+	}
+	if o.HoldTime != nil && o.KeepaliveTime == nil {
+		allErrs = append(allErrs, field.Required(path.Child("keepaliveTime"), errTimerPairRequired))
+		return allErrs
+	}
+	if o.HoldTime == nil {
+		return allErrs
+	}
+
+	holdTime := o.HoldTime.Duration
+	keepaliveTime := o.KeepaliveTime.Duration
+
+	if holdTime%time.Second != 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("holdTime"), holdTime.String(), errTimerWholeSeconds))
+	} else if holdTime != 0 && (holdTime < bgpMinHoldTime || holdTime > bgpMaxTimer) {
+		allErrs = append(allErrs, field.Invalid(path.Child("holdTime"), holdTime.String(),
+			fmt.Sprintf(errHoldTimeOutOfRange, bgpMinHoldTime, bgpMaxTimer)))
+	}
+
+	if keepaliveTime%time.Second != 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("keepaliveTime"), keepaliveTime.String(), errTimerWholeSeconds))
+	} else if keepaliveTime < 0 || keepaliveTime > bgpMaxTimer {
+		allErrs = append(allErrs, field.Invalid(path.Child("keepaliveTime"), keepaliveTime.String(),
+			fmt.Sprintf(errTimerOutOfRange, time.Duration(0), bgpMaxTimer)))
+	}
+
+	if len(allErrs) != 0 {
+		return allErrs
+	}
+
+	// with a hold time of 0 keepalive messages are disabled, otherwise
+	// keepalive messages must be sent before the hold time expires.
+	if holdTime == 0 && keepaliveTime != 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("keepaliveTime"), keepaliveTime.String(), errKeepaliveHoldTime0))
+	} else if holdTime != 0 && keepaliveTime >= holdTime {
+		allErrs = append(allErrs, field.Invalid(path.Child("keepaliveTime"), keepaliveTime.String(),
+			fmt.Sprintf(errKeepaliveNotLower, keepaliveTime, holdTime)))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega" //revive:disable:dot-imports
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestBGPConfigurationValidateNeighborOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		expectErr bool
+		overrides *BGPNeighborOverrideType
+	}{
+		{
+			name:      "should succeed without overrides",
+			expectErr: false,
+			overrides: nil,
+		},
+		{
+			name:      "should succeed with BFD profile only",
+			expectErr: false,
+			overrides: &BGPNeighborOverrideType{
+				BFDProfile: "bfd-fast",
+			},
+		},
+		{
+			name:      "should succeed with valid timers",
+			expectErr: false,
+			overrides: &BGPNeighborOverrideType{
+				BFDProfile:    "bfd-fast",
+				HoldTime:      &metav1.Duration{Duration: 9 * time.Second},
+				KeepaliveTime: &metav1.Duration{Duration: 3 * time.Second},
+			},
+		},
+		{
+			name:      "should succeed with timers disabled",
+			expectErr: false,
+			overrides: &BGPNeighborOverrideType{
+				HoldTime:      &metav1.Duration{Duration: 0},
+				KeepaliveTime: &metav1.Duration{Duration: 0},
+			},
+		},
+		{
+			name:      "should fail with holdTime only",
+			expectErr: true,
+			overrides: &BGPNeighborOverrideType{
+				HoldTime: &metav1.Duration{Duration: 9 * time.Second},
+			},
+		},
+		{
+			name:      "should fail with keepaliveTime only",
+			expectErr: true,
+			overrides: &BGPNeighborOverrideType{
+				KeepaliveTime: &metav1.Duration{Duration: 3 * time.Second},
+			},
+		},
+		{
+			name:      "should fail with holdTime lower than 3s",
+			expectErr: true,
+			overrides: &BGPNeighborOverrideType{
+				HoldTime:      &metav1.Duration{Duration: 2 * time.Second},
+				KeepaliveTime: &metav1.Duration{Duration: 1 * time.Second},
+			},
+		},
+		{
+			name:      "should fail with holdTime above 65535s",
+			expectErr: true,
+			overrides: &BGPNeighborOverrideType{
+				HoldTime:      &metav1.Duration{Duration: 65536 * time.Second},
+				KeepaliveTime: &metav1.Duration{Duration: 60 * time.Second},
+			},
+		},
+		{
+			name:      "should fail with fractional timers",
+			expectErr: true,
+			overrides: &BGPNeighborOverrideType{
+				HoldTime:      &metav1.Duration{Duration: 9500 * time.Millisecond},
+				KeepaliveTime: &metav1.Duration{Duration: 3 * time.Second},
+			},
+		},
+		{
+			name:      "should fail with keepaliveTime not lower than holdTime",
+			expectErr: true,
+			overrides: &BGPNeighborOverrideType{
+				HoldTime:      &metav1.Duration{Duration: 9 * time.Second},
+				KeepaliveTime: &metav1.Duration{Duration: 9 * time.Second},
+			},
+		},
+		{
+			name:      "should fail with keepaliveTime set and holdTime 0s",
+			expectErr: true,
+			overrides: &BGPNeighborOverrideType{
+				HoldTime:      &metav1.Duration{Duration: 0},
+				KeepaliveTime: &metav1.Duration{Duration: 3 * time.Second},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			spec := BGPConfigurationSpec{
+				FRRConfigurationNamespace: "metallb-system",
+				NeighborOverrides:         tt.overrides,
+			}
+
+			errs := spec.ValidateNeighborOverrides(field.NewPath("spec"))
+			if tt.expectErr {
+				g.Expect(errs).ToNot(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
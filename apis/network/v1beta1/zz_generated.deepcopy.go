@@ -24,6 +24,7 @@ import (
 	topologyv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -111,6 +112,11 @@ func (in *BGPConfigurationSpec) DeepCopyInto(out *BGPConfigurationSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NeighborOverrides != nil {
+		in, out := &in.NeighborOverrides, &out.NeighborOverrides
+		*out = new(BGPNeighborOverrideType)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPConfigurationSpec.
@@ -145,6 +151,31 @@ func (in *BGPConfigurationStatus) DeepCopy() *BGPConfigurationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BGPNeighborOverrideType) DeepCopyInto(out *BGPNeighborOverrideType) {
+	*out = *in
+	if in.HoldTime != nil {
+		in, out := &in.HoldTime, &out.HoldTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.KeepaliveTime != nil {
+		in, out := &in.KeepaliveTime, &out.KeepaliveTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BGPNeighborOverrideType.
+func (in *BGPNeighborOverrideType) DeepCopy() *BGPNeighborOverrideType {
+	if in == nil {
+		return nil
+	}
+	out := new(BGPNeighborOverrideType)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSData) DeepCopyInto(out *DNSData) {
 	*out = *in
@@ -34,6 +34,29 @@ const (
 	RabbitMQUserCleanupBlockedFinalizer = "rabbitmq.openstack.org/cleanup-blocked"
 )
 
+// RabbitMQ resource Condition Types used by API objects.
+const (
+	// DriftDetectedCondition Status=True condition which indicates that the state in RabbitMQ
+	// differs from the spec of a RabbitMQUser, RabbitMQVhost or RabbitMQPolicy and is not remediated
+	DriftDetectedCondition condition.Type = "DriftDetected"
+
+	// ScaleDownReadyCondition Status=True condition which indicates that the last scale down of a
+	// RabbitMq completed. Only set once the replicas of the RabbitMq got reduced
+	ScaleDownReadyCondition condition.Type = "ScaleDownReady"
+
+	// BrokerHealthyCondition Status=True condition which indicates that the health checks of the
+	// RabbitMQ cluster pass: no resource alarms, network partitions or quorum critical queues.
+	// Also set on TransportURLs while the cluster they reference has alarms in effect. The condition
+	// is informational, it doesn't affect the Ready condition
+	BrokerHealthyCondition condition.Type = "BrokerHealthy"
+
+	// UpgradeReadyCondition Status=True condition which indicates that the last upgrade of a
+	// RabbitMq to a new container image completed. Only set once the image of the RabbitMq changed.
+	// The condition is informational while the upgrade is in progress, a paused upgrade makes the
+	// RabbitMq not ready
+	UpgradeReadyCondition condition.Type = "UpgradeReady"
+)
+
 // TransportURL Reasons used by API objects.
 const ()
 
@@ -54,4 +77,77 @@ const (
 
 	// TransportURLInProgressMessage
 	TransportURLInProgressMessage = "TransportURL in progress"
+
+	//
+	// DriftDetected condition messages
+	//
+
+	// DriftDetectedMessage
+	DriftDetectedMessage = "State in RabbitMQ differs from the spec: %s"
+
+	//
+	// ScaleDownReady condition messages
+	//
+
+	// ScaleDownReadyMessage
+	ScaleDownReadyMessage = "Scale down completed"
+
+	// ScaleDownWaitingMessage
+	ScaleDownWaitingMessage = "Scale down waiting for the cluster to be ready"
+
+	// ScaleDownQuorumAtRiskMessage
+	ScaleDownQuorumAtRiskMessage = "Scale down blocked, quorum queues would lose their majority: %s"
+
+	// ScaleDownShrinkingMessage
+	ScaleDownShrinkingMessage = "Scale down in progress, removing the quorum queue members on %s"
+
+	// ScaleDownRemovingPodsMessage
+	ScaleDownRemovingPodsMessage = "Scale down in progress, removing the pods %s"
+
+	// ScaleDownForgettingNodesMessage
+	ScaleDownForgettingNodesMessage = "Scale down in progress, removing the departed nodes %s from the cluster"
+
+	// ScaleDownErrorMessage
+	ScaleDownErrorMessage = "Scale down error occured %s"
+
+	//
+	// BrokerHealthy condition messages
+	//
+
+	// BrokerHealthyInitMessage
+	BrokerHealthyInitMessage = "Broker health not checked"
+
+	// BrokerHealthyMessage
+	BrokerHealthyMessage = "Broker healthy"
+
+	// BrokerUnhealthyMessage
+	BrokerUnhealthyMessage = "Broker unhealthy, %s"
+
+	// BrokerAlarmsMessage
+	BrokerAlarmsMessage = "RabbitMQ cluster %s has alarms in effect, publishers are blocked: %s"
+
+	// BrokerHealthyErrorMessage
+	BrokerHealthyErrorMessage = "Broker health check error occured %s"
+
+	//
+	// UpgradeReady condition messages
+	//
+
+	// UpgradeReadyMessage
+	UpgradeReadyMessage = "Upgrade completed"
+
+	// UpgradePreflightMessage
+	UpgradePreflightMessage = "Upgrade to %s waiting for the pre-flight checks, %s"
+
+	// UpgradeRestartingMessage
+	UpgradeRestartingMessage = "Upgrade to %s in progress, restarting %s"
+
+	// UpgradeQuorumCriticalMessage
+	UpgradeQuorumCriticalMessage = "Upgrade to %s waiting, %s is quorum critical for the queues %s"
+
+	// UpgradePausedMessage
+	UpgradePausedMessage = "Upgrade to %s paused, %s"
+
+	// UpgradeErrorMessage
+	UpgradeErrorMessage = "Upgrade error occured %s"
 )
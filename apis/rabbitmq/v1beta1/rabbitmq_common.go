@@ -16,6 +16,15 @@ limitations under the License.
 
 package v1beta1
 
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultDriftDetectionInterval - default interval of the drift detection
+const DefaultDriftDetectionInterval = 5 * time.Minute
+
 // RabbitMqConfig defines RabbitMQ configuration parameters for consuming services
 type RabbitMqConfig struct {
 	// +kubebuilder:validation:Optional
@@ -50,3 +59,58 @@ func DefaultRabbitMqConfig(config *RabbitMqConfig, defaultClusterName string) {
 		config.Cluster = defaultClusterName
 	}
 }
+
+// DriftDetection defines how the state in RabbitMQ gets compared against the spec
+// to detect changes made outside of the operator, e.g. through the management UI
+type DriftDetection struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="5m"
+	// Interval - how often the state in RabbitMQ gets compared against the spec, 0 disables the periodic check
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	// Remediate - restore the state of the spec in RabbitMQ when it differs. If false, the differences
+	// are only reported in the DriftDetected condition until the spec changes
+	Remediate *bool `json:"remediate,omitempty"`
+}
+
+// GetInterval - returns the drift detection interval, the default if not set
+func (d *DriftDetection) GetInterval() time.Duration {
+	if d == nil || d.Interval == nil {
+		return DefaultDriftDetectionInterval
+	}
+	return d.Interval.Duration
+}
+
+// ShouldRemediate - returns true if drift should be remediated, which is the default
+func (d *DriftDetection) ShouldRemediate() bool {
+	return d == nil || d.Remediate == nil || *d.Remediate
+}
+
+// Adoption defines how an object which already exists in RabbitMQ, e.g. from an older
+// deployment, gets taken over by the operator
+type Adoption struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Enabled - check for an existing object in RabbitMQ before creating it and record in the
+	// status that it was adopted
+	Enabled bool `json:"enabled,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// DeleteOnRemoval - delete an adopted object from RabbitMQ when the CR gets deleted. By
+	// default adopted objects are kept in RabbitMQ, since the operator did not create them
+	DeleteOnRemoval bool `json:"deleteOnRemoval,omitempty"`
+}
+
+// IsEnabled - returns true if existing objects in RabbitMQ get adopted
+func (a *Adoption) IsEnabled() bool {
+	return a != nil && a.Enabled
+}
+
+// KeepAdopted - returns true if an object with the given adoption status must be kept in
+// RabbitMQ when the CR gets deleted
+func (a *Adoption) KeepAdopted(adopted *bool) bool {
+	return adopted != nil && *adopted && (a == nil || !a.DeleteOnRemoval)
+}
@@ -97,3 +97,28 @@ func TestDefaultRabbitMqConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestAdoptionKeepAdopted(t *testing.T) {
+	adopted := true
+	notAdopted := false
+	tests := []struct {
+		name     string
+		adoption *Adoption
+		adopted  *bool
+		want     bool
+	}{
+		{name: "adoption not checked", adoption: &Adoption{Enabled: true}, adopted: nil, want: false},
+		{name: "created by the operator", adoption: &Adoption{Enabled: true}, adopted: &notAdopted, want: false},
+		{name: "adopted", adoption: &Adoption{Enabled: true}, adopted: &adopted, want: true},
+		{name: "adopted with adoption removed from the spec", adoption: nil, adopted: &adopted, want: true},
+		{name: "adopted with deletion requested", adoption: &Adoption{Enabled: true, DeleteOnRemoval: true}, adopted: &adopted, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.adoption.KeepAdopted(tt.adopted); got != tt.want {
+				t.Errorf("KeepAdopted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
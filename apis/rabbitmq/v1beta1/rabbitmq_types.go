@@ -20,12 +20,16 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	rabbitmqv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -51,8 +55,66 @@ const (
 	QueueTypeQuorum = "Quorum"
 	// QueueTypeNone - no special queue type
 	QueueTypeNone = "None"
+
+	// DefaultOrphanCleanupInterval - default interval of the sweep for orphaned objects in RabbitMQ
+	DefaultOrphanCleanupInterval = time.Hour
+
+	// Queue migration phases
+	// QueueMigrationPhaseDraining - waiting for the consumers and messages of the classic queues to drain
+	QueueMigrationPhaseDraining = "Draining"
+	// QueueMigrationPhaseRescanning - the clients got switched to quorum queues, classic queues they
+	// declared before their switch get migrated
+	QueueMigrationPhaseRescanning = "Rescanning"
+	// QueueMigrationPhaseCompleted - all classic queues were replaced by quorum queues
+	QueueMigrationPhaseCompleted = "Completed"
+
+	// Scale down phases
+	// ScaleDownPhaseShrinkingQueues - removing the quorum queue members on the departing nodes
+	ScaleDownPhaseShrinkingQueues = "ShrinkingQueues"
+	// ScaleDownPhaseRemovingPods - waiting for the pods of the departing nodes to be removed
+	ScaleDownPhaseRemovingPods = "RemovingPods"
+
+	// Upgrade phases
+	// UpgradePhasePreflightChecks - checking the versions, feature flags and health of the cluster
+	UpgradePhasePreflightChecks = "PreflightChecks"
+	// UpgradePhaseRollingRestart - restarting the nodes one at a time with the new image
+	UpgradePhaseRollingRestart = "RollingRestart"
+	// UpgradePhasePaused - a check failed, the upgrade waits for the ContainerImage to change or
+	// the UpgradeResumeAnnotation
+	UpgradePhasePaused = "Paused"
+
+	// UpgradeResumeAnnotation - annotation of a RabbitMq which resumes a paused upgrade without
+	// changing the ContainerImage. It gets removed once the upgrade got resumed
+	UpgradeResumeAnnotation = "rabbitmq.openstack.org/resume-upgrade"
+
+	// Client certificate verification modes of the AMQP listener
+	// MTLSVerifyModeNone - client certificates are not verified
+	MTLSVerifyModeNone = "None"
+	// MTLSVerifyModeRequest - client certificates are verified if presented
+	MTLSVerifyModeRequest = "Request"
+	// MTLSVerifyModeRequire - clients must present a valid certificate
+	MTLSVerifyModeRequire = "Require"
+
+	// Stream protocol ports, exposed with streams enabled
+	// StreamPort - port of the stream protocol listener
+	StreamPort = 5552
+	// StreamTLSPort - port of the stream protocol listener with TLS
+	StreamTLSPort = 5551
 )
 
+// RabbitMqAllowedPlugins - plugins which can be enabled with the Plugins of a RabbitMq. The
+// plugins rabbitmq_management, rabbitmq_prometheus and rabbitmq_peer_discovery_k8s are always
+// enabled, rabbitmq_auth_mechanism_ssl is enabled with the EXTERNAL authentication of MTLS.
+var RabbitMqAllowedPlugins = []string{
+	"rabbitmq_consistent_hash_exchange",
+	"rabbitmq_federation",
+	"rabbitmq_federation_management",
+	"rabbitmq_shovel",
+	"rabbitmq_shovel_management",
+	"rabbitmq_stream",
+	"rabbitmq_stream_management",
+}
+
 // PodOverride defines per-pod service configurations
 type PodOverride struct {
 	// +kubebuilder:validation:Optional
@@ -61,6 +123,380 @@ type PodOverride struct {
 	Services []service.OverrideSpec `json:"services,omitempty"`
 }
 
+// OrphanCleanup defines the periodic sweep for users, vhosts and policies in RabbitMQ which
+// are not managed by any RabbitMQUser, RabbitMQVhost or RabbitMQPolicy of the cluster, e.g.
+// left over by force-deleted CRs. The default user and the default vhost "/" are never orphans.
+type OrphanCleanup struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="1h"
+	// Interval - how often RabbitMQ gets checked for orphaned objects, 0 disables the sweep
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Delete - delete orphaned objects from RabbitMQ instead of only reporting them in the status.
+	// Note that adopted objects which were kept in RabbitMQ when their CR got deleted are orphans too.
+	Delete bool `json:"delete,omitempty"`
+}
+
+// GetInterval - returns the orphan cleanup interval, the default if not set
+func (o *OrphanCleanup) GetInterval() time.Duration {
+	if o == nil || o.Interval == nil {
+		return DefaultOrphanCleanupInterval
+	}
+	return o.Interval.Duration
+}
+
+// ShouldDelete - returns true if orphaned objects get deleted from RabbitMQ
+func (o *OrphanCleanup) ShouldDelete() bool {
+	return o != nil && o.Delete
+}
+
+// MTLSSection contains mutual TLS configuration of the AMQP listener. Client certificates are
+// verified against the CA of the cluster, TLS must be enabled.
+type MTLSSection struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum="None";"Request";"Require"
+	// +kubebuilder:default="None"
+	// SslVerifyMode - verification of the client certificates. Request verifies certificates presented
+	// by clients, Require additionally rejects clients without a certificate
+	SslVerifyMode string `json:"sslVerifyMode,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// ExternalAuth - enable the EXTERNAL authentication mechanism of the rabbitmq_auth_mechanism_ssl plugin,
+	// which authenticates clients by the common name of their certificate instead of a password
+	ExternalAuth bool `json:"externalAuth,omitempty"`
+}
+
+// IsEnabled - returns true if client certificates get verified
+func (m *MTLSSection) IsEnabled() bool {
+	return m != nil && (m.SslVerifyMode == MTLSVerifyModeRequest || m.SslVerifyMode == MTLSVerifyModeRequire)
+}
+
+// UseExternalAuth - returns true if clients can authenticate with their certificate
+func (m *MTLSSection) UseExternalAuth() bool {
+	return m.IsEnabled() && m.ExternalAuth
+}
+
+// ManagementTLS defines how the operator verifies the TLS certificate of the RabbitMQ
+// management API. By default the certificate must be signed by the CA of the cluster.
+type ManagementTLS struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// UseSystemTrustStore - also trust the CAs of the system trust store of the operator,
+	// e.g. for clusters whose certificate is signed by a public CA
+	UseSystemTrustStore bool `json:"useSystemTrustStore,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// InsecureSkipVerify - do not verify the certificate of the management API. Only meant
+	// for testing, the credentials of the default user can be intercepted.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// ShouldUseSystemTrustStore - returns true if the system trust store is used to verify the management API
+func (m *ManagementTLS) ShouldUseSystemTrustStore() bool {
+	return m != nil && m.UseSystemTrustStore
+}
+
+// ShouldSkipVerify - returns true if the certificate of the management API is not verified
+func (m *ManagementTLS) ShouldSkipVerify() bool {
+	return m != nil && m.InsecureSkipVerify
+}
+
+// Tuning - typed RabbitMQ settings rendered into rabbitmq.conf. Settings of the Tuning replace
+// the defaults of the operator, e.g. vm_memory_high_watermark.relative = 0.6. Keys set in the raw
+// spec.rabbitmq.additionalConfig take precedence over both, the webhook warns about such conflicts.
+type Tuning struct {
+	// +kubebuilder:validation:Optional
+	// VMMemoryHighWatermark - fraction of the memory limit of the pod above which publishers get
+	// blocked (vm_memory_high_watermark.relative), between 0 and 1, e.g. "0.6"
+	VMMemoryHighWatermark *string `json:"vmMemoryHighWatermark,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// DiskFreeLimit - free disk space below which publishers get blocked (disk_free_limit.absolute)
+	DiskFreeLimit *resource.Quantity `json:"diskFreeLimit,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ChannelMax - maximum number of channels per connection (channel_max), 0 means unlimited
+	ChannelMax *int32 `json:"channelMax,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Heartbeat - heartbeat timeout in seconds proposed to clients (heartbeat), 0 disables heartbeats
+	Heartbeat *int32 `json:"heartbeat,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ConsumerTimeout - time after which a consumer which did not acknowledge a delivery gets
+	// its channel closed (consumer_timeout)
+	ConsumerTimeout *metav1.Duration `json:"consumerTimeout,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// CollectStatisticsInterval - interval of the statistics collection of the management
+	// plugin (collect_statistics_interval)
+	CollectStatisticsInterval *metav1.Duration `json:"collectStatisticsInterval,omitempty"`
+}
+
+// Settings - returns the settings of the Tuning in the rabbitmq.conf format
+func (t *Tuning) Settings() []string {
+	var settings []string
+	if t == nil {
+		return settings
+	}
+	if t.VMMemoryHighWatermark != nil {
+		settings = append(settings, fmt.Sprintf("vm_memory_high_watermark.relative = %s", *t.VMMemoryHighWatermark))
+	}
+	if t.DiskFreeLimit != nil {
+		settings = append(settings, fmt.Sprintf("disk_free_limit.absolute = %d", t.DiskFreeLimit.Value()))
+	}
+	if t.ChannelMax != nil {
+		settings = append(settings, fmt.Sprintf("channel_max = %d", *t.ChannelMax))
+	}
+	if t.Heartbeat != nil {
+		settings = append(settings, fmt.Sprintf("heartbeat = %d", *t.Heartbeat))
+	}
+	if t.ConsumerTimeout != nil {
+		settings = append(settings, fmt.Sprintf("consumer_timeout = %d", t.ConsumerTimeout.Milliseconds()))
+	}
+	if t.CollectStatisticsInterval != nil {
+		settings = append(settings, fmt.Sprintf("collect_statistics_interval = %d", t.CollectStatisticsInterval.Milliseconds()))
+	}
+	return settings
+}
+
+// ConfigKey - returns the key of a rabbitmq.conf line, empty for comments and lines without a key
+func ConfigKey(line string) string {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ""
+	}
+	key, _, found := strings.Cut(line, "=")
+	if !found {
+		return ""
+	}
+	return strings.TrimSpace(key)
+}
+
+// ConfigKeys - returns the keys set in a rabbitmq.conf snippet, e.g. the AdditionalConfig
+func ConfigKeys(config string) []string {
+	var keys []string
+	for _, line := range strings.Split(config, "\n") {
+		if key := ConfigKey(line); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// StreamsSection configures stream queues for selected vhosts. Streams are append-only logs with
+// non-destructive consumers, a good fit for high-volume, fan-out and replayable messages like
+// notifications. The QueueType of the cluster keeps applying to all other queues.
+type StreamsSection struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	// Vhosts - vhosts whose TransportURLs are stream aware and whose streams get the retention policy
+	Vhosts []string `json:"vhosts"`
+
+	// +kubebuilder:validation:Optional
+	// MaxLengthBytes - maximum size of a stream (max-length-bytes), the oldest segments get truncated
+	MaxLengthBytes *resource.Quantity `json:"maxLengthBytes,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(Y|M|D|h|m|s)$`
+	// MaxAge - maximum age of the messages of a stream (max-age), e.g. 7D or 12h
+	MaxAge string `json:"maxAge,omitempty"`
+}
+
+// IsEnabled - returns true if streams are enabled for any vhost
+func (s *StreamsSection) IsEnabled() bool {
+	return s != nil && len(s.Vhosts) > 0
+}
+
+// HasVhost - returns true if streams are enabled for the vhost
+func (s *StreamsSection) HasVhost(vhost string) bool {
+	return s != nil && slices.Contains(s.Vhosts, vhost)
+}
+
+// PolicyDefinition - returns the definition of the retention policy of the streams, nil
+// without retention settings
+func (s *StreamsSection) PolicyDefinition() map[string]interface{} {
+	if s == nil || (s.MaxLengthBytes == nil && s.MaxAge == "") {
+		return nil
+	}
+	definition := map[string]interface{}{}
+	if s.MaxLengthBytes != nil {
+		definition["max-length-bytes"] = s.MaxLengthBytes.Value()
+	}
+	if s.MaxAge != "" {
+		definition["max-age"] = s.MaxAge
+	}
+	return definition
+}
+
+// RabbitMqOrphans - orphaned objects found in RabbitMQ by the last sweep
+type RabbitMqOrphans struct {
+	// LastSweepTime - time of the last sweep
+	LastSweepTime *metav1.Time `json:"lastSweepTime,omitempty"`
+
+	// +listType=atomic
+	// Users - orphaned users
+	Users []string `json:"users,omitempty"`
+
+	// +listType=atomic
+	// Vhosts - orphaned vhosts
+	Vhosts []string `json:"vhosts,omitempty"`
+
+	// +listType=atomic
+	// Policies - orphaned policies in the format <vhost>/<name>
+	Policies []string `json:"policies,omitempty"`
+}
+
+// RabbitMqQueueMigration - progress of the migration of the mirrored classic queues to quorum
+// queues, started when the QueueType changes from Mirrored to Quorum
+type RabbitMqQueueMigration struct {
+	// Phase - Draining while queues still have consumers or messages, Rescanning after the
+	// clients got switched to quorum queues, Completed afterwards
+	Phase string `json:"phase,omitempty"`
+
+	// StartTime - time the migration started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// SwitchTime - time the clients got switched to quorum queues
+	SwitchTime *metav1.Time `json:"switchTime,omitempty"`
+
+	// CompletionTime - time the migration completed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// +listType=map
+	// +listMapKey=name
+	// Vhosts - migration state of each vhost
+	Vhosts []RabbitMqVhostQueueMigration `json:"vhosts,omitempty"`
+}
+
+// RabbitMqScaleDown - progress of a scale down of the cluster. The pods of the departing nodes
+// are only removed after their quorum queue members got removed
+type RabbitMqScaleDown struct {
+	// Replicas - number of replicas the cluster is scaled down from
+	Replicas int32 `json:"replicas"`
+
+	// TargetReplicas - number of replicas the cluster is scaled down to
+	TargetReplicas int32 `json:"targetReplicas"`
+
+	// Phase - ShrinkingQueues while the quorum queue members on the departing nodes get removed,
+	// RemovingPods afterwards
+	Phase string `json:"phase,omitempty"`
+
+	// StartTime - time the scale down started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// +listType=atomic
+	// ShrunkNodes - departing nodes whose quorum queue members were removed
+	ShrunkNodes []string `json:"shrunkNodes,omitempty"`
+}
+
+// RabbitMqUpgrade - progress of an upgrade of the cluster to a new container image. The nodes
+// are restarted one at a time, from the highest ordinal down, each only once the previous one runs
+// the new image and the node is not quorum critical. A failed check pauses the upgrade, a paused
+// upgrade is retried by changing the ContainerImage or with the UpgradeResumeAnnotation. Restoring
+// FromImage cancels it until the first node got restarted, RabbitMQ nodes can't be downgraded.
+type RabbitMqUpgrade struct {
+	// FromImage - container image the cluster is upgraded from
+	FromImage string `json:"fromImage"`
+
+	// ToImage - container image the cluster is upgraded to
+	ToImage string `json:"toImage"`
+
+	// FromVersion - RabbitMQ version all nodes ran before the upgrade
+	FromVersion string `json:"fromVersion,omitempty"`
+
+	// ToVersion - RabbitMQ version of the new image, from the image tag or the first restarted node
+	ToVersion string `json:"toVersion,omitempty"`
+
+	// Phase - PreflightChecks before the first node is restarted, RollingRestart while the nodes
+	// get restarted and Paused after a failed check
+	Phase string `json:"phase,omitempty"`
+
+	// Message - reason the upgrade is paused
+	Message string `json:"message,omitempty"`
+
+	// Partition - lowest ordinal of the pods which run the new image
+	Partition int32 `json:"partition"`
+
+	// StartTime - time the upgrade started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// LastRestartTime - time the last node was restarted
+	LastRestartTime *metav1.Time `json:"lastRestartTime,omitempty"`
+
+	// +listType=atomic
+	// Nodes - RabbitMQ version of each node of the cluster
+	Nodes []RabbitMqNodeVersion `json:"nodes,omitempty"`
+}
+
+// RabbitMqNodeVersion - RabbitMQ version running on a node
+type RabbitMqNodeVersion struct {
+	// Name - name of the node
+	Name string `json:"name"`
+
+	// Version - RabbitMQ version running on the node, empty if the node is not running
+	Version string `json:"version,omitempty"`
+}
+
+// RabbitMqHealth - result of the last health check of the cluster
+type RabbitMqHealth struct {
+	// LastCheckTime - time of the last health check
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+
+	// +listType=atomic
+	// Alarms - resource alarms in effect in the format <node>/<resource>, e.g. a memory or disk
+	// alarm. Publishers are blocked while an alarm is in effect
+	Alarms []string `json:"alarms,omitempty"`
+
+	// +listType=atomic
+	// Partitions - nodes which are partitioned from other nodes of the cluster
+	Partitions []string `json:"partitions,omitempty"`
+
+	// +listType=atomic
+	// QuorumCriticalQueues - quorum queues in the format <vhost>/<name> which would lose their
+	// majority if another node got stopped
+	QuorumCriticalQueues []string `json:"quorumCriticalQueues,omitempty"`
+}
+
+// RabbitMqVhostQueueMigration - migration state of the queues of a vhost
+type RabbitMqVhostQueueMigration struct {
+	// Name - name of the vhost
+	Name string `json:"name"`
+
+	// Phase - Draining while queues of the vhost still have consumers or messages, Completed once
+	// it has no classic queues left. Vhosts get checked again until the migration completes.
+	Phase string `json:"phase,omitempty"`
+
+	// +listType=atomic
+	// Pending - classic queues which still have consumers or messages
+	Pending []string `json:"pending,omitempty"`
+
+	// Migrated - number of classic queues which were deleted, durable ones got recreated as quorum queues
+	Migrated int32 `json:"migrated,omitempty"`
+}
+
+// GetVhost - returns the migration state of the vhost, adding it if it doesn't exist yet
+func (m *RabbitMqQueueMigration) GetVhost(name string) *RabbitMqVhostQueueMigration {
+	for i := range m.Vhosts {
+		if m.Vhosts[i].Name == name {
+			return &m.Vhosts[i]
+		}
+	}
+	m.Vhosts = append(m.Vhosts, RabbitMqVhostQueueMigration{Name: name, Phase: QueueMigrationPhaseDraining})
+	return &m.Vhosts[len(m.Vhosts)-1]
+}
+
+// IsRescanning - returns true if the clients got switched to quorum queues and the vhosts are
+// still checked for classic queues
+func (m *RabbitMqQueueMigration) IsRescanning() bool {
+	return m != nil && m.Phase == QueueMigrationPhaseRescanning
+}
+
 // RabbitMqSpec defines the desired state of RabbitMq
 type RabbitMqSpec struct {
 	RabbitMqSpecCore `json:",inline"`
@@ -93,6 +529,42 @@ type RabbitMqSpecCore struct {
 	// services will be created for each pod with the provided configuration, and the transport URL will be
 	// configured to use these per-pod services.
 	PodOverride *PodOverride `json:"podOverride,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// OrphanCleanup - periodic sweep for users, vhosts and policies in RabbitMQ which are not managed
+	// by any CR. Orphans are reported in the status and only deleted if requested
+	OrphanCleanup *OrphanCleanup `json:"orphanCleanup,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// ManagementTLS - verification of the TLS certificate of the management API used by the operator
+	ManagementTLS *ManagementTLS `json:"managementTLS,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// MTLS - mutual TLS authentication of the clients connecting to the AMQP listener
+	MTLS *MTLSSection `json:"mtls,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Plugins - additional plugins to enable on top of the plugins enabled by default. Allowed values are:
+	// rabbitmq_consistent_hash_exchange, rabbitmq_federation, rabbitmq_federation_management, rabbitmq_shovel,
+	// rabbitmq_shovel_management, rabbitmq_stream, rabbitmq_stream_management
+	Plugins []string `json:"plugins,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// PrometheusDetailedMetrics - return per queue, exchange and connection metrics on the /metrics endpoint
+	// of the prometheus plugin instead of aggregated metrics. Can be expensive with many objects
+	PrometheusDetailedMetrics bool `json:"prometheusDetailedMetrics,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Tuning - typed settings like memory and disk limits rendered into rabbitmq.conf, preferred over
+	// raw settings in spec.rabbitmq.additionalConfig
+	Tuning *Tuning `json:"tuning,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// Streams - stream queues for high-volume, replayable messages like notifications in selected vhosts.
+	// Enables the rabbitmq_stream plugin and exposes the stream port
+	Streams *StreamsSection `json:"streams,omitempty"`
 }
 
 // MarshalInto converts RabbitMqSpec to RabbitmqClusterSpec.
@@ -133,6 +605,30 @@ type RabbitMqStatus struct {
 	// When populated, transport URLs use these hostnames instead of pod names.
 	// +listType=atomic
 	ServiceHostnames []string `json:"serviceHostnames,omitempty"`
+
+	// Orphans - users, vhosts and policies in RabbitMQ which are not managed by any CR
+	Orphans *RabbitMqOrphans `json:"orphans,omitempty"`
+
+	// QueueMigration - progress of the migration from mirrored to quorum queues
+	QueueMigration *RabbitMqQueueMigration `json:"queueMigration,omitempty"`
+
+	// ScaleDown - progress of the scale down of the cluster
+	ScaleDown *RabbitMqScaleDown `json:"scaleDown,omitempty"`
+
+	// EnabledPlugins - plugins enabled on all running nodes of the cluster
+	// +listType=atomic
+	EnabledPlugins []string `json:"enabledPlugins,omitempty"`
+
+	// PendingFeatureFlags - stable feature flags which are not enabled yet. They get enabled
+	// once every node of the cluster runs the same RabbitMQ version
+	// +listType=atomic
+	PendingFeatureFlags []string `json:"pendingFeatureFlags,omitempty"`
+
+	// Health - result of the last health check of the cluster
+	Health *RabbitMqHealth `json:"health,omitempty"`
+
+	// Upgrade - progress of the upgrade of the cluster to a new container image
+	Upgrade *RabbitMqUpgrade `json:"upgrade,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -168,6 +664,21 @@ func (instance RabbitMq) IsReady() bool {
 	return instance.Status.Conditions.IsTrue(condition.ReadyCondition)
 }
 
+// IsQueueMigrationPending - returns true if the QueueType changed from Mirrored to Quorum and
+// the existing queues are not migrated yet. Clients keep using classic queues until then.
+func (instance RabbitMq) IsQueueMigrationPending() bool {
+	return instance.Spec.QueueType != nil && *instance.Spec.QueueType == QueueTypeQuorum &&
+		instance.Status.QueueType == QueueTypeMirrored
+}
+
+// GetAlarms - returns the resource alarms in effect found by the last health check
+func (instance RabbitMq) GetAlarms() []string {
+	if instance.Status.Health == nil {
+		return nil
+	}
+	return instance.Status.Health.Alarms
+}
+
 // RbacConditionsSet - set the conditions for the rbac object
 func (instance RabbitMq) RbacConditionsSet(c *condition.Condition) {
 	instance.Status.Conditions.Set(c)
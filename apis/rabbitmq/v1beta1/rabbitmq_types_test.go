@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestTuningSettings(t *testing.T) {
+	var empty *Tuning
+	if settings := empty.Settings(); len(settings) != 0 {
+		t.Errorf("Expected no settings without a Tuning, got %v", settings)
+	}
+
+	diskFreeLimit := resource.MustParse("2Gi")
+	tuning := &Tuning{
+		VMMemoryHighWatermark:     ptr.To("0.4"),
+		DiskFreeLimit:             &diskFreeLimit,
+		ChannelMax:                ptr.To[int32](128),
+		Heartbeat:                 ptr.To[int32](30),
+		ConsumerTimeout:           &metav1.Duration{Duration: 30 * time.Minute},
+		CollectStatisticsInterval: &metav1.Duration{Duration: 10 * time.Second},
+	}
+	want := []string{
+		"vm_memory_high_watermark.relative = 0.4",
+		"disk_free_limit.absolute = 2147483648",
+		"channel_max = 128",
+		"heartbeat = 30",
+		"consumer_timeout = 1800000",
+		"collect_statistics_interval = 10000",
+	}
+	if got := tuning.Settings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Settings() = %v, want %v", got, want)
+	}
+}
+
+func TestConfigKeys(t *testing.T) {
+	config := "# comment\nchannel_max = 64\n\n  heartbeat=10  \ninvalid line\n"
+
+	want := []string{"channel_max", "heartbeat"}
+	if got := ConfigKeys(config); !reflect.DeepEqual(got, want) {
+		t.Errorf("ConfigKeys() = %v, want %v", got, want)
+	}
+}
+
+func TestStreamsPolicyDefinition(t *testing.T) {
+	var empty *StreamsSection
+	if empty.IsEnabled() || empty.HasVhost("/") {
+		t.Errorf("Expected streams to be disabled without a StreamsSection")
+	}
+	if definition := empty.PolicyDefinition(); definition != nil {
+		t.Errorf("Expected no policy definition without a StreamsSection, got %v", definition)
+	}
+
+	streams := &StreamsSection{Vhosts: []string{"ceilometer"}}
+	if !streams.HasVhost("ceilometer") || streams.HasVhost("/") {
+		t.Errorf("HasVhost() doesn't match the vhosts %v", streams.Vhosts)
+	}
+	if definition := streams.PolicyDefinition(); definition != nil {
+		t.Errorf("Expected no policy definition without retention settings, got %v", definition)
+	}
+
+	maxLengthBytes := resource.MustParse("20Gi")
+	streams.MaxLengthBytes = &maxLengthBytes
+	streams.MaxAge = "7D"
+	want := map[string]interface{}{
+		"max-length-bytes": int64(21474836480),
+		"max-age":          "7D",
+	}
+	if got := streams.PolicyDefinition(); !reflect.DeepEqual(got, want) {
+		t.Errorf("PolicyDefinition() = %v, want %v", got, want)
+	}
+}
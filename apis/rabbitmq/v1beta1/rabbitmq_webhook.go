@@ -18,6 +18,9 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strconv"
 	"time"
 
 	common_webhook "github.com/openstack-k8s-operators/lib-common/modules/common/webhook"
@@ -215,6 +218,16 @@ func (r *RabbitMq) ValidateCreate() (admission.Warnings, error) {
 	// Validate QueueType if specified
 	allErrs = append(allErrs, r.Spec.ValidateQueueType(basePath)...)
 
+	allErrs = append(allErrs, r.Spec.ValidateMTLS(basePath)...)
+
+	allErrs = append(allErrs, r.Spec.ValidatePlugins(basePath)...)
+
+	allErrs = append(allErrs, r.Spec.ValidateStreams(basePath)...)
+
+	warn, errs = r.Spec.ValidateTuning(basePath)
+	allWarn = append(allWarn, warn...)
+	allErrs = append(allErrs, errs...)
+
 	if len(allErrs) != 0 {
 		return allWarn, apierrors.NewInvalid(
 			schema.GroupKind{Group: "rabbitmq.openstack.org", Kind: "RabbitMq"},
@@ -245,6 +258,16 @@ func (r *RabbitMq) ValidateUpdate(_ runtime.Object) (admission.Warnings, error)
 	// Validate QueueType if specified
 	allErrs = append(allErrs, r.Spec.ValidateQueueType(basePath)...)
 
+	allErrs = append(allErrs, r.Spec.ValidateMTLS(basePath)...)
+
+	allErrs = append(allErrs, r.Spec.ValidatePlugins(basePath)...)
+
+	allErrs = append(allErrs, r.Spec.ValidateStreams(basePath)...)
+
+	warn, errs = r.Spec.ValidateTuning(basePath)
+	allWarn = append(allWarn, warn...)
+	allErrs = append(allErrs, errs...)
+
 	if len(allErrs) != 0 {
 		return allWarn, apierrors.NewInvalid(
 			schema.GroupKind{Group: "rabbitmq.openstack.org", Kind: "RabbitMq"},
@@ -266,6 +289,12 @@ func (spec *RabbitMqSpecCore) ValidateCreate(basePath *field.Path, namespace str
 	warn, errs := spec.ValidateOverride(basePath, namespace)
 	allWarn = append(allWarn, warn...)
 	allErrs = append(allErrs, errs...)
+	allErrs = append(allErrs, spec.ValidateMTLS(basePath)...)
+	allErrs = append(allErrs, spec.ValidatePlugins(basePath)...)
+	allErrs = append(allErrs, spec.ValidateStreams(basePath)...)
+	warn, errs = spec.ValidateTuning(basePath)
+	allWarn = append(allWarn, warn...)
+	allErrs = append(allErrs, errs...)
 
 	return allWarn, allErrs
 }
@@ -281,6 +310,12 @@ func (spec *RabbitMqSpecCore) ValidateUpdate(_ RabbitMqSpecCore, basePath *field
 	warn, errs := spec.ValidateOverride(basePath, namespace)
 	allWarn = append(allWarn, warn...)
 	allErrs = append(allErrs, errs...)
+	allErrs = append(allErrs, spec.ValidateMTLS(basePath)...)
+	allErrs = append(allErrs, spec.ValidatePlugins(basePath)...)
+	allErrs = append(allErrs, spec.ValidateStreams(basePath)...)
+	warn, errs = spec.ValidateTuning(basePath)
+	allWarn = append(allWarn, warn...)
+	allErrs = append(allErrs, errs...)
 
 	return allWarn, allErrs
 }
@@ -317,3 +352,146 @@ func (spec *RabbitMqSpec) ValidateQueueType(basePath *field.Path) field.ErrorLis
 
 	return allErrs
 }
+
+// ValidateMTLS validates that mutual TLS is only enabled with TLS and that the EXTERNAL
+// authentication mechanism is only enabled with client certificate verification
+func (spec *RabbitMqSpecCore) ValidateMTLS(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.MTLS == nil {
+		return allErrs
+	}
+	mtlsPath := basePath.Child("mtls")
+	if spec.MTLS.IsEnabled() && spec.TLS.SecretName == "" {
+		allErrs = append(allErrs, field.Invalid(
+			mtlsPath.Child("sslVerifyMode"),
+			spec.MTLS.SslVerifyMode,
+			"requires TLS to be enabled with spec.tls.secretName",
+		))
+	}
+	if spec.MTLS.ExternalAuth && !spec.MTLS.IsEnabled() {
+		allErrs = append(allErrs, field.Invalid(
+			mtlsPath.Child("externalAuth"),
+			spec.MTLS.ExternalAuth,
+			"requires sslVerifyMode Request or Require",
+		))
+	}
+
+	return allErrs
+}
+
+// ValidatePlugins validates that only plugins of the allow-list get enabled
+func (spec *RabbitMqSpecCore) ValidatePlugins(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, plugin := range spec.Plugins {
+		if !slices.Contains(RabbitMqAllowedPlugins, plugin) {
+			allErrs = append(allErrs, field.NotSupported(
+				basePath.Child("plugins").Index(i),
+				plugin,
+				RabbitMqAllowedPlugins,
+			))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateStreams validates the vhosts and the retention of the streams
+func (spec *RabbitMqSpecCore) ValidateStreams(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.Streams == nil {
+		return allErrs
+	}
+	streamsPath := basePath.Child("streams")
+
+	for i, vhost := range spec.Streams.Vhosts {
+		// "/" is the default vhost and is always valid
+		if vhost == "/" {
+			continue
+		}
+		if err := validateRabbitMQName(vhost, "vhost"); err != nil {
+			allErrs = append(allErrs, field.Invalid(streamsPath.Child("vhosts").Index(i), vhost, err.Error()))
+		}
+	}
+	if spec.Streams.MaxLengthBytes != nil && spec.Streams.MaxLengthBytes.Sign() <= 0 {
+		allErrs = append(allErrs, field.Invalid(
+			streamsPath.Child("maxLengthBytes"),
+			spec.Streams.MaxLengthBytes.String(),
+			"must be greater than 0",
+		))
+	}
+
+	return allErrs
+}
+
+// ValidateTuning validates the ranges of the Tuning settings and warns about keys which are
+// also set in spec.rabbitmq.additionalConfig, where they take precedence over the Tuning
+func (spec *RabbitMqSpecCore) ValidateTuning(basePath *field.Path) (admission.Warnings, field.ErrorList) {
+	var allErrs field.ErrorList
+	var allWarn []string
+
+	if spec.Tuning == nil {
+		return allWarn, allErrs
+	}
+	tuning := spec.Tuning
+	tuningPath := basePath.Child("tuning")
+
+	if tuning.VMMemoryHighWatermark != nil {
+		watermark, err := strconv.ParseFloat(*tuning.VMMemoryHighWatermark, 64)
+		if err != nil || watermark <= 0 || watermark > 1 {
+			allErrs = append(allErrs, field.Invalid(
+				tuningPath.Child("vmMemoryHighWatermark"),
+				*tuning.VMMemoryHighWatermark,
+				"must be a number greater than 0 and at most 1",
+			))
+		}
+	}
+	if tuning.DiskFreeLimit != nil && tuning.DiskFreeLimit.Sign() <= 0 {
+		allErrs = append(allErrs, field.Invalid(
+			tuningPath.Child("diskFreeLimit"),
+			tuning.DiskFreeLimit.String(),
+			"must be greater than 0",
+		))
+	}
+	if tuning.ChannelMax != nil && (*tuning.ChannelMax < 0 || *tuning.ChannelMax > 65535) {
+		allErrs = append(allErrs, field.Invalid(
+			tuningPath.Child("channelMax"),
+			*tuning.ChannelMax,
+			"must be between 0 and 65535",
+		))
+	}
+	if tuning.Heartbeat != nil && (*tuning.Heartbeat < 0 || *tuning.Heartbeat > 65535) {
+		allErrs = append(allErrs, field.Invalid(
+			tuningPath.Child("heartbeat"),
+			*tuning.Heartbeat,
+			"must be between 0 and 65535",
+		))
+	}
+	if tuning.ConsumerTimeout != nil && tuning.ConsumerTimeout.Milliseconds() <= 0 {
+		allErrs = append(allErrs, field.Invalid(
+			tuningPath.Child("consumerTimeout"),
+			tuning.ConsumerTimeout.String(),
+			"must be at least 1ms",
+		))
+	}
+	if tuning.CollectStatisticsInterval != nil && tuning.CollectStatisticsInterval.Milliseconds() <= 0 {
+		allErrs = append(allErrs, field.Invalid(
+			tuningPath.Child("collectStatisticsInterval"),
+			tuning.CollectStatisticsInterval.String(),
+			"must be at least 1ms",
+		))
+	}
+
+	additionalKeys := ConfigKeys(spec.Rabbitmq.AdditionalConfig)
+	for _, setting := range tuning.Settings() {
+		if key := ConfigKey(setting); slices.Contains(additionalKeys, key) {
+			allWarn = append(allWarn, fmt.Sprintf(
+				"%s: %s is also set in %s, the value of the additionalConfig takes precedence",
+				tuningPath, key, basePath.Child("rabbitmq", "additionalConfig")))
+		}
+	}
+
+	return allWarn, allErrs
+}
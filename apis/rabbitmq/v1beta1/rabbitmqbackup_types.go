@@ -0,0 +1,185 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RabbitMQBackupStorageType - where the backups get stored
+type RabbitMQBackupStorageType string
+
+const (
+	// RabbitMQBackupStorageSecret - each backup is stored in its own Secret
+	RabbitMQBackupStorageSecret RabbitMQBackupStorageType = "Secret"
+	// RabbitMQBackupStoragePVC - backups are written as files to a PersistentVolumeClaim
+	RabbitMQBackupStoragePVC RabbitMQBackupStorageType = "PVC"
+
+	// RabbitMQBackupDefinitionsKey - key of the definitions in the backup Secrets
+	RabbitMQBackupDefinitionsKey = "definitions.json"
+)
+
+// RabbitMQBackupStorage defines where the backups get stored
+type RabbitMQBackupStorage struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Secret;PVC
+	// +kubebuilder:default=Secret
+	// Type - Secret stores each backup in its own Secret with the definitions in the definitions.json key,
+	// PVC writes each backup to a <backup name>.json file on the PersistentVolumeClaim ClaimName
+	Type RabbitMQBackupStorageType `json:"type"`
+
+	// +kubebuilder:validation:Optional
+	// ClaimName - name of the PersistentVolumeClaim the backups get written to, required with type PVC
+	ClaimName string `json:"claimName,omitempty"`
+}
+
+// RabbitMQBackupSpec defines the desired state of RabbitMQBackup
+type RabbitMQBackupSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// RabbitmqClusterName - the name of the RabbitMq cluster to export the definitions of
+	RabbitmqClusterName string `json:"rabbitmqClusterName"`
+
+	// +kubebuilder:validation:Optional
+	// Schedule - take backups on a cron schedule in UTC with the five fields minute, hour,
+	// day of month, month and day of week, e.g. "0 2 * * *". The descriptors @yearly, @monthly,
+	// @weekly and @daily are supported as well. Without a schedule a single backup gets taken
+	Schedule string `json:"schedule,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Storage - where the backups get stored
+	Storage RabbitMQBackupStorage `json:"storage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=7
+	// Retention - number of backups to keep, older backups get deleted
+	Retention int32 `json:"retention"`
+}
+
+// RabbitMQBackupEntry is a backup which was taken
+type RabbitMQBackupEntry struct {
+	// Name - name of the backup, the name of the Secret or of the file on the PersistentVolumeClaim
+	// without the .json extension
+	Name string `json:"name"`
+
+	// Time - when the backup was taken
+	Time metav1.Time `json:"time"`
+}
+
+// RabbitMQBackupStatus defines the observed state of RabbitMQBackup
+type RabbitMQBackupStatus struct {
+	// Conditions
+	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
+
+	// ObservedGeneration - the most recent generation observed for this resource
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +listType=atomic
+	// Backups - the backups which are kept, oldest first
+	Backups []RabbitMQBackupEntry `json:"backups,omitempty"`
+
+	// LastBackupTime - when the last backup was taken
+	LastBackupTime *metav1.Time `json:"lastBackupTime,omitempty"`
+
+	// NextBackupTime - when the next scheduled backup is taken
+	NextBackupTime *metav1.Time `json:"nextBackupTime,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=rabbitmqbackups,shortName=rmqbackup,categories=all;rabbitmq
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.rabbitmqClusterName"
+//+kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule"
+//+kubebuilder:printcolumn:name="LastBackup",type="date",JSONPath=".status.lastBackupTime"
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message"
+
+// RabbitMQBackup is the Schema for the rabbitmqbackups API. It exports the definitions
+// of a RabbitMq cluster, once or on a schedule, and keeps the last Retention backups.
+type RabbitMQBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RabbitMQBackupSpec   `json:"spec,omitempty"`
+	Status RabbitMQBackupStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RabbitMQBackupList contains a list of RabbitMQBackup
+type RabbitMQBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RabbitMQBackup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RabbitMQBackup{}, &RabbitMQBackupList{})
+}
+
+// IsReady returns true if the backup is ready
+func (instance RabbitMQBackup) IsReady() bool {
+	return instance.Status.Conditions.IsTrue(condition.ReadyCondition)
+}
+
+// NextBackup - returns the time of the next scheduled backup after last
+func (spec *RabbitMQBackupSpec) NextBackup(last time.Time) (time.Time, error) {
+	schedule, err := parseRotationSchedule(spec.Schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return schedule.next(last)
+}
+
+// IsComplete - returns true if the backup was completely written. A backup stored on
+// a PersistentVolumeClaim is in progress until LastBackupTime caught up with it.
+func (status *RabbitMQBackupStatus) IsComplete(backup *RabbitMQBackupEntry) bool {
+	return status.LastBackupTime != nil && !backup.Time.After(status.LastBackupTime.Time)
+}
+
+// GetBackup - returns the completed backup with the given name, or the latest completed
+// backup if name is empty. Returns nil if there is no such backup.
+func (status *RabbitMQBackupStatus) GetBackup(name string) *RabbitMQBackupEntry {
+	for i := len(status.Backups) - 1; i >= 0; i-- {
+		backup := &status.Backups[i]
+		if (name == "" || backup.Name == name) && status.IsComplete(backup) {
+			return backup
+		}
+	}
+	return nil
+}
+
+const (
+	// RabbitMQBackupReadyCondition indicates that the backup is ready
+	RabbitMQBackupReadyCondition condition.Type = "RabbitMQBackupReady"
+
+	// RabbitMQBackupReadyMessage is the message for the RabbitMQBackupReady condition
+	RabbitMQBackupReadyMessage = "RabbitMQ backup is ready"
+
+	// RabbitMQBackupReadyInitMessage is the message for the RabbitMQBackupReady condition when not started
+	RabbitMQBackupReadyInitMessage = "RabbitMQ backup not started"
+
+	// RabbitMQBackupReadyRunningMessage is the message format for the RabbitMQBackupReady condition while a backup is written
+	RabbitMQBackupReadyRunningMessage = "RabbitMQ backup %s in progress"
+
+	// RabbitMQBackupReadyErrorMessage is the message format for the RabbitMQBackupReady condition when an error occurs
+	RabbitMQBackupReadyErrorMessage = "RabbitMQ backup error occurred %s"
+)
@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var rabbitmqbackuplog = logf.Log.WithName("rabbitmqbackup-resource")
+
+// Default implements defaulting for RabbitMQBackup
+func (r *RabbitMQBackup) Default(_ client.Client) {
+	rabbitmqbackuplog.Info("default", "name", r.Name)
+
+	if r.Spec.Storage.Type == "" {
+		r.Spec.Storage.Type = RabbitMQBackupStorageSecret
+	}
+}
+
+// ValidateCreate validates the RabbitMQBackup on creation
+func (r *RabbitMQBackup) ValidateCreate(_ client.Client) (admission.Warnings, error) {
+	rabbitmqbackuplog.Info("validate create", "name", r.Name)
+
+	allErrs := r.Spec.validate(field.NewPath("spec"))
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "rabbitmq.openstack.org", Kind: "RabbitMQBackup"},
+			r.Name,
+			allErrs,
+		)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate validates the RabbitMQBackup on update
+func (r *RabbitMQBackup) ValidateUpdate(_ client.Client, old runtime.Object) (admission.Warnings, error) {
+	rabbitmqbackuplog.Info("validate update", "name", r.Name)
+
+	oldBackup, ok := old.(*RabbitMQBackup)
+	if !ok {
+		return nil, fmt.Errorf("expected RabbitMQBackup but got %T", old)
+	}
+
+	basePath := field.NewPath("spec")
+	allErrs := r.Spec.validate(basePath)
+
+	// The backups in the status belong to the cluster and the storage they
+	// were taken from, changing them would mix up or lose track of backups
+	if r.Spec.RabbitmqClusterName != oldBackup.Spec.RabbitmqClusterName {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("rabbitmqClusterName"), "cluster cannot be changed after creation"))
+	}
+	if r.Spec.Storage != oldBackup.Spec.Storage {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("storage"), "storage cannot be changed after creation"))
+	}
+
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "rabbitmq.openstack.org", Kind: "RabbitMQBackup"},
+			r.Name,
+			allErrs,
+		)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete validates the RabbitMQBackup on deletion
+func (r *RabbitMQBackup) ValidateDelete(_ client.Client) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate validates the schedule and the storage of the backup
+func (spec *RabbitMQBackupSpec) validate(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.Schedule != "" {
+		if _, err := parseRotationSchedule(spec.Schedule); err != nil {
+			allErrs = append(allErrs, field.Invalid(basePath.Child("schedule"), spec.Schedule, err.Error()))
+		}
+	}
+
+	storagePath := basePath.Child("storage")
+	switch spec.Storage.Type {
+	case RabbitMQBackupStoragePVC:
+		if spec.Storage.ClaimName == "" {
+			allErrs = append(allErrs, field.Required(storagePath.Child("claimName"), "claimName is required with storage type PVC"))
+		}
+	default:
+		if spec.Storage.ClaimName != "" {
+			allErrs = append(allErrs, field.Invalid(storagePath.Child("claimName"), spec.Storage.ClaimName, "claimName is only supported with storage type PVC"))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RabbitMQBindingSpec defines the desired state of RabbitMQBinding
+type RabbitMQBindingSpec struct {
+	// +kubebuilder:validation:Required
+	// RabbitmqClusterName - the name of the RabbitMQ cluster
+	RabbitmqClusterName string `json:"rabbitmqClusterName"`
+
+	// +kubebuilder:validation:Optional
+	// VhostRef - reference to the RabbitMQVhost resource (if empty, uses default vhost "/")
+	VhostRef string `json:"vhostRef,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Source - name of the source exchange in RabbitMQ
+	Source string `json:"source"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Destination - name of the destination queue or exchange in RabbitMQ
+	Destination string `json:"destination"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=queue;exchange
+	// +kubebuilder:default=queue
+	// DestinationType - whether the destination is a queue or an exchange
+	DestinationType string `json:"destinationType"`
+
+	// +kubebuilder:validation:Optional
+	// RoutingKey - the routing key of the binding
+	RoutingKey string `json:"routingKey,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// Arguments - optional binding arguments, e.g. for headers exchanges
+	Arguments *apiextensionsv1.JSON `json:"arguments,omitempty"`
+}
+
+// RabbitMQBindingStatus defines the observed state of RabbitMQBinding
+type RabbitMQBindingStatus struct {
+	// Conditions
+	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
+
+	// ObservedGeneration - the most recent generation observed for this resource
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=rabbitmqbindings,shortName=rmqbinding,categories=all;rabbitmq
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.rabbitmqClusterName"
+//+kubebuilder:printcolumn:name="Vhost",type="string",JSONPath=".spec.vhostRef"
+//+kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.source"
+//+kubebuilder:printcolumn:name="Destination",type="string",JSONPath=".spec.destination"
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message"
+
+// RabbitMQBinding is the Schema for the rabbitmqbindings API
+type RabbitMQBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RabbitMQBindingSpec   `json:"spec,omitempty"`
+	Status RabbitMQBindingStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RabbitMQBindingList contains a list of RabbitMQBinding
+type RabbitMQBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RabbitMQBinding `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RabbitMQBinding{}, &RabbitMQBindingList{})
+}
+
+// IsReady returns true if the binding is ready
+func (instance RabbitMQBinding) IsReady() bool {
+	return instance.Status.Conditions.IsTrue(condition.ReadyCondition)
+}
+
+const (
+	// RabbitMQBindingReadyCondition indicates that the binding is ready
+	RabbitMQBindingReadyCondition condition.Type = "RabbitMQBindingReady"
+
+	// RabbitMQBindingReadyMessage is the message for the RabbitMQBindingReady condition
+	RabbitMQBindingReadyMessage = "RabbitMQ binding is ready"
+
+	// RabbitMQBindingReadyInitMessage is the message for the RabbitMQBindingReady condition when not started
+	RabbitMQBindingReadyInitMessage = "RabbitMQ binding not started"
+
+	// RabbitMQBindingReadyErrorMessage is the message format for the RabbitMQBindingReady condition when an error occurs
+	RabbitMQBindingReadyErrorMessage = "RabbitMQ binding error occurred %s"
+)
@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var rabbitmqbindinglog = logf.Log.WithName("rabbitmqbinding-resource")
+
+// ValidateCreate validates the RabbitMQBinding on creation
+func (r *RabbitMQBinding) ValidateCreate(_ client.Client) (admission.Warnings, error) {
+	rabbitmqbindinglog.Info("validate create", "name", r.Name)
+
+	var allErrs field.ErrorList
+
+	// The source and destination can be pre-declared amq.* exchanges,
+	// so only the name format gets validated
+	if err := validateRabbitMQName(r.Spec.Source, "source"); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "source"), r.Spec.Source, err.Error()))
+	}
+	if err := validateRabbitMQName(r.Spec.Destination, "destination"); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "destination"), r.Spec.Destination, err.Error()))
+	}
+
+	if err := validateRabbitMQArguments(field.NewPath("spec", "arguments"), r.Spec.Arguments); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "rabbitmq.openstack.org", Kind: "RabbitMQBinding"},
+			r.Name,
+			allErrs,
+		)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate validates the RabbitMQBinding on update
+func (r *RabbitMQBinding) ValidateUpdate(_ client.Client, old runtime.Object) (admission.Warnings, error) {
+	rabbitmqbindinglog.Info("validate update", "name", r.Name)
+
+	oldBinding, ok := old.(*RabbitMQBinding)
+	if !ok {
+		return nil, fmt.Errorf("expected RabbitMQBinding but got %T", old)
+	}
+
+	// Bindings can't be updated in RabbitMQ, create a new RabbitMQBinding instead
+	var allErrs field.ErrorList
+	basePath := field.NewPath("spec")
+
+	if r.Spec.VhostRef != oldBinding.Spec.VhostRef {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("vhostRef"), "vhostRef cannot be changed after creation"))
+	}
+	if r.Spec.Source != oldBinding.Spec.Source {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("source"), "source cannot be changed after creation"))
+	}
+	if r.Spec.Destination != oldBinding.Spec.Destination {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("destination"), "destination cannot be changed after creation"))
+	}
+	if r.Spec.DestinationType != oldBinding.Spec.DestinationType {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("destinationType"), "destinationType cannot be changed after creation"))
+	}
+	if r.Spec.RoutingKey != oldBinding.Spec.RoutingKey {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("routingKey"), "routingKey cannot be changed after creation"))
+	}
+	if !rabbitMQArgumentsEqual(r.Spec.Arguments, oldBinding.Spec.Arguments) {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("arguments"), "arguments cannot be changed after creation"))
+	}
+
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "rabbitmq.openstack.org", Kind: "RabbitMQBinding"},
+			r.Name,
+			allErrs,
+		)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete validates the RabbitMQBinding on deletion
+func (r *RabbitMQBinding) ValidateDelete(_ client.Client) (admission.Warnings, error) {
+	return nil, nil
+}
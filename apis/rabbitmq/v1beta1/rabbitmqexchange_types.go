@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RabbitMQExchangeSpec defines the desired state of RabbitMQExchange
+type RabbitMQExchangeSpec struct {
+	// +kubebuilder:validation:Required
+	// RabbitmqClusterName - the name of the RabbitMQ cluster
+	RabbitmqClusterName string `json:"rabbitmqClusterName"`
+
+	// +kubebuilder:validation:Optional
+	// VhostRef - reference to the RabbitMQVhost resource (if empty, uses default vhost "/")
+	VhostRef string `json:"vhostRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Name - the exchange name in RabbitMQ (defaults to CR name)
+	Name string `json:"name,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=direct;fanout;topic;headers
+	// +kubebuilder:default=direct
+	// Type - the exchange type
+	Type string `json:"type"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	// Durable - whether the exchange survives a broker restart
+	Durable bool `json:"durable"`
+
+	// +kubebuilder:validation:Optional
+	// AutoDelete - whether the exchange gets deleted when the last binding is removed
+	AutoDelete bool `json:"autoDelete,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Internal - whether clients can't publish to the exchange directly,
+	// only through exchange to exchange bindings
+	Internal bool `json:"internal,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// Arguments - optional exchange arguments, e.g. alternate-exchange
+	Arguments *apiextensionsv1.JSON `json:"arguments,omitempty"`
+}
+
+// RabbitMQExchangeStatus defines the observed state of RabbitMQExchange
+type RabbitMQExchangeStatus struct {
+	// Conditions
+	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
+
+	// ObservedGeneration - the most recent generation observed for this resource
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=rabbitmqexchanges,shortName=rmqexchange,categories=all;rabbitmq
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.rabbitmqClusterName"
+//+kubebuilder:printcolumn:name="Vhost",type="string",JSONPath=".spec.vhostRef"
+//+kubebuilder:printcolumn:name="Exchange",type="string",JSONPath=".spec.name"
+//+kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type"
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message"
+
+// RabbitMQExchange is the Schema for the rabbitmqexchanges API
+type RabbitMQExchange struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RabbitMQExchangeSpec   `json:"spec,omitempty"`
+	Status RabbitMQExchangeStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RabbitMQExchangeList contains a list of RabbitMQExchange
+type RabbitMQExchangeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RabbitMQExchange `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RabbitMQExchange{}, &RabbitMQExchangeList{})
+}
+
+// IsReady returns true if the exchange is ready
+func (instance RabbitMQExchange) IsReady() bool {
+	return instance.Status.Conditions.IsTrue(condition.ReadyCondition)
+}
+
+const (
+	// RabbitMQExchangeReadyCondition indicates that the exchange is ready
+	RabbitMQExchangeReadyCondition condition.Type = "RabbitMQExchangeReady"
+
+	// RabbitMQExchangeReadyMessage is the message for the RabbitMQExchangeReady condition
+	RabbitMQExchangeReadyMessage = "RabbitMQ exchange is ready"
+
+	// RabbitMQExchangeReadyInitMessage is the message for the RabbitMQExchangeReady condition when not started
+	RabbitMQExchangeReadyInitMessage = "RabbitMQ exchange not started"
+
+	// RabbitMQExchangeReadyErrorMessage is the message format for the RabbitMQExchangeReady condition when an error occurs
+	RabbitMQExchangeReadyErrorMessage = "RabbitMQ exchange error occurred %s"
+)
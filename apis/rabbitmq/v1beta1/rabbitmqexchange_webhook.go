@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var rabbitmqexchangelog = logf.Log.WithName("rabbitmqexchange-resource")
+
+// Default implements defaulting for RabbitMQExchange
+func (r *RabbitMQExchange) Default(_ client.Client) {
+	rabbitmqexchangelog.Info("default", "name", r.Name)
+
+	// Default the exchange name to the CR name if not specified
+	if r.Spec.Name == "" {
+		r.Spec.Name = r.Name
+	}
+}
+
+// ValidateCreate validates the RabbitMQExchange on creation
+func (r *RabbitMQExchange) ValidateCreate(_ client.Client) (admission.Warnings, error) {
+	rabbitmqexchangelog.Info("validate create", "name", r.Name)
+
+	var allErrs field.ErrorList
+
+	if err := validateRabbitMQDeclarableName(r.Spec.Name, "exchange"); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "name"), r.Spec.Name, err.Error()))
+	}
+
+	if err := validateRabbitMQArguments(field.NewPath("spec", "arguments"), r.Spec.Arguments); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "rabbitmq.openstack.org", Kind: "RabbitMQExchange"},
+			r.Name,
+			allErrs,
+		)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate validates the RabbitMQExchange on update
+func (r *RabbitMQExchange) ValidateUpdate(_ client.Client, old runtime.Object) (admission.Warnings, error) {
+	rabbitmqexchangelog.Info("validate update", "name", r.Name)
+
+	oldExchange, ok := old.(*RabbitMQExchange)
+	if !ok {
+		return nil, fmt.Errorf("expected RabbitMQExchange but got %T", old)
+	}
+
+	// RabbitMQ does not allow to change the properties of an existing exchange
+	var allErrs field.ErrorList
+	basePath := field.NewPath("spec")
+
+	if r.Spec.Name != oldExchange.Spec.Name {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("name"), "exchange name cannot be changed after creation"))
+	}
+	if r.Spec.VhostRef != oldExchange.Spec.VhostRef {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("vhostRef"), "vhostRef cannot be changed after creation"))
+	}
+	if r.Spec.Type != oldExchange.Spec.Type {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("type"), "exchange type cannot be changed after creation"))
+	}
+	if r.Spec.Durable != oldExchange.Spec.Durable {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("durable"), "durable cannot be changed after creation"))
+	}
+	if r.Spec.AutoDelete != oldExchange.Spec.AutoDelete {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("autoDelete"), "autoDelete cannot be changed after creation"))
+	}
+	if r.Spec.Internal != oldExchange.Spec.Internal {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("internal"), "internal cannot be changed after creation"))
+	}
+	if !rabbitMQArgumentsEqual(r.Spec.Arguments, oldExchange.Spec.Arguments) {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("arguments"), "arguments cannot be changed after creation"))
+	}
+
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "rabbitmq.openstack.org", Kind: "RabbitMQExchange"},
+			r.Name,
+			allErrs,
+		)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete validates the RabbitMQExchange on deletion
+func (r *RabbitMQExchange) ValidateDelete(_ client.Client) (admission.Warnings, error) {
+	return nil, nil
+}
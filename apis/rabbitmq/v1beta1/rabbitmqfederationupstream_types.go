@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RabbitMQFederationUpstreamCluster references the cluster and vhost messages get federated from
+type RabbitMQFederationUpstreamCluster struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// RabbitmqClusterName - the name of the upstream RabbitMq cluster
+	RabbitmqClusterName string `json:"rabbitmqClusterName"`
+
+	// +kubebuilder:validation:Optional
+	// VhostRef - reference to the upstream RabbitMQVhost resource (if empty, uses default vhost "/")
+	VhostRef string `json:"vhostRef,omitempty"`
+}
+
+// RabbitMQFederationUpstreamSpec defines the desired state of RabbitMQFederationUpstream
+type RabbitMQFederationUpstreamSpec struct {
+	// +kubebuilder:validation:Required
+	// RabbitmqClusterName - the name of the downstream RabbitMq cluster the upstream gets declared in
+	RabbitmqClusterName string `json:"rabbitmqClusterName"`
+
+	// +kubebuilder:validation:Optional
+	// VhostRef - reference to the downstream RabbitMQVhost resource (if empty, uses default vhost "/")
+	VhostRef string `json:"vhostRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Name - the upstream name in RabbitMQ (defaults to CR name)
+	Name string `json:"name,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// Upstream - the cluster and vhost messages get federated from
+	Upstream RabbitMQFederationUpstreamCluster `json:"upstream"`
+
+	// +kubebuilder:validation:Optional
+	// Exchange - the name of the upstream exchange (defaults to the name of the federated exchange)
+	Exchange string `json:"exchange,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Queue - the name of the upstream queue (defaults to the name of the federated queue)
+	Queue string `json:"queue,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=on-confirm;on-publish;no-ack
+	// +kubebuilder:default=on-confirm
+	// AckMode - when the federation link acknowledges messages at the upstream
+	AckMode string `json:"ackMode"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// PrefetchCount - maximum number of unacknowledged messages of the federation link
+	PrefetchCount *int32 `json:"prefetchCount,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// ReconnectDelay - seconds to wait before reconnecting after a connection failure
+	ReconnectDelay *int32 `json:"reconnectDelay,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// MaxHops - maximum number of federation links a message can traverse
+	MaxHops *int32 `json:"maxHops,omitempty"`
+}
+
+// RabbitMQFederationUpstreamStatus defines the observed state of RabbitMQFederationUpstream
+type RabbitMQFederationUpstreamStatus struct {
+	// Conditions
+	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
+
+	// ObservedGeneration - the most recent generation observed for this resource
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=rabbitmqfederationupstreams,shortName=rmqupstream,categories=all;rabbitmq
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.rabbitmqClusterName"
+//+kubebuilder:printcolumn:name="Upstream",type="string",JSONPath=".spec.upstream.rabbitmqClusterName"
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message"
+
+// RabbitMQFederationUpstream is the Schema for the rabbitmqfederationupstreams API. It
+// manages a federation upstream, the rabbitmq_federation plugin gets enabled in the
+// downstream cluster. Exchanges and queues get federated by a RabbitMQPolicy with the
+// federation-upstream key.
+type RabbitMQFederationUpstream struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RabbitMQFederationUpstreamSpec   `json:"spec,omitempty"`
+	Status RabbitMQFederationUpstreamStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RabbitMQFederationUpstreamList contains a list of RabbitMQFederationUpstream
+type RabbitMQFederationUpstreamList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RabbitMQFederationUpstream `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RabbitMQFederationUpstream{}, &RabbitMQFederationUpstreamList{})
+}
+
+// IsReady returns true if the federation upstream is ready
+func (instance RabbitMQFederationUpstream) IsReady() bool {
+	return instance.Status.Conditions.IsTrue(condition.ReadyCondition)
+}
+
+const (
+	// RabbitMQFederationUpstreamReadyCondition indicates that the federation upstream is ready
+	RabbitMQFederationUpstreamReadyCondition condition.Type = "RabbitMQFederationUpstreamReady"
+
+	// RabbitMQFederationUpstreamReadyMessage is the message for the RabbitMQFederationUpstreamReady condition
+	RabbitMQFederationUpstreamReadyMessage = "RabbitMQ federation upstream is ready"
+
+	// RabbitMQFederationUpstreamReadyInitMessage is the message for the RabbitMQFederationUpstreamReady condition when not started
+	RabbitMQFederationUpstreamReadyInitMessage = "RabbitMQ federation upstream not started"
+
+	// RabbitMQFederationUpstreamReadyErrorMessage is the message format for the RabbitMQFederationUpstreamReady condition when an error occurs
+	RabbitMQFederationUpstreamReadyErrorMessage = "RabbitMQ federation upstream error occurred %s"
+)
@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var rabbitmqfederationupstreamlog = logf.Log.WithName("rabbitmqfederationupstream-resource")
+
+// Default implements defaulting for RabbitMQFederationUpstream
+func (r *RabbitMQFederationUpstream) Default(_ client.Client) {
+	rabbitmqfederationupstreamlog.Info("default", "name", r.Name)
+
+	// Default the upstream name to the CR name if not specified
+	if r.Spec.Name == "" {
+		r.Spec.Name = r.Name
+	}
+}
+
+// ValidateCreate validates the RabbitMQFederationUpstream on creation
+func (r *RabbitMQFederationUpstream) ValidateCreate(_ client.Client) (admission.Warnings, error) {
+	rabbitmqfederationupstreamlog.Info("validate create", "name", r.Name)
+
+	allErrs := r.Spec.validate(field.NewPath("spec"))
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "rabbitmq.openstack.org", Kind: "RabbitMQFederationUpstream"},
+			r.Name,
+			allErrs,
+		)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate validates the RabbitMQFederationUpstream on update
+func (r *RabbitMQFederationUpstream) ValidateUpdate(_ client.Client, old runtime.Object) (admission.Warnings, error) {
+	rabbitmqfederationupstreamlog.Info("validate update", "name", r.Name)
+
+	oldUpstream, ok := old.(*RabbitMQFederationUpstream)
+	if !ok {
+		return nil, fmt.Errorf("expected RabbitMQFederationUpstream but got %T", old)
+	}
+
+	basePath := field.NewPath("spec")
+	allErrs := r.Spec.validate(basePath)
+
+	// Policies reference the upstream by name in the downstream vhost
+	if r.Spec.Name != oldUpstream.Spec.Name {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("name"), "upstream name cannot be changed after creation"))
+	}
+	if r.Spec.RabbitmqClusterName != oldUpstream.Spec.RabbitmqClusterName {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("rabbitmqClusterName"), "rabbitmqClusterName cannot be changed after creation"))
+	}
+	if r.Spec.VhostRef != oldUpstream.Spec.VhostRef {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("vhostRef"), "vhostRef cannot be changed after creation"))
+	}
+
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "rabbitmq.openstack.org", Kind: "RabbitMQFederationUpstream"},
+			r.Name,
+			allErrs,
+		)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete validates the RabbitMQFederationUpstream on deletion
+func (r *RabbitMQFederationUpstream) ValidateDelete(_ client.Client) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate validates the upstream name and that it doesn't federate from itself
+func (spec *RabbitMQFederationUpstreamSpec) validate(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if err := validateRabbitMQName(spec.Name, "upstream"); err != nil {
+		allErrs = append(allErrs, field.Invalid(basePath.Child("name"), spec.Name, err.Error()))
+	}
+
+	if spec.Upstream.RabbitmqClusterName == spec.RabbitmqClusterName && spec.Upstream.VhostRef == spec.VhostRef {
+		allErrs = append(allErrs, field.Invalid(basePath.Child("upstream"), spec.Upstream.RabbitmqClusterName, "upstream must be a different cluster or vhost than the downstream"))
+	}
+
+	if spec.Exchange != "" {
+		if err := validateRabbitMQName(spec.Exchange, "exchange"); err != nil {
+			allErrs = append(allErrs, field.Invalid(basePath.Child("exchange"), spec.Exchange, err.Error()))
+		}
+	}
+	if spec.Queue != "" {
+		if err := validateRabbitMQName(spec.Queue, "queue"); err != nil {
+			allErrs = append(allErrs, field.Invalid(basePath.Child("queue"), spec.Queue, err.Error()))
+		}
+	}
+
+	return allErrs
+}
@@ -52,10 +52,14 @@ type RabbitMQPolicySpec struct {
 	Priority int `json:"priority"`
 
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:validation:Enum=queues;exchanges;all
+	// +kubebuilder:validation:Enum=queues;exchanges;all;streams
 	// +kubebuilder:default=all
 	// ApplyTo - what to apply the policy to
 	ApplyTo string `json:"applyTo"`
+
+	// +kubebuilder:validation:Optional
+	// DriftDetection - periodic comparison of the state in RabbitMQ against the spec
+	DriftDetection *DriftDetection `json:"driftDetection,omitempty"`
 }
 
 // RabbitMQPolicyStatus defines the observed state of RabbitMQPolicy
@@ -65,6 +69,10 @@ type RabbitMQPolicyStatus struct {
 
 	// ObservedGeneration - the most recent generation observed for this resource
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastAppliedHash - hash of the desired state last applied to RabbitMQ, used to tell
+	// changes of the spec from changes made in RabbitMQ
+	LastAppliedHash string `json:"lastAppliedHash,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RabbitMQQueueSpec defines the desired state of RabbitMQQueue
+type RabbitMQQueueSpec struct {
+	// +kubebuilder:validation:Required
+	// RabbitmqClusterName - the name of the RabbitMQ cluster
+	RabbitmqClusterName string `json:"rabbitmqClusterName"`
+
+	// +kubebuilder:validation:Optional
+	// VhostRef - reference to the RabbitMQVhost resource (if empty, uses default vhost "/")
+	VhostRef string `json:"vhostRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Name - the queue name in RabbitMQ (defaults to CR name)
+	Name string `json:"name,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=quorum;classic;stream
+	// Type - the queue type. If not set, the default queue type of the vhost is used
+	Type string `json:"type,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	// Durable - whether the queue survives a broker restart
+	Durable bool `json:"durable"`
+
+	// +kubebuilder:validation:Optional
+	// AutoDelete - whether the queue gets deleted when the last consumer unsubscribes
+	AutoDelete bool `json:"autoDelete,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// Arguments - optional queue arguments, e.g. x-max-length
+	Arguments *apiextensionsv1.JSON `json:"arguments,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ForceDelete - delete the queue from RabbitMQ when the CR gets deleted, even if
+	// the queue still holds messages. By default the deletion is blocked until the
+	// queue is empty.
+	ForceDelete bool `json:"forceDelete,omitempty"`
+}
+
+// RabbitMQQueueStatus defines the observed state of RabbitMQQueue
+type RabbitMQQueueStatus struct {
+	// Conditions
+	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
+
+	// ObservedGeneration - the most recent generation observed for this resource
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=rabbitmqqueues,shortName=rmqqueue,categories=all;rabbitmq
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.rabbitmqClusterName"
+//+kubebuilder:printcolumn:name="Vhost",type="string",JSONPath=".spec.vhostRef"
+//+kubebuilder:printcolumn:name="Queue",type="string",JSONPath=".spec.name"
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message"
+
+// RabbitMQQueue is the Schema for the rabbitmqqueues API
+type RabbitMQQueue struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RabbitMQQueueSpec   `json:"spec,omitempty"`
+	Status RabbitMQQueueStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RabbitMQQueueList contains a list of RabbitMQQueue
+type RabbitMQQueueList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RabbitMQQueue `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RabbitMQQueue{}, &RabbitMQQueueList{})
+}
+
+// IsReady returns true if the queue is ready
+func (instance RabbitMQQueue) IsReady() bool {
+	return instance.Status.Conditions.IsTrue(condition.ReadyCondition)
+}
+
+const (
+	// RabbitMQQueueReadyCondition indicates that the queue is ready
+	RabbitMQQueueReadyCondition condition.Type = "RabbitMQQueueReady"
+
+	// RabbitMQQueueReadyMessage is the message for the RabbitMQQueueReady condition
+	RabbitMQQueueReadyMessage = "RabbitMQ queue is ready"
+
+	// RabbitMQQueueReadyInitMessage is the message for the RabbitMQQueueReady condition when not started
+	RabbitMQQueueReadyInitMessage = "RabbitMQ queue not started"
+
+	// RabbitMQQueueReadyErrorMessage is the message format for the RabbitMQQueueReady condition when an error occurs
+	RabbitMQQueueReadyErrorMessage = "RabbitMQ queue error occurred %s"
+
+	// RabbitMQQueueDeleteBlockedMessage is the message format for the RabbitMQQueueReady condition when
+	// the deletion is blocked because the queue still holds messages
+	RabbitMQQueueDeleteBlockedMessage = "RabbitMQ queue %s still holds %d messages, drain the queue or set forceDelete to delete it"
+)
@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// rabbitMQReservedPrefix - queue and exchange names starting with this prefix
+// are reserved for RabbitMQ internal use and can't be declared by clients
+const rabbitMQReservedPrefix = "amq."
+
+var rabbitmqqueuelog = logf.Log.WithName("rabbitmqqueue-resource")
+
+// Default implements defaulting for RabbitMQQueue
+func (r *RabbitMQQueue) Default(_ client.Client) {
+	rabbitmqqueuelog.Info("default", "name", r.Name)
+
+	// Default the queue name to the CR name if not specified
+	if r.Spec.Name == "" {
+		r.Spec.Name = r.Name
+	}
+}
+
+// ValidateCreate validates the RabbitMQQueue on creation
+func (r *RabbitMQQueue) ValidateCreate(_ client.Client) (admission.Warnings, error) {
+	rabbitmqqueuelog.Info("validate create", "name", r.Name)
+
+	var allErrs field.ErrorList
+
+	if err := validateRabbitMQDeclarableName(r.Spec.Name, "queue"); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "name"), r.Spec.Name, err.Error()))
+	}
+
+	if err := validateRabbitMQArguments(field.NewPath("spec", "arguments"), r.Spec.Arguments); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "rabbitmq.openstack.org", Kind: "RabbitMQQueue"},
+			r.Name,
+			allErrs,
+		)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate validates the RabbitMQQueue on update
+func (r *RabbitMQQueue) ValidateUpdate(_ client.Client, old runtime.Object) (admission.Warnings, error) {
+	rabbitmqqueuelog.Info("validate update", "name", r.Name)
+
+	oldQueue, ok := old.(*RabbitMQQueue)
+	if !ok {
+		return nil, fmt.Errorf("expected RabbitMQQueue but got %T", old)
+	}
+
+	// RabbitMQ does not allow to change the properties of an existing queue,
+	// only forceDelete can be changed after creation
+	var allErrs field.ErrorList
+	basePath := field.NewPath("spec")
+
+	if r.Spec.Name != oldQueue.Spec.Name {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("name"), "queue name cannot be changed after creation"))
+	}
+	if r.Spec.VhostRef != oldQueue.Spec.VhostRef {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("vhostRef"), "vhostRef cannot be changed after creation"))
+	}
+	if r.Spec.Type != oldQueue.Spec.Type {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("type"), "queue type cannot be changed after creation"))
+	}
+	if r.Spec.Durable != oldQueue.Spec.Durable {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("durable"), "durable cannot be changed after creation"))
+	}
+	if r.Spec.AutoDelete != oldQueue.Spec.AutoDelete {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("autoDelete"), "autoDelete cannot be changed after creation"))
+	}
+	if !rabbitMQArgumentsEqual(r.Spec.Arguments, oldQueue.Spec.Arguments) {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("arguments"), "arguments cannot be changed after creation"))
+	}
+
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "rabbitmq.openstack.org", Kind: "RabbitMQQueue"},
+			r.Name,
+			allErrs,
+		)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete validates the RabbitMQQueue on deletion
+func (r *RabbitMQQueue) ValidateDelete(_ client.Client) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateRabbitMQDeclarableName validates the name of a queue or exchange which
+// gets declared by the operator
+func validateRabbitMQDeclarableName(name, resourceType string) error {
+	if err := validateRabbitMQName(name, resourceType); err != nil {
+		return err
+	}
+
+	if strings.HasPrefix(name, rabbitMQReservedPrefix) {
+		return fmt.Errorf("%s name must not start with the reserved prefix %q", resourceType, rabbitMQReservedPrefix)
+	}
+
+	return nil
+}
+
+// validateRabbitMQArguments validates that the queue, exchange or binding
+// arguments are a JSON object
+func validateRabbitMQArguments(path *field.Path, arguments *apiextensionsv1.JSON) *field.Error {
+	if arguments == nil {
+		return nil
+	}
+
+	args := map[string]interface{}{}
+	if err := json.Unmarshal(arguments.Raw, &args); err != nil {
+		return field.Invalid(path, string(arguments.Raw), fmt.Sprintf("arguments must be a JSON object: %v", err))
+	}
+
+	return nil
+}
+
+// rabbitMQArgumentsEqual returns true if both arguments decode to the same
+// JSON object, not set arguments are equal to an empty object
+func rabbitMQArgumentsEqual(a, b *apiextensionsv1.JSON) bool {
+	decode := func(arguments *apiextensionsv1.JSON) map[string]interface{} {
+		args := map[string]interface{}{}
+		if arguments != nil {
+			_ = json.Unmarshal(arguments.Raw, &args)
+		}
+		return args
+	}
+
+	return reflect.DeepEqual(decode(a), decode(b))
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RabbitMQRestoreSpec defines the desired state of RabbitMQRestore
+type RabbitMQRestoreSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// RabbitmqClusterName - the name of the RabbitMq cluster to import the definitions into
+	RabbitmqClusterName string `json:"rabbitmqClusterName"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// BackupRef - name of the RabbitMQBackup to restore from
+	BackupRef string `json:"backupRef"`
+
+	// +kubebuilder:validation:Optional
+	// Backup - name of the backup to restore from the backups in the RabbitMQBackup status,
+	// defaults to the latest backup
+	Backup string `json:"backup,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// Vhosts - only restore the definitions of these vhosts, together with the users which have
+	// permissions on them, e.g. to restore the topology of a single service. Restores all
+	// definitions if not specified
+	Vhosts []string `json:"vhosts,omitempty"`
+}
+
+// RabbitMQRestoreStatus defines the observed state of RabbitMQRestore
+type RabbitMQRestoreStatus struct {
+	// Conditions
+	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
+
+	// ObservedGeneration - the most recent generation observed for this resource
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// RestoredBackup - name of the backup which got restored
+	RestoredBackup string `json:"restoredBackup,omitempty"`
+
+	// CompletionTime - when the restore completed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=rabbitmqrestores,shortName=rmqrestore,categories=all;rabbitmq
+//+kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.rabbitmqClusterName"
+//+kubebuilder:printcolumn:name="Backup",type="string",JSONPath=".status.restoredBackup"
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message"
+
+// RabbitMQRestore is the Schema for the rabbitmqrestores API. It imports a backup taken by a
+// RabbitMQBackup into a RabbitMq cluster once, the spec can't be changed after creation.
+type RabbitMQRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RabbitMQRestoreSpec   `json:"spec,omitempty"`
+	Status RabbitMQRestoreStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RabbitMQRestoreList contains a list of RabbitMQRestore
+type RabbitMQRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RabbitMQRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RabbitMQRestore{}, &RabbitMQRestoreList{})
+}
+
+// IsReady returns true if the restore completed
+func (instance RabbitMQRestore) IsReady() bool {
+	return instance.Status.Conditions.IsTrue(condition.ReadyCondition)
+}
+
+// RbacConditionsSet - set the conditions for the rbac object
+func (instance RabbitMQRestore) RbacConditionsSet(c *condition.Condition) {
+	instance.Status.Conditions.Set(c)
+}
+
+// RbacNamespace - return the namespace
+func (instance RabbitMQRestore) RbacNamespace() string {
+	return instance.Namespace
+}
+
+// RbacResourceName - return the name to be used for rbac objects (serviceaccount, role, rolebinding)
+// of the Job reading a backup from a PersistentVolumeClaim
+func (instance RabbitMQRestore) RbacResourceName() string {
+	return "rabbitmqrestore-" + instance.Name
+}
+
+const (
+	// RabbitMQRestoreReadyCondition indicates that the restore completed
+	RabbitMQRestoreReadyCondition condition.Type = "RabbitMQRestoreReady"
+
+	// RabbitMQRestoreReadyMessage is the message for the RabbitMQRestoreReady condition
+	RabbitMQRestoreReadyMessage = "RabbitMQ restore completed"
+
+	// RabbitMQRestoreReadyInitMessage is the message for the RabbitMQRestoreReady condition when not started
+	RabbitMQRestoreReadyInitMessage = "RabbitMQ restore not started"
+
+	// RabbitMQRestoreReadyRunningMessage is the message format for the RabbitMQRestoreReady condition while the backup is read
+	RabbitMQRestoreReadyRunningMessage = "RabbitMQ restore of backup %s in progress"
+
+	// RabbitMQRestoreReadyErrorMessage is the message format for the RabbitMQRestoreReady condition when an error occurs
+	RabbitMQRestoreReadyErrorMessage = "RabbitMQ restore error occurred %s"
+)
@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var rabbitmqrestorelog = logf.Log.WithName("rabbitmqrestore-resource")
+
+// Default implements defaulting for RabbitMQRestore
+func (r *RabbitMQRestore) Default(_ client.Client) {
+	rabbitmqrestorelog.Info("default", "name", r.Name)
+}
+
+// ValidateCreate validates the RabbitMQRestore on creation
+func (r *RabbitMQRestore) ValidateCreate(_ client.Client) (admission.Warnings, error) {
+	rabbitmqrestorelog.Info("validate create", "name", r.Name)
+
+	allErrs := r.Spec.validate(field.NewPath("spec"))
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "rabbitmq.openstack.org", Kind: "RabbitMQRestore"},
+			r.Name,
+			allErrs,
+		)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate validates the RabbitMQRestore on update
+func (r *RabbitMQRestore) ValidateUpdate(_ client.Client, old runtime.Object) (admission.Warnings, error) {
+	rabbitmqrestorelog.Info("validate update", "name", r.Name)
+
+	oldRestore, ok := old.(*RabbitMQRestore)
+	if !ok {
+		return nil, fmt.Errorf("expected RabbitMQRestore but got %T", old)
+	}
+
+	// A restore runs once, another restore needs a new RabbitMQRestore
+	var allErrs field.ErrorList
+	if !equality.Semantic.DeepEqual(r.Spec, oldRestore.Spec) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "spec cannot be changed after creation, create a new RabbitMQRestore instead"))
+	}
+
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "rabbitmq.openstack.org", Kind: "RabbitMQRestore"},
+			r.Name,
+			allErrs,
+		)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete validates the RabbitMQRestore on deletion
+func (r *RabbitMQRestore) ValidateDelete(_ client.Client) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate validates the vhosts to restore
+func (spec *RabbitMQRestoreSpec) validate(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, vhost := range spec.Vhosts {
+		// "/" is the default vhost and is always valid
+		if vhost == "/" {
+			continue
+		}
+		if err := validateRabbitMQName(vhost, "vhost"); err != nil {
+			allErrs = append(allErrs, field.Invalid(basePath.Child("vhosts").Index(i), vhost, err.Error()))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RabbitMQShovelEndpoint defines the source or destination of a shovel
+type RabbitMQShovelEndpoint struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// RabbitmqClusterName - the name of the RabbitMq cluster
+	RabbitmqClusterName string `json:"rabbitmqClusterName"`
+
+	// +kubebuilder:validation:Optional
+	// VhostRef - reference to the RabbitMQVhost resource (if empty, uses default vhost "/")
+	VhostRef string `json:"vhostRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Queue - the queue to consume from or publish to
+	Queue string `json:"queue,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Exchange - the exchange to consume from or publish to
+	Exchange string `json:"exchange,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ExchangeKey - the routing key used to bind to the source exchange or to
+	// publish to the destination exchange
+	ExchangeKey string `json:"exchangeKey,omitempty"`
+}
+
+// RabbitMQShovelSpec defines the desired state of RabbitMQShovel
+type RabbitMQShovelSpec struct {
+	// +kubebuilder:validation:Optional
+	// Name - the shovel name in RabbitMQ (defaults to CR name)
+	Name string `json:"name,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// Source - where the shovel consumes messages from. The shovel gets declared
+	// in the source cluster and vhost.
+	Source RabbitMQShovelEndpoint `json:"source"`
+
+	// +kubebuilder:validation:Required
+	// Destination - where the shovel publishes messages to
+	Destination RabbitMQShovelEndpoint `json:"destination"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=on-confirm;on-publish;no-ack
+	// +kubebuilder:default=on-confirm
+	// AckMode - when the shovel acknowledges messages at the source
+	AckMode string `json:"ackMode"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=never;queue-length
+	// +kubebuilder:default=never
+	// DeleteAfter - with queue-length the shovel moves the messages which are in the
+	// source queue when the shovel starts and then deletes itself, used to migrate queues
+	DeleteAfter string `json:"deleteAfter"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// PrefetchCount - maximum number of unacknowledged messages the shovel consumes
+	PrefetchCount *int32 `json:"prefetchCount,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// ReconnectDelay - seconds to wait before reconnecting after a connection failure
+	ReconnectDelay *int32 `json:"reconnectDelay,omitempty"`
+}
+
+// RabbitMQShovelStatus defines the observed state of RabbitMQShovel
+type RabbitMQShovelStatus struct {
+	// Conditions
+	Conditions condition.Conditions `json:"conditions,omitempty" optional:"true"`
+
+	// ObservedGeneration - the most recent generation observed for this resource
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=rabbitmqshovels,shortName=rmqshovel,categories=all;rabbitmq
+//+kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.source.rabbitmqClusterName"
+//+kubebuilder:printcolumn:name="Destination",type="string",JSONPath=".spec.destination.rabbitmqClusterName"
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message"
+
+// RabbitMQShovel is the Schema for the rabbitmqshovels API. It manages a dynamic
+// shovel, the rabbitmq_shovel plugin gets enabled in the source cluster.
+type RabbitMQShovel struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RabbitMQShovelSpec   `json:"spec,omitempty"`
+	Status RabbitMQShovelStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RabbitMQShovelList contains a list of RabbitMQShovel
+type RabbitMQShovelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RabbitMQShovel `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RabbitMQShovel{}, &RabbitMQShovelList{})
+}
+
+// IsReady returns true if the shovel is ready
+func (instance RabbitMQShovel) IsReady() bool {
+	return instance.Status.Conditions.IsTrue(condition.ReadyCondition)
+}
+
+const (
+	// RabbitMQShovelReadyCondition indicates that the shovel is ready
+	RabbitMQShovelReadyCondition condition.Type = "RabbitMQShovelReady"
+
+	// RabbitMQShovelReadyMessage is the message for the RabbitMQShovelReady condition
+	RabbitMQShovelReadyMessage = "RabbitMQ shovel is ready"
+
+	// RabbitMQShovelReadyInitMessage is the message for the RabbitMQShovelReady condition when not started
+	RabbitMQShovelReadyInitMessage = "RabbitMQ shovel not started"
+
+	// RabbitMQShovelReadyErrorMessage is the message format for the RabbitMQShovelReady condition when an error occurs
+	RabbitMQShovelReadyErrorMessage = "RabbitMQ shovel error occurred %s"
+)
@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var rabbitmqshovellog = logf.Log.WithName("rabbitmqshovel-resource")
+
+// Default implements defaulting for RabbitMQShovel
+func (r *RabbitMQShovel) Default(_ client.Client) {
+	rabbitmqshovellog.Info("default", "name", r.Name)
+
+	// Default the shovel name to the CR name if not specified
+	if r.Spec.Name == "" {
+		r.Spec.Name = r.Name
+	}
+}
+
+// ValidateCreate validates the RabbitMQShovel on creation
+func (r *RabbitMQShovel) ValidateCreate(_ client.Client) (admission.Warnings, error) {
+	rabbitmqshovellog.Info("validate create", "name", r.Name)
+
+	allErrs := r.Spec.validate(field.NewPath("spec"))
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "rabbitmq.openstack.org", Kind: "RabbitMQShovel"},
+			r.Name,
+			allErrs,
+		)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate validates the RabbitMQShovel on update
+func (r *RabbitMQShovel) ValidateUpdate(_ client.Client, old runtime.Object) (admission.Warnings, error) {
+	rabbitmqshovellog.Info("validate update", "name", r.Name)
+
+	oldShovel, ok := old.(*RabbitMQShovel)
+	if !ok {
+		return nil, fmt.Errorf("expected RabbitMQShovel but got %T", old)
+	}
+
+	basePath := field.NewPath("spec")
+	allErrs := r.Spec.validate(basePath)
+
+	// The shovel is declared in the source cluster and vhost, moving it
+	// would leave the old shovel behind
+	if r.Spec.Name != oldShovel.Spec.Name {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("name"), "shovel name cannot be changed after creation"))
+	}
+	if r.Spec.Source.RabbitmqClusterName != oldShovel.Spec.Source.RabbitmqClusterName {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("source", "rabbitmqClusterName"), "source cluster cannot be changed after creation"))
+	}
+	if r.Spec.Source.VhostRef != oldShovel.Spec.Source.VhostRef {
+		allErrs = append(allErrs, field.Forbidden(basePath.Child("source", "vhostRef"), "source vhostRef cannot be changed after creation"))
+	}
+
+	if len(allErrs) != 0 {
+		return nil, apierrors.NewInvalid(
+			schema.GroupKind{Group: "rabbitmq.openstack.org", Kind: "RabbitMQShovel"},
+			r.Name,
+			allErrs,
+		)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete validates the RabbitMQShovel on deletion
+func (r *RabbitMQShovel) ValidateDelete(_ client.Client) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate validates the shovel name and endpoints
+func (spec *RabbitMQShovelSpec) validate(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if err := validateRabbitMQName(spec.Name, "shovel"); err != nil {
+		allErrs = append(allErrs, field.Invalid(basePath.Child("name"), spec.Name, err.Error()))
+	}
+
+	allErrs = append(allErrs, spec.Source.validate(basePath.Child("source"), true)...)
+	allErrs = append(allErrs, spec.Destination.validate(basePath.Child("destination"), false)...)
+
+	if spec.DeleteAfter == "queue-length" && spec.Source.Queue == "" {
+		allErrs = append(allErrs, field.Invalid(basePath.Child("deleteAfter"), spec.DeleteAfter, "queue-length requires a source queue"))
+	}
+
+	if spec.Source == spec.Destination {
+		allErrs = append(allErrs, field.Invalid(basePath.Child("destination"), spec.Destination.RabbitmqClusterName, "destination must be different from the source"))
+	}
+
+	return allErrs
+}
+
+// validate validates a shovel endpoint, the source must either consume from a
+// queue or from an exchange
+func (e *RabbitMQShovelEndpoint) validate(path *field.Path, source bool) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if e.Queue != "" && e.Exchange != "" {
+		allErrs = append(allErrs, field.Invalid(path.Child("exchange"), e.Exchange, "queue and exchange are mutually exclusive"))
+	}
+	if source && e.Queue == "" && e.Exchange == "" {
+		allErrs = append(allErrs, field.Required(path.Child("queue"), "either queue or exchange must be set"))
+	}
+	if e.ExchangeKey != "" && e.Exchange == "" {
+		allErrs = append(allErrs, field.Invalid(path.Child("exchangeKey"), e.ExchangeKey, "exchangeKey requires exchange to be set"))
+	}
+
+	if e.Queue != "" {
+		if err := validateRabbitMQName(e.Queue, "queue"); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("queue"), e.Queue, err.Error()))
+		}
+	}
+	if e.Exchange != "" {
+		if err := validateRabbitMQName(e.Exchange, "exchange"); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("exchange"), e.Exchange, err.Error()))
+		}
+	}
+
+	return allErrs
+}
@@ -34,6 +34,47 @@ type CredentialSelectors struct {
 	Password string `json:"password"`
 }
 
+const (
+	// ExternalSecretProviderFile - reads the password from a file mounted into the operator pod
+	ExternalSecretProviderFile = "file"
+)
+
+// HashedCredentials defines a secret with a precomputed RabbitMQ password hash. The hash is
+// base64(salt + hash(salt + password)) with a 4 byte salt, as created by
+// "rabbitmqctl hash_password".
+type HashedCredentials struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Secret - name of the secret containing the password hash
+	Secret string `json:"secret"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="password_hash"
+	// PasswordHashKey - key name for the password hash in the secret (default "password_hash")
+	PasswordHashKey string `json:"passwordHashKey"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="rabbit_password_hashing_sha256"
+	// +kubebuilder:validation:Enum=rabbit_password_hashing_sha256;rabbit_password_hashing_sha512;rabbit_password_hashing_md5
+	// HashingAlgorithm - algorithm the password hash was computed with
+	HashingAlgorithm string `json:"hashingAlgorithm"`
+}
+
+// ExternalSecretSource defines the password of a user read from an external secret provider
+type ExternalSecretSource struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="file"
+	// +kubebuilder:validation:Enum=file
+	// Provider - the external secret provider. "file" reads the password from a file mounted
+	// into the operator pod, e.g. by the Secrets Store CSI driver
+	Provider string `json:"provider"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Path - path of the password in the provider, relative to the secrets directory for "file"
+	Path string `json:"path"`
+}
+
 // RabbitMQUserPermissions defines permissions for a user on a vhost.
 // Design note: this implementation uses a default of ".*" (allow all).
 // To explicitly deny permissions, set the field to an empty string "".
@@ -56,6 +97,40 @@ type RabbitMQUserPermissions struct {
 	Read string `json:"read"`
 }
 
+// RabbitMQUserTopicPermission defines topic permissions for a user on a
+// topic exchange of the vhost. Topic permissions restrict the routing keys
+// a user can publish to (write) or bind to (read) on that exchange.
+type RabbitMQUserTopicPermission struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Exchange - name of the topic exchange the permissions apply to
+	Exchange string `json:"exchange"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=".*"
+	// Write - routing key regex the user can publish with (default ".*" allows all, "" denies all)
+	Write string `json:"write"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=".*"
+	// Read - routing key regex the user can bind with (default ".*" allows all, "" denies all)
+	Read string `json:"read"`
+}
+
+// RabbitMQUserLimits defines per-user resource limits. A limit which is not
+// set is removed from the user, which means it is unlimited.
+type RabbitMQUserLimits struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// MaxConnections - maximum number of connections the user can open
+	MaxConnections *int64 `json:"maxConnections,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// MaxChannels - maximum number of channels the user can open
+	MaxChannels *int64 `json:"maxChannels,omitempty"`
+}
+
 // RabbitMQUserSpec defines the desired state of RabbitMQUser
 type RabbitMQUserSpec struct {
 	// +kubebuilder:validation:Required
@@ -80,6 +155,16 @@ type RabbitMQUserSpec struct {
 	// Defaults to {username: "username", password: "password"} when not specified
 	CredentialSelectors *CredentialSelectors `json:"credentialSelectors,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// HashedCredentials - secret with a precomputed password hash, so that the plaintext password
+	// never passes through the operator. Mutually exclusive with Secret and ExternalSecret
+	HashedCredentials *HashedCredentials `json:"hashedCredentials,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ExternalSecret - password from an external secret provider instead of a secret in the namespace.
+	// Mutually exclusive with Secret and HashedCredentials
+	ExternalSecret *ExternalSecretSource `json:"externalSecret,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// Permissions - user permissions on the vhost
 	Permissions RabbitMQUserPermissions `json:"permissions"`
@@ -87,6 +172,24 @@ type RabbitMQUserSpec struct {
 	// +kubebuilder:validation:Optional
 	// Tags - RabbitMQ user tags
 	Tags []string `json:"tags,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=exchange
+	// TopicPermissions - user topic permissions on topic exchanges of the vhost
+	TopicPermissions []RabbitMQUserTopicPermission `json:"topicPermissions,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Limits - per-user limits, e.g. the maximum number of connections
+	Limits *RabbitMQUserLimits `json:"limits,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// DriftDetection - periodic comparison of the state in RabbitMQ against the spec
+	DriftDetection *DriftDetection `json:"driftDetection,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Adoption - take over a user which already exists in RabbitMQ instead of silently overwriting it
+	Adoption *Adoption `json:"adoption,omitempty"`
 }
 
 // RabbitMQUserStatus defines the observed state of RabbitMQUser
@@ -97,7 +200,8 @@ type RabbitMQUserStatus struct {
 	// ObservedGeneration - the most recent generation observed for this resource
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
-	// SecretName - name of the secret containing user credentials
+	// SecretName - name of the secret containing user credentials, empty if the password
+	// comes from an external secret provider
 	SecretName string `json:"secretName,omitempty"`
 
 	// Username - actual username used in RabbitMQ
@@ -108,6 +212,14 @@ type RabbitMQUserStatus struct {
 
 	// VhostRef - reference to the RabbitMQVhost CR (for tracking finalizers)
 	VhostRef string `json:"vhostRef,omitempty"`
+
+	// LastAppliedHash - hash of the desired state last applied to RabbitMQ, used to tell
+	// changes of the spec from changes made in RabbitMQ
+	LastAppliedHash string `json:"lastAppliedHash,omitempty"`
+
+	// Adopted - whether the user already existed in RabbitMQ and was adopted. Only recorded when
+	// adoption is enabled, before the operator first writes the user
+	Adopted *bool `json:"adopted,omitempty"`
 }
 
 //+kubebuilder:object:root=true
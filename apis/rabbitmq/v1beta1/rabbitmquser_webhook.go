@@ -18,8 +18,11 @@ package v1beta1
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"path/filepath"
 	"regexp"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -82,6 +85,9 @@ func (r *RabbitMQUser) ValidateCreate(k8sClient client.Client) (admission.Warnin
 	rabbitmquserlog.Info("validate create", "name", r.Name)
 
 	// Validate secret and credentials
+	if err := r.validateCredentialSources(k8sClient); err != nil {
+		return nil, err
+	}
 	if err := r.validateSecretAndExtractCredentials(k8sClient); err != nil {
 		return nil, err
 	}
@@ -125,6 +131,9 @@ func (r *RabbitMQUser) ValidateUpdate(k8sClient client.Client, old runtime.Objec
 	}
 
 	// Validate secret and credentials
+	if err := r.validateCredentialSources(k8sClient); err != nil {
+		return nil, err
+	}
 	if err := r.validateSecretAndExtractCredentials(k8sClient); err != nil {
 		return nil, err
 	}
@@ -281,6 +290,86 @@ func (r *RabbitMQUser) validateSecretAndExtractCredentials(k8sClient client.Clie
 	return nil
 }
 
+// validateCredentialSources validates that at most one password source is set, and that the
+// hashed credentials and the external secret can be used
+func (r *RabbitMQUser) validateCredentialSources(k8sClient client.Client) error {
+	var allErrs field.ErrorList
+
+	sources := []string{}
+	if r.Spec.Secret != nil && *r.Spec.Secret != "" {
+		sources = append(sources, "secret")
+	}
+	if r.Spec.HashedCredentials != nil {
+		sources = append(sources, "hashedCredentials")
+	}
+	if r.Spec.ExternalSecret != nil {
+		sources = append(sources, "externalSecret")
+	}
+	if len(sources) > 1 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"),
+			fmt.Sprintf("only one of secret, hashedCredentials and externalSecret can be set, got %v", sources)))
+	}
+
+	if hashed := r.Spec.HashedCredentials; hashed != nil {
+		path := field.NewPath("spec", "hashedCredentials")
+		hashKey := hashed.PasswordHashKey
+		if hashKey == "" {
+			hashKey = "password_hash"
+		}
+
+		secret := &corev1.Secret{}
+		if hashed.Secret == fmt.Sprintf("rabbitmq-user-%s", r.Name) {
+			allErrs = append(allErrs, field.Invalid(path.Child("secret"), hashed.Secret,
+				fmt.Sprintf("secret name %q is reserved for auto-generated secrets", hashed.Secret)))
+		} else if err := k8sClient.Get(context.TODO(),
+			client.ObjectKey{Name: hashed.Secret, Namespace: r.Namespace},
+			secret); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("secret"), hashed.Secret,
+				fmt.Sprintf("referenced secret does not exist: %v", err)))
+		} else if passwordHash, ok := secret.Data[hashKey]; !ok {
+			allErrs = append(allErrs, field.Invalid(path.Child("passwordHashKey"), hashKey,
+				fmt.Sprintf("key %q not found in secret %s", hashKey, hashed.Secret)))
+		} else if err := validatePasswordHash(string(passwordHash)); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("passwordHashKey"), hashKey,
+				fmt.Sprintf("invalid password hash in secret %s: %v", hashed.Secret, err)))
+		}
+	}
+
+	if external := r.Spec.ExternalSecret; external != nil {
+		path := field.NewPath("spec", "externalSecret")
+		if external.Provider != "" && external.Provider != ExternalSecretProviderFile {
+			allErrs = append(allErrs, field.NotSupported(path.Child("provider"), external.Provider,
+				[]string{ExternalSecretProviderFile}))
+		}
+		if !filepath.IsLocal(external.Path) {
+			allErrs = append(allErrs, field.Invalid(path.Child("path"), external.Path,
+				"path must be relative to the secrets directory and must not contain \"..\""))
+		}
+	}
+
+	if len(allErrs) != 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: "rabbitmq.openstack.org", Kind: "RabbitMQUser"},
+			r.Name,
+			allErrs,
+		)
+	}
+
+	return nil
+}
+
+// validatePasswordHash validates that the password hash is base64(salt + hash) with a 4 byte salt
+func validatePasswordHash(passwordHash string) error {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(passwordHash))
+	if err != nil {
+		return fmt.Errorf("not base64 encoded: %w", err)
+	}
+	if len(decoded) <= 4 {
+		return fmt.Errorf("too short to contain a salt and a hash")
+	}
+	return nil
+}
+
 // validateUsername validates the username format and length
 func (r *RabbitMQUser) validateUsername() error {
 	var allErrs field.ErrorList
@@ -394,6 +483,25 @@ func (r *RabbitMQUser) validatePermissions() error {
 		))
 	}
 
+	// Validate topic permission regex patterns
+	for i, topicPerm := range r.Spec.TopicPermissions {
+		path := field.NewPath("spec", "topicPermissions").Index(i)
+		if _, err := regexp.Compile(topicPerm.Write); err != nil {
+			allErrs = append(allErrs, field.Invalid(
+				path.Child("write"),
+				topicPerm.Write,
+				fmt.Sprintf("invalid regex pattern: %v", err),
+			))
+		}
+		if _, err := regexp.Compile(topicPerm.Read); err != nil {
+			allErrs = append(allErrs, field.Invalid(
+				path.Child("read"),
+				topicPerm.Read,
+				fmt.Sprintf("invalid regex pattern: %v", err),
+			))
+		}
+	}
+
 	if len(allErrs) != 0 {
 		return apierrors.NewInvalid(
 			schema.GroupKind{Group: "rabbitmq.openstack.org", Kind: "RabbitMQUser"},
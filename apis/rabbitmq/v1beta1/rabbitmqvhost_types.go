@@ -32,6 +32,47 @@ type RabbitMQVhostSpec struct {
 	// +kubebuilder:default="/"
 	// Name - the vhost name in RabbitMQ (defaults to "/")
 	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// Description - description of the vhost
+	Description string `json:"description,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Tags - vhost tags
+	Tags []string `json:"tags,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=quorum;classic;stream
+	// DefaultQueueType - queue type used for queues declared in the vhost without
+	// an x-queue-type argument. Once set, RabbitMQ keeps the value when the field
+	// gets cleared.
+	DefaultQueueType string `json:"defaultQueueType,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Limits - vhost limits, e.g. the maximum number of queues
+	Limits *RabbitMQVhostLimits `json:"limits,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// DriftDetection - periodic comparison of the state in RabbitMQ against the spec
+	DriftDetection *DriftDetection `json:"driftDetection,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Adoption - take over a vhost which already exists in RabbitMQ instead of silently overwriting it
+	Adoption *Adoption `json:"adoption,omitempty"`
+}
+
+// RabbitMQVhostLimits defines vhost limits. A limit which is not set is
+// removed from the vhost, which means it is unlimited.
+type RabbitMQVhostLimits struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// MaxConnections - maximum number of concurrent client connections to the vhost
+	MaxConnections *int64 `json:"maxConnections,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// MaxQueues - maximum number of queues which can be declared in the vhost
+	MaxQueues *int64 `json:"maxQueues,omitempty"`
 }
 
 // RabbitMQVhostStatus defines the observed state of RabbitMQVhost
@@ -41,6 +82,14 @@ type RabbitMQVhostStatus struct {
 
 	// ObservedGeneration - the most recent generation observed for this resource
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// LastAppliedHash - hash of the desired state last applied to RabbitMQ, used to tell
+	// changes of the spec from changes made in RabbitMQ
+	LastAppliedHash string `json:"lastAppliedHash,omitempty"`
+
+	// Adopted - whether the vhost already existed in RabbitMQ and was adopted. Only recorded when
+	// adoption is enabled, before the operator first writes the vhost
+	Adopted *bool `json:"adopted,omitempty"`
 }
 
 //+kubebuilder:object:root=true
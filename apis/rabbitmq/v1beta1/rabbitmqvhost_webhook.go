@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"fmt"
+	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -59,7 +60,7 @@ func (r *RabbitMQVhost) ValidateCreate(_ client.Client) (admission.Warnings, err
 		}
 	}
 
-	return nil, nil
+	return nil, r.validateTags()
 }
 
 // ValidateUpdate validates the RabbitMQVhost on update
@@ -85,10 +86,36 @@ func (r *RabbitMQVhost) ValidateUpdate(_ client.Client, old runtime.Object) (adm
 		)
 	}
 
-	return nil, nil
+	return nil, r.validateTags()
 }
 
 // ValidateDelete validates the RabbitMQVhost on deletion
 func (r *RabbitMQVhost) ValidateDelete(_ client.Client) (admission.Warnings, error) {
 	return nil, nil
 }
+
+// validateTags validates the vhost tags. RabbitMQ stores vhost tags as a
+// comma separated list, so a tag can't be empty or contain a comma.
+func (r *RabbitMQVhost) validateTags() error {
+	var allErrs field.ErrorList
+
+	for i, tag := range r.Spec.Tags {
+		if tag == "" || strings.Contains(tag, ",") {
+			allErrs = append(allErrs, field.Invalid(
+				field.NewPath("spec", "tags").Index(i),
+				tag,
+				"tag must not be empty or contain a comma",
+			))
+		}
+	}
+
+	if len(allErrs) != 0 {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: "rabbitmq.openstack.org", Kind: "RabbitMQVhost"},
+			r.Name,
+			allErrs,
+		)
+	}
+
+	return nil
+}
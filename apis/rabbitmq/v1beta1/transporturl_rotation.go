@@ -0,0 +1,191 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rotationScheduleSearchLimit - how far NextRotation looks ahead for a matching
+// time of a schedule, which guards against schedules which never match, e.g. "0 0 31 2 *"
+const rotationScheduleSearchLimit = 5 * 366 * 24 * time.Hour
+
+// rotationScheduleDescriptors - shortcuts for commonly used schedules
+var rotationScheduleDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+}
+
+// rotationSchedule - a parsed cron schedule, each field holds the allowed values
+type rotationSchedule struct {
+	minute, hour, dom, month, dow map[int]bool
+	// domRestricted/dowRestricted - with both day fields restricted a day
+	// matches if either of them matches, as in cron
+	domRestricted, dowRestricted bool
+}
+
+// Validate - checks the rotation policy
+func (r *TransportURLCredentialRotation) Validate() error {
+	switch {
+	case r.Interval != nil && r.Schedule != "":
+		return fmt.Errorf("only one of interval and schedule can be set")
+	case r.Interval != nil:
+		if r.Interval.Duration <= 0 {
+			return fmt.Errorf("interval %s must be greater than 0", r.Interval.Duration)
+		}
+	case r.Schedule != "":
+		if _, err := parseRotationSchedule(r.Schedule); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("either interval or schedule must be set")
+	}
+	return nil
+}
+
+// NextRotation - returns the time of the next rotation after last
+func (r *TransportURLCredentialRotation) NextRotation(last time.Time) (time.Time, error) {
+	if err := r.Validate(); err != nil {
+		return time.Time{}, err
+	}
+	if r.Interval != nil {
+		return last.Add(r.Interval.Duration), nil
+	}
+
+	// Validate already made sure the schedule parses
+	schedule, _ := parseRotationSchedule(r.Schedule)
+	return schedule.next(last)
+}
+
+func parseRotationSchedule(spec string) (*rotationSchedule, error) {
+	expr := strings.TrimSpace(spec)
+	if d, ok := rotationScheduleDescriptors[expr]; ok {
+		expr = d
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q: expected 5 fields, got %d", spec, len(fields))
+	}
+
+	s := &rotationSchedule{}
+	var err error
+	if s.minute, err = parseRotationScheduleField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: minute: %w", spec, err)
+	}
+	if s.hour, err = parseRotationScheduleField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: hour: %w", spec, err)
+	}
+	if s.dom, err = parseRotationScheduleField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: day of month: %w", spec, err)
+	}
+	if s.month, err = parseRotationScheduleField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: month: %w", spec, err)
+	}
+	// 7 is accepted for Sunday as well
+	if s.dow, err = parseRotationScheduleField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: day of week: %w", spec, err)
+	}
+	if s.dow[7] {
+		s.dow[0] = true
+	}
+	s.domRestricted = fields[2] != "*"
+	s.dowRestricted = fields[4] != "*"
+
+	return s, nil
+}
+
+// parseRotationScheduleField - parses a comma separated list of *, values and
+// ranges, each with an optional /step, e.g. "*/15" or "1-5,10"
+func parseRotationScheduleField(field string, minValue int, maxValue int) (map[int]bool, error) {
+	values := map[int]bool{}
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step %q", stepPart)
+			}
+		}
+
+		low, high := minValue, maxValue
+		if rangePart != "*" {
+			lowPart, highPart, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = strconv.Atoi(lowPart); err != nil {
+				return nil, fmt.Errorf("invalid value %q", lowPart)
+			}
+			high = low
+			if isRange {
+				if high, err = strconv.Atoi(highPart); err != nil {
+					return nil, fmt.Errorf("invalid value %q", highPart)
+				}
+			} else if hasStep {
+				// "5/10" means starting at 5 every 10
+				high = maxValue
+			}
+		}
+		if low < minValue || high > maxValue || low > high {
+			return nil, fmt.Errorf("%q out of range %d-%d", rangePart, minValue, maxValue)
+		}
+
+		for v := low; v <= high; v += step {
+			values[v] = true
+		}
+	}
+	return values, nil
+}
+
+func (s *rotationSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom[t.Day()]
+	dow := s.dow[int(t.Weekday())]
+	if s.domRestricted && s.dowRestricted {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+// next - returns the first time after t which matches the schedule
+func (s *rotationSchedule) next(t time.Time) (time.Time, error) {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(rotationScheduleSearchLimit)
+
+	for t.Before(limit) {
+		switch {
+		case !s.month[int(t.Month())]:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !s.hour[t.Hour()]:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case !s.minute[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("schedule does not match any time")
+}
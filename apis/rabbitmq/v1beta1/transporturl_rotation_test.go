@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCredentialRotationNextRotation(t *testing.T) {
+	last := time.Date(2025, time.March, 14, 10, 30, 15, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		rotation *TransportURLCredentialRotation
+		want     time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "interval is added to the last rotation",
+			rotation: &TransportURLCredentialRotation{Interval: &metav1.Duration{Duration: 90 * 24 * time.Hour}},
+			want:     time.Date(2025, time.June, 12, 10, 30, 15, 0, time.UTC),
+		},
+		{
+			name:     "every 15 minutes",
+			rotation: &TransportURLCredentialRotation{Schedule: "*/15 * * * *"},
+			want:     time.Date(2025, time.March, 14, 10, 45, 0, 0, time.UTC),
+		},
+		{
+			name:     "daily at 03:00",
+			rotation: &TransportURLCredentialRotation{Schedule: "0 3 * * *"},
+			want:     time.Date(2025, time.March, 15, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "quarterly on the first day of the month",
+			rotation: &TransportURLCredentialRotation{Schedule: "0 3 1 */3 *"},
+			want:     time.Date(2025, time.April, 1, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "wraps into the next year",
+			rotation: &TransportURLCredentialRotation{Schedule: "0 0 1 1,2 *"},
+			want:     time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "day of week range",
+			rotation: &TransportURLCredentialRotation{Schedule: "30 2 * * 1-5"},
+			want:     time.Date(2025, time.March, 17, 2, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "7 is Sunday",
+			rotation: &TransportURLCredentialRotation{Schedule: "0 0 * * 7"},
+			want:     time.Date(2025, time.March, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "day of month or day of week",
+			rotation: &TransportURLCredentialRotation{Schedule: "0 0 20 * 6"},
+			want:     time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "descriptor",
+			rotation: &TransportURLCredentialRotation{Schedule: "@monthly"},
+			want:     time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "schedule never matches",
+			rotation: &TransportURLCredentialRotation{Schedule: "0 0 31 2 *"},
+			wantErr:  true,
+		},
+		{
+			name:     "too few fields",
+			rotation: &TransportURLCredentialRotation{Schedule: "0 3 * *"},
+			wantErr:  true,
+		},
+		{
+			name:     "value out of range",
+			rotation: &TransportURLCredentialRotation{Schedule: "0 24 * * *"},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid step",
+			rotation: &TransportURLCredentialRotation{Schedule: "*/0 * * * *"},
+			wantErr:  true,
+		},
+		{
+			name:     "interval must be positive",
+			rotation: &TransportURLCredentialRotation{Interval: &metav1.Duration{}},
+			wantErr:  true,
+		},
+		{
+			name: "interval and schedule are mutually exclusive",
+			rotation: &TransportURLCredentialRotation{
+				Interval: &metav1.Duration{Duration: time.Hour},
+				Schedule: "@daily",
+			},
+			wantErr: true,
+		},
+		{
+			name:     "neither interval nor schedule",
+			rotation: &TransportURLCredentialRotation{},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.rotation.NextRotation(last)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("NextRotation() expected an error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NextRotation() unexpected error: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("NextRotation() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,6 +17,10 @@ limitations under the License.
 package v1beta1
 
 import (
+	"slices"
+	"sort"
+	"strings"
+
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -38,6 +42,156 @@ type TransportURLSpec struct {
 	// +kubebuilder:validation:Optional
 	// Vhost - RabbitMQ vhost name. If specified and vhost doesn't exist, a RabbitMQVhost will be created. Defaults to "/" if not specified
 	Vhost string `json:"vhost,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// CredentialRotation - policy to rotate the credentials of the RabbitMQ user managed by this TransportURL.
+	// Each rotation creates a new RabbitMQUser with a generated username, Username (or the TransportURL name if not set)
+	// with a timestamp suffix, and a generated password and switches over to it. The previous user gets removed once
+	// the owner service is ready and the grace period passed, regardless of BlockUserCleanup. Ignored if UserRef is specified
+	CredentialRotation *TransportURLCredentialRotation `json:"credentialRotation,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Permissions - permissions of the RabbitMQUser created for Username, passed through to the RabbitMQUser.
+	// Defaults to the full profile if not specified. Ignored if UserRef is specified
+	Permissions *TransportURLPermissions `json:"permissions,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	// BlockUserCleanup - add the cleanup-blocked finalizer to the RabbitMQUsers created for Username, so that
+	// users replaced on a username change are only deleted once an admin removed it. If false, replaced users get
+	// deleted once the owner service is ready and the grace period passed. Users of a CredentialRotation never get it
+	BlockUserCleanup *bool `json:"blockUserCleanup,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	// SecretFormats - additional formats of the connection details to add to the transport URL secret,
+	// next to the transport_url key. structured adds the keys hosts, port, username, password, vhost, ssl,
+	// amqp_urls and with TLS ca.crt. oslo-config adds an oslo.messaging configuration snippet in the
+	// oslo_messaging.conf key
+	SecretFormats []TransportURLSecretFormat `json:"secretFormats,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// HeartbeatTimeoutThreshold - oslo.messaging heartbeat_timeout_threshold in seconds used in the oslo-config
+	// secret format, 0 disables the heartbeat (defaults to 60)
+	HeartbeatTimeoutThreshold *int32 `json:"heartbeatTimeoutThreshold,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// HeartbeatRate - oslo.messaging heartbeat_rate used in the oslo-config secret format, how often
+	// the heartbeat gets checked within the heartbeat timeout threshold (defaults to 3)
+	HeartbeatRate *int32 `json:"heartbeatRate,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	// PreferPerPodServices - use the per-pod service hostnames from the RabbitMq status, if the cluster
+	// has per-pod services, so clients outside of the cluster like EDPM nodes reach each RabbitMQ node
+	// directly. If false, the host from the cluster default user secret is used
+	PreferPerPodServices *bool `json:"preferPerPodServices,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Notifications - separate target for oslo.messaging notifications, another cluster or vhost and
+	// another user. If specified, the notification_transport_url key gets added to the transport URL secret
+	Notifications *TransportURLNotifications `json:"notifications,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ClientCert - client certificate the service presents to RabbitMQ if the cluster has mutual TLS enabled.
+	// The oslo-config secret format then points ssl_cert_file and ssl_key_file to the paths the service mounts
+	// the certificate at, and uses the EXTERNAL login method if the cluster enables it. With EXTERNAL the common
+	// name of the certificate must match the RabbitMQ username
+	ClientCert *TransportURLClientCert `json:"clientCert,omitempty"`
+}
+
+// TransportURLClientCert defines the client certificate secret of a service and where the service mounts it
+type TransportURLClientCert struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// SecretName - name of the secret with the client certificate in the tls.crt and tls.key keys
+	SecretName string `json:"secretName"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="/etc/pki/tls/certs/rabbitmq-client.crt"
+	// CertFile - path of the client certificate in the service pods
+	CertFile string `json:"certFile,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="/etc/pki/tls/private/rabbitmq-client.key"
+	// KeyFile - path of the client key in the service pods
+	KeyFile string `json:"keyFile,omitempty"`
+}
+
+// TransportURLNotifications defines the RabbitMQ cluster, vhost and user used for notifications
+type TransportURLNotifications struct {
+	// +kubebuilder:validation:Optional
+	// RabbitmqClusterName - the name of the Rabbitmq cluster used for notifications. Defaults to the
+	// RabbitmqClusterName of the TransportURL if not specified
+	RabbitmqClusterName string `json:"rabbitmqClusterName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// UserRef - reference to a RabbitMQUser resource. If not specified, Username will be used to create a RabbitMQUser on-demand
+	UserRef string `json:"userRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Username - RabbitMQ username. If UserRef is not specified, a RabbitMQUser will be created with this username.
+	// If neither is specified, the default cluster admin credentials are used. The Username of the TransportURL on
+	// the same cluster shares its RabbitMQUser and must use the same vhost
+	Username string `json:"username,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Vhost - RabbitMQ vhost name. If specified and vhost doesn't exist, a RabbitMQVhost will be created. Defaults to "/" if not specified
+	Vhost string `json:"vhost,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Permissions - permissions of the RabbitMQUser created for Username, passed through to the RabbitMQUser.
+	// Defaults to the full profile if not specified. Ignored if UserRef is specified
+	Permissions *TransportURLPermissions `json:"permissions,omitempty"`
+}
+
+// TransportURLSecretFormat - additional format of the connection details in the transport URL secret
+// +kubebuilder:validation:Enum=structured;oslo-config
+type TransportURLSecretFormat string
+
+// TransportURLPermissions defines the permissions of the RabbitMQUser created by a TransportURL
+type TransportURLPermissions struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=full;rpc-client;notification-publisher
+	// +kubebuilder:default=full
+	// Profile - named permission preset on the vhost. full allows to configure, write and read everything.
+	// rpc-client grants what oslo.messaging RPC clients and servers need, which declare queues and exchanges
+	// per topic and server, without access to the notification queues. notification-publisher allows to declare and publish to exchanges and notification
+	// queues, read is limited to names without a dot, which allows binding the notification queues to the
+	// exchange but not consuming any messages
+	Profile string `json:"profile,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Custom - explicit configure, write and read regexes, used instead of the Profile
+	Custom *RabbitMQUserPermissions `json:"custom,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=exchange
+	// TopicPermissions - topic permissions on topic exchanges of the vhost
+	TopicPermissions []RabbitMQUserTopicPermission `json:"topicPermissions,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Tags - RabbitMQ user tags
+	Tags []string `json:"tags,omitempty"`
+}
+
+// TransportURLCredentialRotation defines when the credentials of a TransportURL get rotated.
+// Exactly one of Interval and Schedule must be set
+// +kubebuilder:validation:MinProperties=1
+// +kubebuilder:validation:MaxProperties=1
+type TransportURLCredentialRotation struct {
+	// +kubebuilder:validation:Optional
+	// Interval - rotate the credentials when this duration passed since the last rotation, e.g. 2160h for 90 days
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Schedule - rotate the credentials on a cron schedule in UTC with the five fields
+	// minute, hour, day of month, month and day of week, e.g. "0 3 1 */3 *".
+	// The descriptors @yearly, @monthly, @weekly and @daily are supported as well
+	Schedule string `json:"schedule,omitempty"`
 }
 
 // TransportURLStatus defines the observed state of TransportURL
@@ -62,6 +216,15 @@ type TransportURLStatus struct {
 	// Empty if using default cluster admin credentials (no dedicated RabbitMQUser CR)
 	RabbitmqUserRef string `json:"rabbitmqUserRef,omitempty"`
 
+	// LastCredentialRotation - time of the last credential rotation
+	LastCredentialRotation *metav1.Time `json:"lastCredentialRotation,omitempty"`
+
+	// NextCredentialRotation - time the credentials get rotated next
+	NextCredentialRotation *metav1.Time `json:"nextCredentialRotation,omitempty"`
+
+	// Notifications - the actual cluster, user and vhost used for notifications
+	Notifications *TransportURLNotificationsStatus `json:"notifications,omitempty"`
+
 	// ObservedGeneration - the most recent generation observed for this
 	// service. If the observed generation is less than the spec generation,
 	// then the controller has not processed the latest changes injected by
@@ -69,6 +232,22 @@ type TransportURLStatus struct {
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
+// TransportURLNotificationsStatus defines the observed state of the notifications target of a TransportURL
+type TransportURLNotificationsStatus struct {
+	// RabbitmqClusterName - the name of the Rabbitmq cluster used for notifications
+	RabbitmqClusterName string `json:"rabbitmqClusterName,omitempty"`
+
+	// RabbitmqUsername - the actual username used for notifications
+	RabbitmqUsername string `json:"rabbitmqUsername,omitempty"`
+
+	// RabbitmqVhost - the actual vhost name used for notifications
+	RabbitmqVhost string `json:"rabbitmqVhost,omitempty"`
+
+	// RabbitmqUserRef - the name of the RabbitMQUser CR that was created or referenced for notifications.
+	// Empty if using default cluster admin credentials (no dedicated RabbitMQUser CR)
+	RabbitmqUserRef string `json:"rabbitmqUserRef,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
@@ -100,3 +279,117 @@ func init() {
 func (instance TransportURL) IsReady() bool {
 	return instance.Status.Conditions.IsTrue(TransportURLReadyCondition)
 }
+
+const (
+	// TransportURLPermissionProfileFull - configure, write and read everything on the vhost
+	TransportURLPermissionProfileFull = "full"
+	// TransportURLPermissionProfileRPCClient - permissions of oslo.messaging RPC clients and servers
+	TransportURLPermissionProfileRPCClient = "rpc-client"
+	// TransportURLPermissionProfileNotificationPublisher - permissions of oslo.messaging notification publishers
+	TransportURLPermissionProfileNotificationPublisher = "notification-publisher"
+)
+
+const (
+	// TransportURLSecretFormatStructured - the connection details as separate keys
+	TransportURLSecretFormatStructured TransportURLSecretFormat = "structured"
+	// TransportURLSecretFormatOsloConfig - an oslo.messaging configuration snippet
+	TransportURLSecretFormatOsloConfig TransportURLSecretFormat = "oslo-config"
+)
+
+// rpcClientPermission - names of the exchanges and queues used by oslo.messaging RPC, the names
+// without a dot and the <topic>.<server> queues of all topics but the notification topics. The
+// webhook validates permissions as Go regexps, which have no lookahead to exclude the topics.
+var rpcClientPermission = `^([^.]+|` + segmentNotInRegex("notifications", "versioned_notifications") + `\..+)$`
+
+// segmentNotInRegex returns a regex matching a non-empty name part without dots which is none
+// of the given words, built from the trie of the words
+func segmentNotInRegex(words ...string) string {
+	return segmentNotIn(words, 0)
+}
+
+// segmentNotIn returns the regex of the remainder of a name part which matched the first depth
+// characters of the words
+func segmentNotIn(words []string, depth int) string {
+	terminal := false
+	children := map[byte][]string{}
+	for _, w := range words {
+		if len(w) == depth {
+			terminal = true
+			continue
+		}
+		children[w[depth]] = append(children[w[depth]], w)
+	}
+	if len(children) == 0 {
+		// the part equals a word unless it continues
+		return "[^.]+"
+	}
+
+	next := make([]byte, 0, len(children))
+	for c := range children {
+		next = append(next, c)
+	}
+	sort.Slice(next, func(i, j int) bool { return next[i] < next[j] })
+
+	alternatives := []string{"[^." + string(next) + "][^.]*"}
+	for _, c := range next {
+		alternatives = append(alternatives, string(c)+segmentNotIn(children[c], depth+1))
+	}
+	regex := "(?:" + strings.Join(alternatives, "|") + ")"
+	if depth > 0 && !terminal {
+		// a proper prefix of the words is none of them
+		regex += "?"
+	}
+	return regex
+}
+
+// transportURLPermissionProfiles - vhost permissions of the named profiles
+var transportURLPermissionProfiles = map[string]RabbitMQUserPermissions{
+	TransportURLPermissionProfileFull: {
+		Configure: ".*",
+		Write:     ".*",
+		Read:      ".*",
+	},
+	// reply_<id> queues and exchanges, the control exchange, the <topic> and <topic>.<server> queues
+	// and the <topic>_fanout exchanges and <topic>_fanout_<id> queues, but not the notification queues
+	TransportURLPermissionProfileRPCClient: {
+		Configure: rpcClientPermission,
+		Write:     rpcClientPermission,
+		Read:      rpcClientPermission,
+	},
+	TransportURLPermissionProfileNotificationPublisher: {
+		Configure: `^([^.]+|(versioned_)?notifications\..+)$`,
+		Write:     `^([^.]+|(versioned_)?notifications\..+)$`,
+		Read:      `^[^.]+$`,
+	},
+}
+
+// UserPermissions - returns the vhost permissions for the RabbitMQUser, the Custom
+// permissions if set, otherwise the ones of the Profile
+func (p *TransportURLPermissions) UserPermissions() RabbitMQUserPermissions {
+	if p == nil {
+		return transportURLPermissionProfiles[TransportURLPermissionProfileFull]
+	}
+	if p.Custom != nil {
+		return *p.Custom
+	}
+	if perms, ok := transportURLPermissionProfiles[p.Profile]; ok {
+		return perms
+	}
+	return transportURLPermissionProfiles[TransportURLPermissionProfileFull]
+}
+
+// HasSecretFormat - returns true if the format should be added to the transport URL secret
+func (spec TransportURLSpec) HasSecretFormat(format TransportURLSecretFormat) bool {
+	return slices.Contains(spec.SecretFormats, format)
+}
+
+// BlocksUserCleanup - returns true if the created RabbitMQUsers get the cleanup-blocked finalizer,
+// users created for a CredentialRotation never get it
+func (spec TransportURLSpec) BlocksUserCleanup() bool {
+	return spec.BlockUserCleanup == nil || *spec.BlockUserCleanup
+}
+
+// UsePerPodServices - returns true if the per-pod service hostnames should be used when available
+func (spec TransportURLSpec) UsePerPodServices() bool {
+	return spec.PreferPerPodServices == nil || *spec.PreferPerPodServices
+}
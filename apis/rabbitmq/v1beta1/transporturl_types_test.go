@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestTransportURLPermissionsUserPermissions(t *testing.T) {
+	full := RabbitMQUserPermissions{Configure: ".*", Write: ".*", Read: ".*"}
+	custom := RabbitMQUserPermissions{Configure: "^nova.*", Write: "^nova.*", Read: ""}
+
+	tests := []struct {
+		name        string
+		permissions *TransportURLPermissions
+		want        RabbitMQUserPermissions
+	}{
+		{
+			name:        "defaults to full permissions",
+			permissions: nil,
+			want:        full,
+		},
+		{
+			name:        "full profile",
+			permissions: &TransportURLPermissions{Profile: TransportURLPermissionProfileFull},
+			want:        full,
+		},
+		{
+			name:        "rpc-client profile",
+			permissions: &TransportURLPermissions{Profile: TransportURLPermissionProfileRPCClient},
+			want: RabbitMQUserPermissions{
+				Configure: rpcClientPermission,
+				Write:     rpcClientPermission,
+				Read:      rpcClientPermission,
+			},
+		},
+		{
+			name:        "notification-publisher profile",
+			permissions: &TransportURLPermissions{Profile: TransportURLPermissionProfileNotificationPublisher},
+			want: RabbitMQUserPermissions{
+				Configure: `^([^.]+|(versioned_)?notifications\..+)$`,
+				Write:     `^([^.]+|(versioned_)?notifications\..+)$`,
+				Read:      `^[^.]+$`,
+			},
+		},
+		{
+			name: "custom permissions take precedence over the profile",
+			permissions: &TransportURLPermissions{
+				Profile: TransportURLPermissionProfileNotificationPublisher,
+				Custom:  &custom,
+			},
+			want: custom,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.permissions.UserPermissions(); got != tt.want {
+				t.Errorf("UserPermissions() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRPCClientPermission(t *testing.T) {
+	full := transportURLPermissionProfiles[TransportURLPermissionProfileFull]
+	rpc := transportURLPermissionProfiles[TransportURLPermissionProfileRPCClient]
+	if rpc == full {
+		t.Fatalf("rpc-client profile must differ from the full profile")
+	}
+
+	re, err := regexp.Compile(rpc.Read)
+	if err != nil {
+		t.Fatalf("rpc-client permission does not compile: %v", err)
+	}
+	tests := map[string]bool{
+		"nova":                                 true,
+		"reply_0123456789abcdef":               true,
+		"compute_fanout":                       true,
+		"compute_fanout_0123456789abcdef":      true,
+		"compute.compute-0.example.com":        true,
+		"ironic.conductor_manager.conductor-0": true,
+		"notification.info":                    true,
+		"notifications.info":                   false,
+		"notifications.error":                  false,
+		"versioned_notifications.info":         false,
+	}
+	for name, want := range tests {
+		if got := re.MatchString(name); got != want {
+			t.Errorf("rpc-client permission match of %q = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -24,9 +24,26 @@ import (
 	topologyv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Adoption) DeepCopyInto(out *Adoption) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Adoption.
+func (in *Adoption) DeepCopy() *Adoption {
+	if in == nil {
+		return nil
+	}
+	out := new(Adoption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CredentialSelectors) DeepCopyInto(out *CredentialSelectors) {
 	*out = *in
@@ -42,6 +59,111 @@ func (in *CredentialSelectors) DeepCopy() *CredentialSelectors {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftDetection) DeepCopyInto(out *DriftDetection) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Remediate != nil {
+		in, out := &in.Remediate, &out.Remediate
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftDetection.
+func (in *DriftDetection) DeepCopy() *DriftDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretSource) DeepCopyInto(out *ExternalSecretSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretSource.
+func (in *ExternalSecretSource) DeepCopy() *ExternalSecretSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HashedCredentials) DeepCopyInto(out *HashedCredentials) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HashedCredentials.
+func (in *HashedCredentials) DeepCopy() *HashedCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(HashedCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MTLSSection) DeepCopyInto(out *MTLSSection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MTLSSection.
+func (in *MTLSSection) DeepCopy() *MTLSSection {
+	if in == nil {
+		return nil
+	}
+	out := new(MTLSSection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementTLS) DeepCopyInto(out *ManagementTLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementTLS.
+func (in *ManagementTLS) DeepCopy() *ManagementTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanCleanup) DeepCopyInto(out *OrphanCleanup) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanCleanup.
+func (in *OrphanCleanup) DeepCopy() *OrphanCleanup {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanCleanup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodOverride) DeepCopyInto(out *PodOverride) {
 	*out = *in
@@ -65,7 +187,7 @@ func (in *PodOverride) DeepCopy() *PodOverride {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMQPolicy) DeepCopyInto(out *RabbitMQPolicy) {
+func (in *RabbitMQBackup) DeepCopyInto(out *RabbitMQBackup) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -73,18 +195,18 @@ func (in *RabbitMQPolicy) DeepCopyInto(out *RabbitMQPolicy) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQPolicy.
-func (in *RabbitMQPolicy) DeepCopy() *RabbitMQPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQBackup.
+func (in *RabbitMQBackup) DeepCopy() *RabbitMQBackup {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMQPolicy)
+	out := new(RabbitMQBackup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RabbitMQPolicy) DeepCopyObject() runtime.Object {
+func (in *RabbitMQBackup) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -92,31 +214,47 @@ func (in *RabbitMQPolicy) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMQPolicyList) DeepCopyInto(out *RabbitMQPolicyList) {
+func (in *RabbitMQBackupEntry) DeepCopyInto(out *RabbitMQBackupEntry) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQBackupEntry.
+func (in *RabbitMQBackupEntry) DeepCopy() *RabbitMQBackupEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQBackupEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQBackupList) DeepCopyInto(out *RabbitMQBackupList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]RabbitMQPolicy, len(*in))
+		*out = make([]RabbitMQBackup, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQPolicyList.
-func (in *RabbitMQPolicyList) DeepCopy() *RabbitMQPolicyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQBackupList.
+func (in *RabbitMQBackupList) DeepCopy() *RabbitMQBackupList {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMQPolicyList)
+	out := new(RabbitMQBackupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RabbitMQPolicyList) DeepCopyObject() runtime.Object {
+func (in *RabbitMQBackupList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -124,23 +262,23 @@ func (in *RabbitMQPolicyList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMQPolicySpec) DeepCopyInto(out *RabbitMQPolicySpec) {
+func (in *RabbitMQBackupSpec) DeepCopyInto(out *RabbitMQBackupSpec) {
 	*out = *in
-	in.Definition.DeepCopyInto(&out.Definition)
+	out.Storage = in.Storage
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQPolicySpec.
-func (in *RabbitMQPolicySpec) DeepCopy() *RabbitMQPolicySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQBackupSpec.
+func (in *RabbitMQBackupSpec) DeepCopy() *RabbitMQBackupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMQPolicySpec)
+	out := new(RabbitMQBackupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMQPolicyStatus) DeepCopyInto(out *RabbitMQPolicyStatus) {
+func (in *RabbitMQBackupStatus) DeepCopyInto(out *RabbitMQBackupStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -149,20 +287,50 @@ func (in *RabbitMQPolicyStatus) DeepCopyInto(out *RabbitMQPolicyStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Backups != nil {
+		in, out := &in.Backups, &out.Backups
+		*out = make([]RabbitMQBackupEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastBackupTime != nil {
+		in, out := &in.LastBackupTime, &out.LastBackupTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextBackupTime != nil {
+		in, out := &in.NextBackupTime, &out.NextBackupTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQPolicyStatus.
-func (in *RabbitMQPolicyStatus) DeepCopy() *RabbitMQPolicyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQBackupStatus.
+func (in *RabbitMQBackupStatus) DeepCopy() *RabbitMQBackupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMQPolicyStatus)
+	out := new(RabbitMQBackupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMQUser) DeepCopyInto(out *RabbitMQUser) {
+func (in *RabbitMQBackupStorage) DeepCopyInto(out *RabbitMQBackupStorage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQBackupStorage.
+func (in *RabbitMQBackupStorage) DeepCopy() *RabbitMQBackupStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQBackupStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQBinding) DeepCopyInto(out *RabbitMQBinding) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -170,18 +338,18 @@ func (in *RabbitMQUser) DeepCopyInto(out *RabbitMQUser) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQUser.
-func (in *RabbitMQUser) DeepCopy() *RabbitMQUser {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQBinding.
+func (in *RabbitMQBinding) DeepCopy() *RabbitMQBinding {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMQUser)
+	out := new(RabbitMQBinding)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RabbitMQUser) DeepCopyObject() runtime.Object {
+func (in *RabbitMQBinding) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -189,31 +357,31 @@ func (in *RabbitMQUser) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMQUserList) DeepCopyInto(out *RabbitMQUserList) {
+func (in *RabbitMQBindingList) DeepCopyInto(out *RabbitMQBindingList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]RabbitMQUser, len(*in))
+		*out = make([]RabbitMQBinding, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQUserList.
-func (in *RabbitMQUserList) DeepCopy() *RabbitMQUserList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQBindingList.
+func (in *RabbitMQBindingList) DeepCopy() *RabbitMQBindingList {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMQUserList)
+	out := new(RabbitMQBindingList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RabbitMQUserList) DeepCopyObject() runtime.Object {
+func (in *RabbitMQBindingList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -221,53 +389,27 @@ func (in *RabbitMQUserList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMQUserPermissions) DeepCopyInto(out *RabbitMQUserPermissions) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQUserPermissions.
-func (in *RabbitMQUserPermissions) DeepCopy() *RabbitMQUserPermissions {
-	if in == nil {
-		return nil
-	}
-	out := new(RabbitMQUserPermissions)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMQUserSpec) DeepCopyInto(out *RabbitMQUserSpec) {
+func (in *RabbitMQBindingSpec) DeepCopyInto(out *RabbitMQBindingSpec) {
 	*out = *in
-	if in.Secret != nil {
-		in, out := &in.Secret, &out.Secret
-		*out = new(string)
-		**out = **in
-	}
-	if in.CredentialSelectors != nil {
-		in, out := &in.CredentialSelectors, &out.CredentialSelectors
-		*out = new(CredentialSelectors)
-		**out = **in
-	}
-	out.Permissions = in.Permissions
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Arguments != nil {
+		in, out := &in.Arguments, &out.Arguments
+		*out = new(v1.JSON)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQUserSpec.
-func (in *RabbitMQUserSpec) DeepCopy() *RabbitMQUserSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQBindingSpec.
+func (in *RabbitMQBindingSpec) DeepCopy() *RabbitMQBindingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMQUserSpec)
+	out := new(RabbitMQBindingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMQUserStatus) DeepCopyInto(out *RabbitMQUserStatus) {
+func (in *RabbitMQBindingStatus) DeepCopyInto(out *RabbitMQBindingStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -278,37 +420,37 @@ func (in *RabbitMQUserStatus) DeepCopyInto(out *RabbitMQUserStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQUserStatus.
-func (in *RabbitMQUserStatus) DeepCopy() *RabbitMQUserStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQBindingStatus.
+func (in *RabbitMQBindingStatus) DeepCopy() *RabbitMQBindingStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMQUserStatus)
+	out := new(RabbitMQBindingStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMQVhost) DeepCopyInto(out *RabbitMQVhost) {
+func (in *RabbitMQExchange) DeepCopyInto(out *RabbitMQExchange) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQVhost.
-func (in *RabbitMQVhost) DeepCopy() *RabbitMQVhost {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQExchange.
+func (in *RabbitMQExchange) DeepCopy() *RabbitMQExchange {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMQVhost)
+	out := new(RabbitMQExchange)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RabbitMQVhost) DeepCopyObject() runtime.Object {
+func (in *RabbitMQExchange) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -316,31 +458,31 @@ func (in *RabbitMQVhost) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMQVhostList) DeepCopyInto(out *RabbitMQVhostList) {
+func (in *RabbitMQExchangeList) DeepCopyInto(out *RabbitMQExchangeList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]RabbitMQVhost, len(*in))
+		*out = make([]RabbitMQExchange, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQVhostList.
-func (in *RabbitMQVhostList) DeepCopy() *RabbitMQVhostList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQExchangeList.
+func (in *RabbitMQExchangeList) DeepCopy() *RabbitMQExchangeList {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMQVhostList)
+	out := new(RabbitMQExchangeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RabbitMQVhostList) DeepCopyObject() runtime.Object {
+func (in *RabbitMQExchangeList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -348,22 +490,27 @@ func (in *RabbitMQVhostList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMQVhostSpec) DeepCopyInto(out *RabbitMQVhostSpec) {
+func (in *RabbitMQExchangeSpec) DeepCopyInto(out *RabbitMQExchangeSpec) {
 	*out = *in
+	if in.Arguments != nil {
+		in, out := &in.Arguments, &out.Arguments
+		*out = new(v1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQVhostSpec.
-func (in *RabbitMQVhostSpec) DeepCopy() *RabbitMQVhostSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQExchangeSpec.
+func (in *RabbitMQExchangeSpec) DeepCopy() *RabbitMQExchangeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMQVhostSpec)
+	out := new(RabbitMQExchangeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMQVhostStatus) DeepCopyInto(out *RabbitMQVhostStatus) {
+func (in *RabbitMQExchangeStatus) DeepCopyInto(out *RabbitMQExchangeStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -374,18 +521,18 @@ func (in *RabbitMQVhostStatus) DeepCopyInto(out *RabbitMQVhostStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQVhostStatus.
-func (in *RabbitMQVhostStatus) DeepCopy() *RabbitMQVhostStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQExchangeStatus.
+func (in *RabbitMQExchangeStatus) DeepCopy() *RabbitMQExchangeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMQVhostStatus)
+	out := new(RabbitMQExchangeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMq) DeepCopyInto(out *RabbitMq) {
+func (in *RabbitMQFederationUpstream) DeepCopyInto(out *RabbitMQFederationUpstream) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -393,18 +540,18 @@ func (in *RabbitMq) DeepCopyInto(out *RabbitMq) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMq.
-func (in *RabbitMq) DeepCopy() *RabbitMq {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQFederationUpstream.
+func (in *RabbitMQFederationUpstream) DeepCopy() *RabbitMQFederationUpstream {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMq)
+	out := new(RabbitMQFederationUpstream)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RabbitMq) DeepCopyObject() runtime.Object {
+func (in *RabbitMQFederationUpstream) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -412,61 +559,46 @@ func (in *RabbitMq) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMqConfig) DeepCopyInto(out *RabbitMqConfig) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMqConfig.
-func (in *RabbitMqConfig) DeepCopy() *RabbitMqConfig {
-	if in == nil {
-		return nil
-	}
-	out := new(RabbitMqConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMqDefaults) DeepCopyInto(out *RabbitMqDefaults) {
+func (in *RabbitMQFederationUpstreamCluster) DeepCopyInto(out *RabbitMQFederationUpstreamCluster) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMqDefaults.
-func (in *RabbitMqDefaults) DeepCopy() *RabbitMqDefaults {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQFederationUpstreamCluster.
+func (in *RabbitMQFederationUpstreamCluster) DeepCopy() *RabbitMQFederationUpstreamCluster {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMqDefaults)
+	out := new(RabbitMQFederationUpstreamCluster)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMqList) DeepCopyInto(out *RabbitMqList) {
+func (in *RabbitMQFederationUpstreamList) DeepCopyInto(out *RabbitMQFederationUpstreamList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]RabbitMq, len(*in))
+		*out = make([]RabbitMQFederationUpstream, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMqList.
-func (in *RabbitMqList) DeepCopy() *RabbitMqList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQFederationUpstreamList.
+func (in *RabbitMQFederationUpstreamList) DeepCopy() *RabbitMQFederationUpstreamList {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMqList)
+	out := new(RabbitMQFederationUpstreamList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RabbitMqList) DeepCopyObject() runtime.Object {
+func (in *RabbitMQFederationUpstreamList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -474,35 +606,1097 @@ func (in *RabbitMqList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMqSpec) DeepCopyInto(out *RabbitMqSpec) {
+func (in *RabbitMQFederationUpstreamSpec) DeepCopyInto(out *RabbitMQFederationUpstreamSpec) {
 	*out = *in
-	in.RabbitMqSpecCore.DeepCopyInto(&out.RabbitMqSpecCore)
+	out.Upstream = in.Upstream
+	if in.PrefetchCount != nil {
+		in, out := &in.PrefetchCount, &out.PrefetchCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReconnectDelay != nil {
+		in, out := &in.ReconnectDelay, &out.ReconnectDelay
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxHops != nil {
+		in, out := &in.MaxHops, &out.MaxHops
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMqSpec.
-func (in *RabbitMqSpec) DeepCopy() *RabbitMqSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQFederationUpstreamSpec.
+func (in *RabbitMQFederationUpstreamSpec) DeepCopy() *RabbitMQFederationUpstreamSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RabbitMqSpec)
+	out := new(RabbitMQFederationUpstreamSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RabbitMqSpecCore) DeepCopyInto(out *RabbitMqSpecCore) {
+func (in *RabbitMQFederationUpstreamStatus) DeepCopyInto(out *RabbitMQFederationUpstreamStatus) {
 	*out = *in
-	in.RabbitmqClusterSpecCore.DeepCopyInto(&out.RabbitmqClusterSpecCore)
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = new(map[string]string)
-		if **in != nil {
-			in, out := *in, *out
-			*out = make(map[string]string, len(*in))
-			for key, val := range *in {
-				(*out)[key] = val
-			}
-		}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQFederationUpstreamStatus.
+func (in *RabbitMQFederationUpstreamStatus) DeepCopy() *RabbitMQFederationUpstreamStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQFederationUpstreamStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQPolicy) DeepCopyInto(out *RabbitMQPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQPolicy.
+func (in *RabbitMQPolicy) DeepCopy() *RabbitMQPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RabbitMQPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQPolicyList) DeepCopyInto(out *RabbitMQPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RabbitMQPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQPolicyList.
+func (in *RabbitMQPolicyList) DeepCopy() *RabbitMQPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RabbitMQPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQPolicySpec) DeepCopyInto(out *RabbitMQPolicySpec) {
+	*out = *in
+	in.Definition.DeepCopyInto(&out.Definition)
+	if in.DriftDetection != nil {
+		in, out := &in.DriftDetection, &out.DriftDetection
+		*out = new(DriftDetection)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQPolicySpec.
+func (in *RabbitMQPolicySpec) DeepCopy() *RabbitMQPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQPolicyStatus) DeepCopyInto(out *RabbitMQPolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQPolicyStatus.
+func (in *RabbitMQPolicyStatus) DeepCopy() *RabbitMQPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQQueue) DeepCopyInto(out *RabbitMQQueue) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQQueue.
+func (in *RabbitMQQueue) DeepCopy() *RabbitMQQueue {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQQueue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RabbitMQQueue) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQQueueList) DeepCopyInto(out *RabbitMQQueueList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RabbitMQQueue, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQQueueList.
+func (in *RabbitMQQueueList) DeepCopy() *RabbitMQQueueList {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQQueueList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RabbitMQQueueList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQQueueSpec) DeepCopyInto(out *RabbitMQQueueSpec) {
+	*out = *in
+	if in.Arguments != nil {
+		in, out := &in.Arguments, &out.Arguments
+		*out = new(v1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQQueueSpec.
+func (in *RabbitMQQueueSpec) DeepCopy() *RabbitMQQueueSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQQueueSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQQueueStatus) DeepCopyInto(out *RabbitMQQueueStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQQueueStatus.
+func (in *RabbitMQQueueStatus) DeepCopy() *RabbitMQQueueStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQQueueStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQRestore) DeepCopyInto(out *RabbitMQRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQRestore.
+func (in *RabbitMQRestore) DeepCopy() *RabbitMQRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RabbitMQRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQRestoreList) DeepCopyInto(out *RabbitMQRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RabbitMQRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQRestoreList.
+func (in *RabbitMQRestoreList) DeepCopy() *RabbitMQRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RabbitMQRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQRestoreSpec) DeepCopyInto(out *RabbitMQRestoreSpec) {
+	*out = *in
+	if in.Vhosts != nil {
+		in, out := &in.Vhosts, &out.Vhosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQRestoreSpec.
+func (in *RabbitMQRestoreSpec) DeepCopy() *RabbitMQRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQRestoreStatus) DeepCopyInto(out *RabbitMQRestoreStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQRestoreStatus.
+func (in *RabbitMQRestoreStatus) DeepCopy() *RabbitMQRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQShovel) DeepCopyInto(out *RabbitMQShovel) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQShovel.
+func (in *RabbitMQShovel) DeepCopy() *RabbitMQShovel {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQShovel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RabbitMQShovel) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQShovelEndpoint) DeepCopyInto(out *RabbitMQShovelEndpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQShovelEndpoint.
+func (in *RabbitMQShovelEndpoint) DeepCopy() *RabbitMQShovelEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQShovelEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQShovelList) DeepCopyInto(out *RabbitMQShovelList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RabbitMQShovel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQShovelList.
+func (in *RabbitMQShovelList) DeepCopy() *RabbitMQShovelList {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQShovelList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RabbitMQShovelList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQShovelSpec) DeepCopyInto(out *RabbitMQShovelSpec) {
+	*out = *in
+	out.Source = in.Source
+	out.Destination = in.Destination
+	if in.PrefetchCount != nil {
+		in, out := &in.PrefetchCount, &out.PrefetchCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReconnectDelay != nil {
+		in, out := &in.ReconnectDelay, &out.ReconnectDelay
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQShovelSpec.
+func (in *RabbitMQShovelSpec) DeepCopy() *RabbitMQShovelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQShovelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQShovelStatus) DeepCopyInto(out *RabbitMQShovelStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQShovelStatus.
+func (in *RabbitMQShovelStatus) DeepCopy() *RabbitMQShovelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQShovelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQUser) DeepCopyInto(out *RabbitMQUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQUser.
+func (in *RabbitMQUser) DeepCopy() *RabbitMQUser {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RabbitMQUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQUserLimits) DeepCopyInto(out *RabbitMQUserLimits) {
+	*out = *in
+	if in.MaxConnections != nil {
+		in, out := &in.MaxConnections, &out.MaxConnections
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxChannels != nil {
+		in, out := &in.MaxChannels, &out.MaxChannels
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQUserLimits.
+func (in *RabbitMQUserLimits) DeepCopy() *RabbitMQUserLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQUserLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQUserList) DeepCopyInto(out *RabbitMQUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RabbitMQUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQUserList.
+func (in *RabbitMQUserList) DeepCopy() *RabbitMQUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RabbitMQUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQUserPermissions) DeepCopyInto(out *RabbitMQUserPermissions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQUserPermissions.
+func (in *RabbitMQUserPermissions) DeepCopy() *RabbitMQUserPermissions {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQUserPermissions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQUserSpec) DeepCopyInto(out *RabbitMQUserSpec) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(string)
+		**out = **in
+	}
+	if in.CredentialSelectors != nil {
+		in, out := &in.CredentialSelectors, &out.CredentialSelectors
+		*out = new(CredentialSelectors)
+		**out = **in
+	}
+	if in.HashedCredentials != nil {
+		in, out := &in.HashedCredentials, &out.HashedCredentials
+		*out = new(HashedCredentials)
+		**out = **in
+	}
+	if in.ExternalSecret != nil {
+		in, out := &in.ExternalSecret, &out.ExternalSecret
+		*out = new(ExternalSecretSource)
+		**out = **in
+	}
+	out.Permissions = in.Permissions
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TopicPermissions != nil {
+		in, out := &in.TopicPermissions, &out.TopicPermissions
+		*out = make([]RabbitMQUserTopicPermission, len(*in))
+		copy(*out, *in)
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(RabbitMQUserLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DriftDetection != nil {
+		in, out := &in.DriftDetection, &out.DriftDetection
+		*out = new(DriftDetection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(Adoption)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQUserSpec.
+func (in *RabbitMQUserSpec) DeepCopy() *RabbitMQUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQUserStatus) DeepCopyInto(out *RabbitMQUserStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Adopted != nil {
+		in, out := &in.Adopted, &out.Adopted
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQUserStatus.
+func (in *RabbitMQUserStatus) DeepCopy() *RabbitMQUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQUserTopicPermission) DeepCopyInto(out *RabbitMQUserTopicPermission) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQUserTopicPermission.
+func (in *RabbitMQUserTopicPermission) DeepCopy() *RabbitMQUserTopicPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQUserTopicPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQVhost) DeepCopyInto(out *RabbitMQVhost) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQVhost.
+func (in *RabbitMQVhost) DeepCopy() *RabbitMQVhost {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQVhost)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RabbitMQVhost) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQVhostLimits) DeepCopyInto(out *RabbitMQVhostLimits) {
+	*out = *in
+	if in.MaxConnections != nil {
+		in, out := &in.MaxConnections, &out.MaxConnections
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxQueues != nil {
+		in, out := &in.MaxQueues, &out.MaxQueues
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQVhostLimits.
+func (in *RabbitMQVhostLimits) DeepCopy() *RabbitMQVhostLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQVhostLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQVhostList) DeepCopyInto(out *RabbitMQVhostList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RabbitMQVhost, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQVhostList.
+func (in *RabbitMQVhostList) DeepCopy() *RabbitMQVhostList {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQVhostList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RabbitMQVhostList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQVhostSpec) DeepCopyInto(out *RabbitMQVhostSpec) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(RabbitMQVhostLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DriftDetection != nil {
+		in, out := &in.DriftDetection, &out.DriftDetection
+		*out = new(DriftDetection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(Adoption)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQVhostSpec.
+func (in *RabbitMQVhostSpec) DeepCopy() *RabbitMQVhostSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQVhostSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMQVhostStatus) DeepCopyInto(out *RabbitMQVhostStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(condition.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Adopted != nil {
+		in, out := &in.Adopted, &out.Adopted
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMQVhostStatus.
+func (in *RabbitMQVhostStatus) DeepCopy() *RabbitMQVhostStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMQVhostStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMq) DeepCopyInto(out *RabbitMq) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMq.
+func (in *RabbitMq) DeepCopy() *RabbitMq {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMq)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RabbitMq) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMqConfig) DeepCopyInto(out *RabbitMqConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMqConfig.
+func (in *RabbitMqConfig) DeepCopy() *RabbitMqConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMqConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMqDefaults) DeepCopyInto(out *RabbitMqDefaults) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMqDefaults.
+func (in *RabbitMqDefaults) DeepCopy() *RabbitMqDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMqDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMqHealth) DeepCopyInto(out *RabbitMqHealth) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Alarms != nil {
+		in, out := &in.Alarms, &out.Alarms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.QuorumCriticalQueues != nil {
+		in, out := &in.QuorumCriticalQueues, &out.QuorumCriticalQueues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMqHealth.
+func (in *RabbitMqHealth) DeepCopy() *RabbitMqHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMqHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMqList) DeepCopyInto(out *RabbitMqList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RabbitMq, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMqList.
+func (in *RabbitMqList) DeepCopy() *RabbitMqList {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMqList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RabbitMqList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMqNodeVersion) DeepCopyInto(out *RabbitMqNodeVersion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMqNodeVersion.
+func (in *RabbitMqNodeVersion) DeepCopy() *RabbitMqNodeVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMqNodeVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMqOrphans) DeepCopyInto(out *RabbitMqOrphans) {
+	*out = *in
+	if in.LastSweepTime != nil {
+		in, out := &in.LastSweepTime, &out.LastSweepTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Vhosts != nil {
+		in, out := &in.Vhosts, &out.Vhosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMqOrphans.
+func (in *RabbitMqOrphans) DeepCopy() *RabbitMqOrphans {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMqOrphans)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMqQueueMigration) DeepCopyInto(out *RabbitMqQueueMigration) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.SwitchTime != nil {
+		in, out := &in.SwitchTime, &out.SwitchTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Vhosts != nil {
+		in, out := &in.Vhosts, &out.Vhosts
+		*out = make([]RabbitMqVhostQueueMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMqQueueMigration.
+func (in *RabbitMqQueueMigration) DeepCopy() *RabbitMqQueueMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMqQueueMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMqScaleDown) DeepCopyInto(out *RabbitMqScaleDown) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ShrunkNodes != nil {
+		in, out := &in.ShrunkNodes, &out.ShrunkNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMqScaleDown.
+func (in *RabbitMqScaleDown) DeepCopy() *RabbitMqScaleDown {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMqScaleDown)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMqSpec) DeepCopyInto(out *RabbitMqSpec) {
+	*out = *in
+	in.RabbitMqSpecCore.DeepCopyInto(&out.RabbitMqSpecCore)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMqSpec.
+func (in *RabbitMqSpec) DeepCopy() *RabbitMqSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMqSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMqSpecCore) DeepCopyInto(out *RabbitMqSpecCore) {
+	*out = *in
+	in.RabbitmqClusterSpecCore.DeepCopyInto(&out.RabbitmqClusterSpecCore)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(map[string]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make(map[string]string, len(*in))
+			for key, val := range *in {
+				(*out)[key] = val
+			}
+		}
 	}
 	if in.TopologyRef != nil {
 		in, out := &in.TopologyRef, &out.TopologyRef
@@ -519,6 +1713,37 @@ func (in *RabbitMqSpecCore) DeepCopyInto(out *RabbitMqSpecCore) {
 		*out = new(PodOverride)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OrphanCleanup != nil {
+		in, out := &in.OrphanCleanup, &out.OrphanCleanup
+		*out = new(OrphanCleanup)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ManagementTLS != nil {
+		in, out := &in.ManagementTLS, &out.ManagementTLS
+		*out = new(ManagementTLS)
+		**out = **in
+	}
+	if in.MTLS != nil {
+		in, out := &in.MTLS, &out.MTLS
+		*out = new(MTLSSection)
+		**out = **in
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+
+	if in.Tuning != nil {
+		in, out := &in.Tuning, &out.Tuning
+		*out = new(Tuning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Streams != nil {
+		in, out := &in.Streams, &out.Streams
+		*out = new(StreamsSection)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMqSpecCore.
@@ -551,6 +1776,41 @@ func (in *RabbitMqStatus) DeepCopyInto(out *RabbitMqStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Orphans != nil {
+		in, out := &in.Orphans, &out.Orphans
+		*out = new(RabbitMqOrphans)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.QueueMigration != nil {
+		in, out := &in.QueueMigration, &out.QueueMigration
+		*out = new(RabbitMqQueueMigration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScaleDown != nil {
+		in, out := &in.ScaleDown, &out.ScaleDown
+		*out = new(RabbitMqScaleDown)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnabledPlugins != nil {
+		in, out := &in.EnabledPlugins, &out.EnabledPlugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingFeatureFlags != nil {
+		in, out := &in.PendingFeatureFlags, &out.PendingFeatureFlags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(RabbitMqHealth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(RabbitMqUpgrade)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMqStatus.
@@ -563,12 +1823,85 @@ func (in *RabbitMqStatus) DeepCopy() *RabbitMqStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMqUpgrade) DeepCopyInto(out *RabbitMqUpgrade) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRestartTime != nil {
+		in, out := &in.LastRestartTime, &out.LastRestartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]RabbitMqNodeVersion, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMqUpgrade.
+func (in *RabbitMqUpgrade) DeepCopy() *RabbitMqUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMqUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RabbitMqVhostQueueMigration) DeepCopyInto(out *RabbitMqVhostQueueMigration) {
+	*out = *in
+	if in.Pending != nil {
+		in, out := &in.Pending, &out.Pending
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RabbitMqVhostQueueMigration.
+func (in *RabbitMqVhostQueueMigration) DeepCopy() *RabbitMqVhostQueueMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(RabbitMqVhostQueueMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StreamsSection) DeepCopyInto(out *StreamsSection) {
+	*out = *in
+	if in.Vhosts != nil {
+		in, out := &in.Vhosts, &out.Vhosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxLengthBytes != nil {
+		in, out := &in.MaxLengthBytes, &out.MaxLengthBytes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StreamsSection.
+func (in *StreamsSection) DeepCopy() *StreamsSection {
+	if in == nil {
+		return nil
+	}
+	out := new(StreamsSection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransportURL) DeepCopyInto(out *TransportURL) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -590,6 +1923,41 @@ func (in *TransportURL) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransportURLClientCert) DeepCopyInto(out *TransportURLClientCert) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportURLClientCert.
+func (in *TransportURLClientCert) DeepCopy() *TransportURLClientCert {
+	if in == nil {
+		return nil
+	}
+	out := new(TransportURLClientCert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransportURLCredentialRotation) DeepCopyInto(out *TransportURLCredentialRotation) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportURLCredentialRotation.
+func (in *TransportURLCredentialRotation) DeepCopy() *TransportURLCredentialRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(TransportURLCredentialRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransportURLList) DeepCopyInto(out *TransportURLList) {
 	*out = *in
@@ -622,9 +1990,119 @@ func (in *TransportURLList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransportURLNotifications) DeepCopyInto(out *TransportURLNotifications) {
+	*out = *in
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = new(TransportURLPermissions)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportURLNotifications.
+func (in *TransportURLNotifications) DeepCopy() *TransportURLNotifications {
+	if in == nil {
+		return nil
+	}
+	out := new(TransportURLNotifications)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransportURLNotificationsStatus) DeepCopyInto(out *TransportURLNotificationsStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportURLNotificationsStatus.
+func (in *TransportURLNotificationsStatus) DeepCopy() *TransportURLNotificationsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TransportURLNotificationsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransportURLPermissions) DeepCopyInto(out *TransportURLPermissions) {
+	*out = *in
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = new(RabbitMQUserPermissions)
+		**out = **in
+	}
+	if in.TopicPermissions != nil {
+		in, out := &in.TopicPermissions, &out.TopicPermissions
+		*out = make([]RabbitMQUserTopicPermission, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportURLPermissions.
+func (in *TransportURLPermissions) DeepCopy() *TransportURLPermissions {
+	if in == nil {
+		return nil
+	}
+	out := new(TransportURLPermissions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TransportURLSpec) DeepCopyInto(out *TransportURLSpec) {
 	*out = *in
+	if in.CredentialRotation != nil {
+		in, out := &in.CredentialRotation, &out.CredentialRotation
+		*out = new(TransportURLCredentialRotation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = new(TransportURLPermissions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BlockUserCleanup != nil {
+		in, out := &in.BlockUserCleanup, &out.BlockUserCleanup
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SecretFormats != nil {
+		in, out := &in.SecretFormats, &out.SecretFormats
+		*out = make([]TransportURLSecretFormat, len(*in))
+		copy(*out, *in)
+	}
+	if in.HeartbeatTimeoutThreshold != nil {
+		in, out := &in.HeartbeatTimeoutThreshold, &out.HeartbeatTimeoutThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HeartbeatRate != nil {
+		in, out := &in.HeartbeatRate, &out.HeartbeatRate
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PreferPerPodServices != nil {
+		in, out := &in.PreferPerPodServices, &out.PreferPerPodServices
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(TransportURLNotifications)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCert != nil {
+		in, out := &in.ClientCert, &out.ClientCert
+		*out = new(TransportURLClientCert)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportURLSpec.
@@ -647,6 +2125,19 @@ func (in *TransportURLStatus) DeepCopyInto(out *TransportURLStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastCredentialRotation != nil {
+		in, out := &in.LastCredentialRotation, &out.LastCredentialRotation
+		*out = (*in).DeepCopy()
+	}
+	if in.NextCredentialRotation != nil {
+		in, out := &in.NextCredentialRotation, &out.NextCredentialRotation
+		*out = (*in).DeepCopy()
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(TransportURLNotificationsStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransportURLStatus.
@@ -658,3 +2149,48 @@ func (in *TransportURLStatus) DeepCopy() *TransportURLStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tuning) DeepCopyInto(out *Tuning) {
+	*out = *in
+	if in.VMMemoryHighWatermark != nil {
+		in, out := &in.VMMemoryHighWatermark, &out.VMMemoryHighWatermark
+		*out = new(string)
+		**out = **in
+	}
+	if in.DiskFreeLimit != nil {
+		in, out := &in.DiskFreeLimit, &out.DiskFreeLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ChannelMax != nil {
+		in, out := &in.ChannelMax, &out.ChannelMax
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Heartbeat != nil {
+		in, out := &in.Heartbeat, &out.Heartbeat
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ConsumerTimeout != nil {
+		in, out := &in.ConsumerTimeout, &out.ConsumerTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.CollectStatisticsInterval != nil {
+		in, out := &in.CollectStatisticsInterval, &out.CollectStatisticsInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tuning.
+func (in *Tuning) DeepCopy() *Tuning {
+	if in == nil {
+		return nil
+	}
+	out := new(Tuning)
+	in.DeepCopyInto(out)
+	return out
+}
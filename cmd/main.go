@@ -48,6 +48,7 @@ import (
 	webhooknetworkv1beta1 "github.com/openstack-k8s-operators/infra-operator/internal/webhook/network/v1beta1"
 	webhookrabbitmqv1beta1 "github.com/openstack-k8s-operators/infra-operator/internal/webhook/rabbitmq/v1beta1"
 	webhookredisv1beta1 "github.com/openstack-k8s-operators/infra-operator/internal/webhook/redis/v1beta1"
+	"github.com/openstack-k8s-operators/infra-operator/pkg/rabbitmq/secretprovider"
 
 	// +kubebuilder:scaffold:imports
 	"context"
@@ -99,6 +100,7 @@ func main() {
 	var webhookPort int
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var rabbitmqUserSecretsDir string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -119,6 +121,8 @@ func main() {
 	flag.IntVar(&webhookPort, "webhook-bind-address", 9443, "The port the webhook server binds to.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&rabbitmqUserSecretsDir, "rabbitmq-user-secrets-dir", "/var/run/secrets/rabbitmq-users",
+		"The directory with the mounted passwords of RabbitMQUsers using the file external secret provider.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -359,6 +363,9 @@ func main() {
 		Client:  mgr.GetClient(),
 		Scheme:  mgr.GetScheme(),
 		Kclient: kclient,
+		SecretProviders: map[string]secretprovider.Provider{
+			rabbitmqv1beta1.ExternalSecretProviderFile: secretprovider.NewFileProvider(rabbitmqUserSecretsDir),
+		},
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "RabbitMQUser")
 		os.Exit(1)
@@ -373,6 +380,69 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := (&rabbitmqcontroller.RabbitMQQueueReconciler{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RabbitMQQueue")
+		os.Exit(1)
+	}
+
+	if err := (&rabbitmqcontroller.RabbitMQExchangeReconciler{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RabbitMQExchange")
+		os.Exit(1)
+	}
+
+	if err := (&rabbitmqcontroller.RabbitMQBindingReconciler{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RabbitMQBinding")
+		os.Exit(1)
+	}
+
+	if err := (&rabbitmqcontroller.RabbitMQShovelReconciler{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RabbitMQShovel")
+		os.Exit(1)
+	}
+
+	if err := (&rabbitmqcontroller.RabbitMQFederationUpstreamReconciler{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RabbitMQFederationUpstream")
+		os.Exit(1)
+	}
+
+	if err := (&rabbitmqcontroller.RabbitMQBackupReconciler{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RabbitMQBackup")
+		os.Exit(1)
+	}
+
+	if err := (&rabbitmqcontroller.RabbitMQRestoreReconciler{
+		Client:  mgr.GetClient(),
+		Scheme:  mgr.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RabbitMQRestore")
+		os.Exit(1)
+	}
+
 	// Initialize webhook defaults
 	rabbitmqv1beta1.SetupDefaults()
 	memcachedv1.SetupDefaults()
@@ -419,6 +489,34 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "RabbitMQVhost")
 			os.Exit(1)
 		}
+		if err := webhookrabbitmqv1beta1.SetupRabbitMQQueueWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RabbitMQQueue")
+			os.Exit(1)
+		}
+		if err := webhookrabbitmqv1beta1.SetupRabbitMQExchangeWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RabbitMQExchange")
+			os.Exit(1)
+		}
+		if err := webhookrabbitmqv1beta1.SetupRabbitMQBindingWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RabbitMQBinding")
+			os.Exit(1)
+		}
+		if err := webhookrabbitmqv1beta1.SetupRabbitMQShovelWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RabbitMQShovel")
+			os.Exit(1)
+		}
+		if err := webhookrabbitmqv1beta1.SetupRabbitMQFederationUpstreamWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RabbitMQFederationUpstream")
+			os.Exit(1)
+		}
+		if err := webhookrabbitmqv1beta1.SetupRabbitMQBackupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RabbitMQBackup")
+			os.Exit(1)
+		}
+		if err := webhookrabbitmqv1beta1.SetupRabbitMQRestoreWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RabbitMQRestore")
+			os.Exit(1)
+		}
 		if err := webhooknetworkv1beta1.SetupNetConfigWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "NetConfig")
 			os.Exit(1)
@@ -427,6 +525,10 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Reservation")
 			os.Exit(1)
 		}
+		if err := webhooknetworkv1beta1.SetupBGPConfigurationWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "BGPConfiguration")
+			os.Exit(1)
+		}
 		checker = mgr.GetWebhookServer().StartedChecker()
 	}
 	// +kubebuilder:scaffold:builder
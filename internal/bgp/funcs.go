@@ -6,6 +6,7 @@ import (
 
 	k8s_networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	frrk8sv1 "github.com/metallb/frr-k8s/api/v1beta1"
+	networkv1 "github.com/openstack-k8s-operators/infra-operator/apis/network/v1beta1"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 )
 
@@ -42,12 +43,28 @@ func GetFRRPodPrefixes(networkStatus []k8s_networkv1.NetworkStatus) []string {
 }
 
 // GetFRRNeighbors - returs a list of  FRR Neighor for podPrefixes, using a copy of the
-// nodeNeigbors and replacing its Prefixes with the podPrefixes.
-func GetFRRNeighbors(nodeNeighbors []frrk8sv1.Neighbor, podPrefixes []string) []frrk8sv1.Neighbor {
+// nodeNeigbors and replacing its Prefixes with the podPrefixes. If overrides are passed,
+// the BFD profile and BGP timers set in there replace the ones of the nodeNeighbors.
+func GetFRRNeighbors(
+	nodeNeighbors []frrk8sv1.Neighbor,
+	podPrefixes []string,
+	overrides *networkv1.BGPNeighborOverrideType,
+) []frrk8sv1.Neighbor {
 	podNeighbors := []frrk8sv1.Neighbor{}
 
 	for _, neighbor := range nodeNeighbors {
 		neighbor.ToAdvertise.Allowed.Prefixes = podPrefixes
+		if overrides != nil {
+			if overrides.BFDProfile != "" {
+				neighbor.BFDProfile = overrides.BFDProfile
+			}
+			if overrides.HoldTime != nil {
+				neighbor.HoldTime = overrides.HoldTime.DeepCopy()
+			}
+			if overrides.KeepaliveTime != nil {
+				neighbor.KeepaliveTime = overrides.KeepaliveTime.DeepCopy()
+			}
+		}
 		podNeighbors = append(podNeighbors, neighbor)
 	}
 
@@ -2,11 +2,14 @@ package bgp
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega" //revive:disable:dot-imports
 
 	k8s_networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	frrk8sv1 "github.com/metallb/frr-k8s/api/v1beta1"
+	networkv1 "github.com/openstack-k8s-operators/infra-operator/apis/network/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestGetNodesRunningPods(t *testing.T) {
@@ -250,6 +253,7 @@ func TestGetFRRNeighbors(t *testing.T) {
 		name          string
 		nodeNeighbors []frrk8sv1.Neighbor
 		podPrefixes   []string
+		overrides     *networkv1.BGPNeighborOverrideType
 		want          []frrk8sv1.Neighbor
 	}{
 		{
@@ -352,13 +356,87 @@ func TestGetFRRNeighbors(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "nodeNeighbor with BFD profile and timer overrides",
+			nodeNeighbors: []frrk8sv1.Neighbor{
+				{
+					Address:       "10.10.10.10",
+					ASN:           64999,
+					HoldTime:      &metav1.Duration{Duration: 180 * time.Second},
+					KeepaliveTime: &metav1.Duration{Duration: 60 * time.Second},
+					ToAdvertise: frrk8sv1.Advertise{
+						Allowed: frrk8sv1.AllowedOutPrefixes{
+							Mode: frrk8sv1.AllowRestricted,
+							Prefixes: []string{
+								"10.10.10.11/32",
+							},
+						},
+					},
+				},
+			},
+			podPrefixes: []string{"172.17.0.40/32"},
+			overrides: &networkv1.BGPNeighborOverrideType{
+				BFDProfile:    "bfd-fast",
+				HoldTime:      &metav1.Duration{Duration: 9 * time.Second},
+				KeepaliveTime: &metav1.Duration{Duration: 3 * time.Second},
+			},
+			want: []frrk8sv1.Neighbor{
+				{
+					Address:       "10.10.10.10",
+					ASN:           64999,
+					BFDProfile:    "bfd-fast",
+					HoldTime:      &metav1.Duration{Duration: 9 * time.Second},
+					KeepaliveTime: &metav1.Duration{Duration: 3 * time.Second},
+					ToAdvertise: frrk8sv1.Advertise{
+						Allowed: frrk8sv1.AllowedOutPrefixes{
+							Mode: frrk8sv1.AllowRestricted,
+							Prefixes: []string{
+								"172.17.0.40/32",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "nodeNeighbor with BFD profile override only",
+			nodeNeighbors: []frrk8sv1.Neighbor{
+				{
+					Address:       "10.10.10.10",
+					ASN:           64999,
+					BFDProfile:    "bfd-default",
+					HoldTime:      &metav1.Duration{Duration: 180 * time.Second},
+					KeepaliveTime: &metav1.Duration{Duration: 60 * time.Second},
+				},
+			},
+			podPrefixes: []string{"172.17.0.40/32"},
+			overrides: &networkv1.BGPNeighborOverrideType{
+				BFDProfile: "bfd-fast",
+			},
+			want: []frrk8sv1.Neighbor{
+				{
+					Address:       "10.10.10.10",
+					ASN:           64999,
+					BFDProfile:    "bfd-fast",
+					HoldTime:      &metav1.Duration{Duration: 180 * time.Second},
+					KeepaliveTime: &metav1.Duration{Duration: 60 * time.Second},
+					ToAdvertise: frrk8sv1.Advertise{
+						Allowed: frrk8sv1.AllowedOutPrefixes{
+							Prefixes: []string{
+								"172.17.0.40/32",
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
 
-			nodes := GetFRRNeighbors(tt.nodeNeighbors, tt.podPrefixes)
+			nodes := GetFRRNeighbors(tt.nodeNeighbors, tt.podPrefixes, tt.overrides)
 			g.Expect(nodes).To(HaveLen(len(tt.want)))
 			g.Expect(nodes).To(BeEquivalentTo(tt.want))
 		})
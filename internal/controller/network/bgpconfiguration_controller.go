@@ -671,11 +671,27 @@ func (r *BGPConfigurationReconciler) createOrPatchFRRConfiguration(
 	for _, r := range nodeFRRCfg.Spec.BGP.Routers {
 		routers = append(routers, frrk8sv1.Router{
 			ASN:       r.ASN,
-			Neighbors: bgp.GetFRRNeighbors(r.Neighbors, podPrefixes),
+			Neighbors: bgp.GetFRRNeighbors(r.Neighbors, podPrefixes, instance.Spec.NeighborOverrides),
 			Prefixes:  podPrefixes,
 		})
 	}
 	frrConfigSpec.BGP.Routers = routers
+
+	// add the BFD profile referenced by the neighbors from the node FRRConfiguration,
+	// so the generated FRRConfiguration does not depend on the node one.
+	if instance.Spec.NeighborOverrides != nil && instance.Spec.NeighborOverrides.BFDProfile != "" {
+		bfdProfileName := instance.Spec.NeighborOverrides.BFDProfile
+		idx := slices.IndexFunc(nodeFRRCfg.Spec.BGP.BFDProfiles, func(p frrk8sv1.BFDProfile) bool {
+			return p.Name == bfdProfileName
+		})
+		if idx < 0 {
+			return fmt.Errorf("BFD profile %s not found in FRRConfiguration %s/%s",
+				bfdProfileName, nodeFRRCfg.Namespace, nodeFRRCfg.Name)
+		}
+		frrConfigSpec.BGP.BFDProfiles = []frrk8sv1.BFDProfile{
+			*nodeFRRCfg.Spec.BGP.BFDProfiles[idx].DeepCopy(),
+		}
+	}
 	frrConfigSpec.NodeSelector = nodeFRRCfg.Spec.NodeSelector
 
 	// create or update the FRRConfiguration
@@ -19,11 +19,35 @@ package rabbitmq
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"slices"
+	"strings"
 
+	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	"github.com/openstack-k8s-operators/infra-operator/internal/rabbitmq"
+	rabbitmqapi "github.com/openstack-k8s-operators/infra-operator/pkg/rabbitmq/api"
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	oko_secret "github.com/openstack-k8s-operators/lib-common/modules/common/secret"
 	rabbitmqclusterv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// TLS files inside the RabbitMQ pods, used by shovels and federation links when TLS is enabled
+const (
+	// amqpTLSCACertFile - CA certificate of the cluster, verifies peers with the same CA
+	amqpTLSCACertFile = "/etc/rabbitmq-tls/ca.crt"
+	// amqpTLSCertFile - certificate of the cluster, presented to peers verifying client certificates
+	amqpTLSCertFile = "/etc/rabbitmq-tls/tls.crt"
+	// amqpTLSKeyFile - key of the certificate of the cluster
+	amqpTLSKeyFile = "/etc/rabbitmq-tls/tls.key"
 )
 
 // getManagementURL constructs the RabbitMQ management API URL from cluster spec and secret data
@@ -44,25 +68,120 @@ func getManagementURL(rabbit *rabbitmqclusterv2.RabbitmqCluster, rabbitSecret *c
 	return fmt.Sprintf("%s://%s:%s", protocol, string(rabbitSecret.Data["host"]), managementPort)
 }
 
-// getTLSCACert retrieves the CA certificate for RabbitMQ TLS if configured
-func getTLSCACert(ctx context.Context, h *helper.Helper, rabbit *rabbitmqclusterv2.RabbitmqCluster, namespace string) ([]byte, error) {
-	if rabbit.Spec.TLS.CaSecretName == "" {
-		return nil, nil
+// getTLSCACert retrieves the CA certificate for RabbitMQ TLS. The CA secret of the cluster
+// is used, or the ca.crt of the TLS secret if the cluster has no separate CA secret.
+func getTLSCACert(ctx context.Context, h *helper.Helper, rabbit *rabbitmqclusterv2.RabbitmqCluster) ([]byte, error) {
+	caSecretName := rabbit.Spec.TLS.CaSecretName
+	if caSecretName == "" {
+		caSecretName = rabbit.Spec.TLS.SecretName
 	}
 
-	caSecret, _, err := oko_secret.GetSecret(ctx, h, rabbit.Spec.TLS.CaSecretName, namespace)
+	caSecret, _, err := oko_secret.GetSecret(ctx, h, caSecretName, rabbit.Namespace)
 	if err != nil {
 		return nil, err
 	}
 
 	caCert, ok := caSecret.Data["ca.crt"]
-	if !ok {
+	if !ok && rabbit.Spec.TLS.CaSecretName != "" {
 		return nil, fmt.Errorf("ca.crt not found in CA secret %s", rabbit.Spec.TLS.CaSecretName)
 	}
 
 	return caCert, nil
 }
 
+// getAPIClient returns a client for the management API of the RabbitMQ cluster with the
+// credentials of the default user. With TLS the certificate of the management API is
+// verified against the CA of the cluster and the service hostname, unless the RabbitMq
+// CR explicitly opts out of the verification.
+func getAPIClient(ctx context.Context, h *helper.Helper, rabbit *rabbitmqclusterv2.RabbitmqCluster, rabbitSecret *corev1.Secret) (*rabbitmqapi.Client, error) {
+	tlsConfig := rabbitmqapi.TLSConfig{
+		Enabled: rabbit.Spec.TLS.SecretName != "",
+	}
+
+	if tlsConfig.Enabled {
+		// The RabbitMq CR has the same name as the RabbitmqCluster
+		rabbitmqCR := &rabbitmqv1.RabbitMq{}
+		err := h.GetClient().Get(ctx, types.NamespacedName{Name: rabbit.Name, Namespace: rabbit.Namespace}, rabbitmqCR)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return nil, err
+		}
+		managementTLS := rabbitmqCR.Spec.ManagementTLS
+
+		tlsConfig.ServerName = string(rabbitSecret.Data["host"])
+		tlsConfig.UseSystemCAs = managementTLS.ShouldUseSystemTrustStore()
+		tlsConfig.InsecureSkipVerify = managementTLS.ShouldSkipVerify()
+		if tlsConfig.InsecureSkipVerify {
+			log.FromContext(ctx).Info("TLS certificate verification of the RabbitMQ management API is disabled by the RabbitMq CR", "cluster", rabbit.Name)
+		} else {
+			caCert, err := getTLSCACert(ctx, h, rabbit)
+			if err != nil {
+				return nil, err
+			}
+			if len(caCert) == 0 && !tlsConfig.UseSystemCAs {
+				return nil, fmt.Errorf("%w of cluster %s, set the CA secret of the cluster or enable the system trust store",
+					rabbitmqapi.ErrNoCACert, rabbit.Name)
+			}
+			tlsConfig.CACert = caCert
+		}
+	}
+
+	return rabbitmqapi.GetClient(
+		getManagementURL(rabbit, rabbitSecret),
+		string(rabbitSecret.Data["username"]),
+		string(rabbitSecret.Data["password"]),
+		tlsConfig)
+}
+
+// getDefaultUserAPIClient returns the management API client with the default user of the
+// cluster, reading the credentials from the default user secret in the cluster status
+func getDefaultUserAPIClient(ctx context.Context, h *helper.Helper, rabbit *rabbitmqclusterv2.RabbitmqCluster) (*rabbitmqapi.Client, error) {
+	if rabbit.Status.DefaultUser == nil || rabbit.Status.DefaultUser.SecretReference == nil {
+		return nil, fmt.Errorf("default user of RabbitMQ cluster %s not available", rabbit.Name)
+	}
+	rabbitSecret, _, err := oko_secret.GetSecret(ctx, h, rabbit.Status.DefaultUser.SecretReference.Name, rabbit.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	return getAPIClient(ctx, h, rabbit, rabbitSecret)
+}
+
+// getNodeAPIClient returns the management API client of a single node of the cluster, reached
+// at host. Used for the health checks which only check the node serving the request.
+func getNodeAPIClient(ctx context.Context, h *helper.Helper, rabbit *rabbitmqclusterv2.RabbitmqCluster, host string) (*rabbitmqapi.Client, error) {
+	if rabbit.Status.DefaultUser == nil || rabbit.Status.DefaultUser.SecretReference == nil {
+		return nil, fmt.Errorf("default user of RabbitMQ cluster %s not available", rabbit.Name)
+	}
+	rabbitSecret, _, err := oko_secret.GetSecret(ctx, h, rabbit.Status.DefaultUser.SecretReference.Name, rabbit.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	nodeSecret := rabbitSecret.DeepCopy()
+	nodeSecret.Data["host"] = []byte(host)
+	return getAPIClient(ctx, h, rabbit, nodeSecret)
+}
+
+// informationalConditions report the state of the broker without affecting the Ready condition,
+// e.g. a resource alarm or an upgrade in progress doesn't make the deployment or its transport
+// URLs not ready. A paused upgrade does, see the RabbitMq reconciler
+var informationalConditions = []condition.Type{
+	rabbitmqv1.BrokerHealthyCondition,
+	rabbitmqv1.UpgradeReadyCondition,
+}
+
+// allSubConditionsTrue returns true if all conditions besides the Ready condition and the
+// informational conditions are True
+func allSubConditionsTrue(conditions condition.Conditions) bool {
+	for _, c := range conditions {
+		if c.Type == condition.ReadyCondition || slices.Contains(informationalConditions, c.Type) {
+			continue
+		}
+		if c.Status != corev1.ConditionTrue {
+			return false
+		}
+	}
+	return true
+}
+
 // ClusterReadinessError represents different types of cluster readiness failures
 type ClusterReadinessError struct {
 	ClusterName string
@@ -96,3 +215,245 @@ func checkClusterReadiness(rabbit *rabbitmqclusterv2.RabbitmqCluster) *ClusterRe
 
 	return nil
 }
+
+// getRabbitMQHosts returns the hosts clients connect to. The per-pod service
+// hostnames of the RabbitMq CR are used if available, otherwise the host from
+// the cluster default user secret. rabbitmqCR can be nil if it doesn't exist.
+func getRabbitMQHosts(rabbitmqCR *rabbitmqv1.RabbitMq, rabbitSecret *corev1.Secret) ([]string, error) {
+	if rabbitmqCR != nil && len(rabbitmqCR.Status.ServiceHostnames) > 0 {
+		return rabbitmqCR.Status.ServiceHostnames, nil
+	}
+
+	host, ok := rabbitSecret.Data["host"]
+	if !ok {
+		return nil, fmt.Errorf("host does not exist in rabbitmq secret %s", rabbitSecret.Name)
+	}
+
+	return []string{string(host)}, nil
+}
+
+// buildAMQPURIs returns one AMQP URI per host, as used by shovels, federation upstreams
+// and the structured transport URL secret. query is only added with TLS enabled.
+func buildAMQPURIs(username, password string, hosts []string, port, vhost string, tlsEnabled bool, tlsQuery url.Values) []string {
+	scheme := "amqp"
+	query := ""
+	if tlsEnabled {
+		scheme = "amqps"
+		query = tlsQuery.Encode()
+	}
+
+	uris := []string{}
+	for _, host := range hosts {
+		u := url.URL{
+			Scheme:   scheme,
+			User:     url.UserPassword(username, password),
+			Host:     fmt.Sprintf("%s:%s", host, port),
+			RawPath:  "/" + url.PathEscape(vhost),
+			Path:     "/" + vhost,
+			RawQuery: query,
+		}
+		uris = append(uris, u.String())
+	}
+
+	return uris
+}
+
+// buildStreamURIs returns one stream protocol URI per host, as used by the stream clients
+// of the structured transport URL secret
+func buildStreamURIs(username, password string, hosts []string, vhost string, tlsEnabled bool) []string {
+	scheme := "rabbitmq-stream"
+	port := rabbitmqv1.StreamPort
+	if tlsEnabled {
+		scheme = "rabbitmq-stream+tls"
+		port = rabbitmqv1.StreamTLSPort
+	}
+
+	uris := []string{}
+	for _, host := range hosts {
+		u := url.URL{
+			Scheme:  scheme,
+			User:    url.UserPassword(username, password),
+			Host:    fmt.Sprintf("%s:%d", host, port),
+			RawPath: "/" + url.PathEscape(vhost),
+			Path:    "/" + vhost,
+		}
+		uris = append(uris, u.String())
+	}
+
+	return uris
+}
+
+// transportURLVhostName returns the RabbitMQ name of the vhost of a TransportURL target,
+// the root vhost being either empty or "/"
+func transportURLVhostName(vhost string) string {
+	name := strings.TrimPrefix(vhost, "/")
+	if name == "" {
+		return "/"
+	}
+	return name
+}
+
+// hasStreamsVhost returns true if the RabbitMq CR enables streams for the vhost of a
+// TransportURL target
+func hasStreamsVhost(rabbitmqCR *rabbitmqv1.RabbitMq, vhost string) bool {
+	return rabbitmqCR != nil && rabbitmqCR.Spec.Streams.HasVhost(transportURLVhostName(vhost))
+}
+
+// getAMQPURIs returns the AMQP URIs used by the RabbitMQ pods of the cluster the link runs in to
+// connect to the vhost of the peer cluster with the given credentials. Hosts are the same used for
+// the transport URLs. With TLS the peer gets verified against its own CA, which is mounted into the
+// pods if it differs from the CA of the cluster the link runs in. If the peer verifies client
+// certificates, the TLS certificate of the pods gets presented as client certificate.
+func getAMQPURIs(
+	ctx context.Context,
+	h *helper.Helper,
+	rabbit *rabbitmqclusterv2.RabbitmqCluster,
+	peer *rabbitmqclusterv2.RabbitmqCluster,
+	vhost string,
+	username string,
+	password string,
+) ([]string, error) {
+	peerSecret, _, err := oko_secret.GetSecret(ctx, h, peer.Status.DefaultUser.SecretReference.Name, peer.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	port, ok := peerSecret.Data["port"]
+	if !ok {
+		return nil, fmt.Errorf("port does not exist in rabbitmq secret %s", peerSecret.Name)
+	}
+
+	// The RabbitMq CR has the same name as the RabbitmqCluster
+	peerCR := &rabbitmqv1.RabbitMq{}
+	err = h.GetClient().Get(ctx, types.NamespacedName{Name: peer.Name, Namespace: peer.Namespace}, peerCR)
+	if err != nil {
+		if !k8s_errors.IsNotFound(err) {
+			return nil, err
+		}
+		peerCR = nil
+	}
+
+	hosts, err := getRabbitMQHosts(peerCR, peerSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsEnabled := peer.Spec.TLS.SecretName != ""
+	tlsQuery := url.Values{
+		"cacertfile": []string{amqpTLSCACertFile},
+		"verify":     []string{"verify_peer"},
+	}
+	if tlsEnabled {
+		peerCASecret := getCASecretName(peer.Spec.TLS)
+		if rabbit.Spec.TLS.SecretName == "" || peerCASecret != getCASecretName(rabbit.Spec.TLS) {
+			tlsQuery.Set("cacertfile", rabbitmq.PeerCACertFile(peerCASecret))
+		}
+		if peerCR != nil && peerCR.Spec.MTLS.IsEnabled() {
+			if rabbit.Spec.TLS.SecretName == "" {
+				if peerCR.Spec.MTLS.SslVerifyMode == rabbitmqv1.MTLSVerifyModeRequire {
+					return nil, fmt.Errorf("cluster %s requires a client certificate, but cluster %s has no TLS certificate",
+						peer.Name, rabbit.Name)
+				}
+			} else {
+				tlsQuery.Set("certfile", amqpTLSCertFile)
+				tlsQuery.Set("keyfile", amqpTLSKeyFile)
+			}
+		}
+	}
+	return buildAMQPURIs(username, password, hosts, string(port), vhost, tlsEnabled, tlsQuery), nil
+}
+
+// reconcileLinkUser creates or updates the RabbitMQUser a shovel or federation link connects to a
+// peer with, so that links don't use the default user of the peer. The user is owned by the link
+// and gets deleted with it. The credentials are returned once the user is ready, ready is false
+// until then.
+func reconcileLinkUser(
+	ctx context.Context,
+	h *helper.Helper,
+	owner client.Object,
+	name string,
+	clusterName string,
+	vhostRef string,
+	username string,
+	permissions rabbitmqv1.RabbitMQUserPermissions,
+) (linkUsername string, linkPassword string, ready bool, err error) {
+	user := &rabbitmqv1.RabbitMQUser{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: owner.GetNamespace(),
+		},
+	}
+	_, err = controllerutil.CreateOrUpdate(ctx, h.GetClient(), user, func() error {
+		if err := controllerutil.SetControllerReference(owner, user, h.GetScheme()); err != nil {
+			return err
+		}
+		user.Spec.RabbitmqClusterName = clusterName
+		user.Spec.VhostRef = vhostRef
+		user.Spec.Username = username
+		user.Spec.Permissions = permissions
+		return nil
+	})
+	if err != nil {
+		return "", "", false, err
+	}
+
+	if !user.IsReady() || user.Status.SecretName == "" {
+		return "", "", false, nil
+	}
+	userSecret, _, err := oko_secret.GetSecret(ctx, h, user.Status.SecretName, user.Namespace)
+	if err != nil {
+		return "", "", false, err
+	}
+	return string(userSecret.Data["username"]), string(userSecret.Data["password"]), true, nil
+}
+
+// getVhostName returns the RabbitMQ vhost name of the referenced RabbitMQVhost,
+// or the default vhost "/" if vhostRef is empty
+func getVhostName(ctx context.Context, c client.Client, namespace, vhostRef string) (string, error) {
+	if vhostRef == "" {
+		return "/", nil
+	}
+
+	vhost := &rabbitmqv1.RabbitMQVhost{}
+	if err := c.Get(ctx, types.NamespacedName{Name: vhostRef, Namespace: namespace}, vhost); err != nil {
+		return "", err
+	}
+
+	return vhost.Spec.Name, nil
+}
+
+// checkDrift handles the differences between the state in RabbitMQ and the spec. specApplied
+// is true if the spec didn't change since it was last applied to RabbitMQ. It returns true if
+// the spec should get applied (again), which is the case if it changed, nothing drifted or the
+// drift gets remediated. Drift which is not remediated is reported in the DriftDetected condition.
+func checkDrift(
+	ctx context.Context,
+	driftDetection *rabbitmqv1.DriftDetection,
+	conditions *condition.Conditions,
+	specApplied bool,
+	drift []string,
+) bool {
+	conditions.Remove(rabbitmqv1.DriftDetectedCondition)
+	if !specApplied || len(drift) == 0 {
+		return true
+	}
+
+	if driftDetection.ShouldRemediate() {
+		log.FromContext(ctx).Info("State in RabbitMQ differs from the spec, restoring it", "drift", drift)
+		return true
+	}
+
+	conditions.Set(condition.TrueCondition(
+		rabbitmqv1.DriftDetectedCondition,
+		rabbitmqv1.DriftDetectedMessage,
+		strings.Join(drift, ", ")))
+	return false
+}
+
+// driftCheckResult returns the result which requeues the reconcile for the next drift check
+func driftCheckResult(driftDetection *rabbitmqv1.DriftDetection) ctrl.Result {
+	if interval := driftDetection.GetInterval(); interval > 0 {
+		return ctrl.Result{RequeueAfter: interval}
+	}
+	return ctrl.Result{}
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -90,11 +91,19 @@ type Reconciler struct {
 	Kclient kubernetes.Interface
 	config  *rest.Config
 	Scheme  *runtime.Scheme
+	// ExecInPod runs a command in a container of a pod, execInPod is used if not set
+	ExecInPod func(ctx context.Context, namespace, pod, container string, command []string) error
+	// NodeHost returns the host of the management API of the node running in a pod, the
+	// hostname of the pod in the headless service of the cluster is used if not set
+	NodeHost func(rabbit *rabbitmqv2.RabbitmqCluster, podName string) string
 }
 
 // +kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqs/finalizers,verbs=update
+// +kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqshovels,verbs=get;list;watch
+// +kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqfederationupstreams,verbs=get;list;watch
+// +kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=transporturls,verbs=get;list;watch
 
 // +kubebuilder:rbac:groups=rabbitmq.com,resources=rabbitmqclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rabbitmq.com,resources=rabbitmqclusters/finalizers,verbs=update
@@ -382,7 +391,21 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 		instance.Status.LastAppliedTopology = nil
 	}
 
-	err = rabbitmq.ConfigureCluster(rabbitmqCluster, IPv6Enabled, fipsEnabled, topology, instance.Spec.NodeSelector, instance.Spec.Override)
+	// Shovels and federation links running in the cluster need their plugins and the CA of their peers
+	links, err := r.getClusterLinks(ctx, instance)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.ServiceConfigReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.ServiceConfigReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	plugins := append(slices.Clone(instance.Spec.Plugins), links.plugins...)
+
+	err = rabbitmq.ConfigureCluster(rabbitmqCluster, IPv6Enabled, fipsEnabled, topology, instance.Spec.NodeSelector, instance.Spec.Override, instance.Spec.MTLS,
+		plugins, instance.Spec.PrometheusDetailedMetrics, instance.Spec.Tuning, instance.Spec.Streams, links.peerCASecrets)
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			condition.ServiceConfigReadyCondition,
@@ -393,6 +416,28 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 		return ctrl.Result{}, fmt.Errorf("error configuring RabbitmqCluster: %w", err)
 	}
 
+	// Keep the departing nodes until the quorum queue members on them are removed
+	if err := r.holdReplicasForScaleDown(ctx, instance, rabbitmqCluster); err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			rabbitmqv1beta1.ScaleDownReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			rabbitmqv1beta1.ScaleDownErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Roll out a new container image one node at a time
+	if err := r.holdPodsForUpgrade(ctx, instance, rabbitmqCluster); err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			rabbitmqv1beta1.UpgradeReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			rabbitmqv1beta1.UpgradeErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
 	rabbitmqImplCluster := impl.NewRabbitMqCluster(rabbitmqCluster, 5)
 	rmqres, rmqerr := rabbitmqImplCluster.CreateOrPatch(ctx, helper)
 	if rmqerr != nil {
@@ -412,6 +457,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	orphansResult := ctrl.Result{}
+	featureFlagsResult := ctrl.Result{}
+	healthResult := ctrl.Result{}
+	streamsResult := ctrl.Result{}
+	queueMigrationResult := ctrl.Result{}
 	clusterReady := false
 	if rabbitmqClusterInstance.Status.ObservedGeneration == rabbitmqClusterInstance.Generation {
 		for _, oldCond := range rabbitmqClusterInstance.Status.Conditions {
@@ -504,6 +554,51 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 					return ctrl.Result{}, err
 				}
 				instance.Status.QueueType = rabbitmqv1beta1.QueueTypeMirrored
+			} else if instance.IsQueueMigrationPending() {
+				// The classic queues get replaced before the TransportURLs switch the clients to quorum queues
+				migrated, err := r.reconcileQueueMigration(ctx, instance, helper, &rabbitmqClusterInstance)
+				if err != nil {
+					Log.Error(err, "Could not migrate the classic queues to quorum queues")
+					instance.Status.Conditions.Set(condition.FalseCondition(
+						condition.DeploymentReadyCondition,
+						condition.ErrorReason,
+						condition.SeverityWarning,
+						condition.DeploymentReadyErrorMessage, err.Error()))
+					return ctrl.Result{}, err
+				}
+				queueMigrationResult = ctrl.Result{RequeueAfter: queueMigrationInterval}
+				if migrated {
+					Log.Info("Queues migrated to quorum queues. Removing ha-all policy")
+					err := deleteMirroredPolicy(ctx, helper, instance)
+					if err != nil {
+						Log.Error(err, "Could not remove ha-all policy")
+						instance.Status.Conditions.Set(condition.FalseCondition(
+							condition.DeploymentReadyCondition,
+							condition.ErrorReason,
+							condition.SeverityWarning,
+							condition.DeploymentReadyErrorMessage, err.Error()))
+						return ctrl.Result{}, err
+					}
+					// Switches the TransportURLs to quorum queues, clients which still use the
+					// classic queues until they get restarted can declare them again
+					instance.Status.QueueType = rabbitmqv1beta1.QueueTypeQuorum
+					instance.Status.QueueMigration.Phase = rabbitmqv1beta1.QueueMigrationPhaseRescanning
+					instance.Status.QueueMigration.SwitchTime = &metav1.Time{Time: time.Now()}
+				}
+			} else if *instance.Spec.QueueType == rabbitmqv1beta1.QueueTypeQuorum && instance.Status.QueueMigration.IsRescanning() {
+				completed, err := r.reconcileQueueMigrationRescan(ctx, instance, helper, &rabbitmqClusterInstance)
+				if err != nil {
+					Log.Error(err, "Could not migrate the classic queues to quorum queues")
+					instance.Status.Conditions.Set(condition.FalseCondition(
+						condition.DeploymentReadyCondition,
+						condition.ErrorReason,
+						condition.SeverityWarning,
+						condition.DeploymentReadyErrorMessage, err.Error()))
+					return ctrl.Result{}, err
+				}
+				if !completed {
+					queueMigrationResult = ctrl.Result{RequeueAfter: queueMigrationInterval}
+				}
 			} else if *instance.Spec.QueueType != rabbitmqv1beta1.QueueTypeMirrored && instance.Status.QueueType == rabbitmqv1beta1.QueueTypeMirrored {
 				Log.Info("QueueType changed from Mirrored. Removing ha-all policy")
 				err := deleteMirroredPolicy(ctx, helper, instance)
@@ -520,7 +615,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 			}
 
 			// Update status for Quorum queue type
-			if *instance.Spec.QueueType == rabbitmqv1beta1.QueueTypeQuorum && instance.Status.QueueType != rabbitmqv1beta1.QueueTypeQuorum {
+			if *instance.Spec.QueueType == rabbitmqv1beta1.QueueTypeQuorum && instance.Status.QueueType != rabbitmqv1beta1.QueueTypeQuorum && !instance.IsQueueMigrationPending() {
 				Log.Info("Setting queue type status to quorum")
 				instance.Status.QueueType = rabbitmqv1beta1.QueueTypeQuorum
 			} else if *instance.Spec.QueueType != rabbitmqv1beta1.QueueTypeQuorum && instance.Status.QueueType == rabbitmqv1beta1.QueueTypeQuorum {
@@ -534,13 +629,68 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ct
 				instance.Status.QueueType = ""
 			}
 		}
+
+		// Retention policy of the streams in the stream vhosts
+		streamsResult, err = r.reconcileStreams(ctx, instance, helper)
+		if err != nil {
+			Log.Error(err, "Could not apply the retention policy of the streams")
+			return ctrl.Result{}, err
+		}
+
+		// Look for users, vhosts and policies in RabbitMQ left over by force-deleted CRs
+		orphansResult = r.reconcileOrphans(ctx, instance, helper, &rabbitmqClusterInstance)
+
+		// Enable the stable feature flags once every node runs the same RabbitMQ version
+		featureFlagsResult = r.reconcileFeatureFlags(ctx, instance, helper, &rabbitmqClusterInstance)
+
+		// Report alarms, network partitions and quorum critical queues
+		healthResult = r.reconcileHealth(ctx, instance, helper, &rabbitmqClusterInstance)
 	}
 
-	if instance.Status.Conditions.AllSubConditionIsTrue() {
+	scaleDownResult, err := r.reconcileScaleDown(ctx, instance, helper, &rabbitmqClusterInstance, clusterReady)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	upgradeResult, err := r.reconcileUpgrade(ctx, instance, helper, &rabbitmqClusterInstance, clusterReady)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The health checks and the upgrade are informational, see informationalConditions. A paused
+	// upgrade leaves the nodes on mixed versions until an admin acts, it makes the RabbitMq not ready
+	if upgrade := instance.Status.Upgrade; upgrade != nil && upgrade.Phase == rabbitmqv1beta1.UpgradePhasePaused {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.ReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			rabbitmqv1beta1.UpgradePausedMessage,
+			upgrade.ToImage,
+			upgrade.Message))
+	} else if allSubConditionsTrue(instance.Status.Conditions) {
 		instance.Status.Conditions.MarkTrue(
 			condition.ReadyCondition, condition.ReadyMessage)
 	}
-	return ctrl.Result{}, nil
+	if !scaleDownResult.IsZero() {
+		return scaleDownResult, nil
+	}
+	if !upgradeResult.IsZero() {
+		return upgradeResult, nil
+	}
+	if queueMigrationResult.RequeueAfter > 0 {
+		return queueMigrationResult, nil
+	}
+	if streamsResult.RequeueAfter > 0 {
+		return streamsResult, nil
+	}
+	if featureFlagsResult.RequeueAfter > 0 {
+		return featureFlagsResult, nil
+	}
+	// Run the sweep and the health checks at their own intervals
+	if healthResult.RequeueAfter > 0 && (orphansResult.RequeueAfter == 0 || healthResult.RequeueAfter < orphansResult.RequeueAfter) {
+		return healthResult, nil
+	}
+	return orphansResult, nil
 }
 
 func (r *Reconciler) reconcilePerPodServices(ctx context.Context, instance *rabbitmqv1beta1.RabbitMq, helper *helper.Helper, labelMap map[string]string) (ctrl.Result, error) {
@@ -554,12 +704,25 @@ func (r *Reconciler) reconcilePerPodServices(ctx context.Context, instance *rabb
 
 	replicas := int(*instance.Spec.Replicas)
 
-	if len(instance.Spec.PodOverride.Services) != replicas {
-		return ctrl.Result{}, fmt.Errorf("number of services in podOverride (%d) must match number of replicas (%d)", len(instance.Spec.PodOverride.Services), replicas)
+	// Services of the pods departing on a scale down are left to reconcileScaleDown, which
+	// deletes them once the pods are removed
+	if len(instance.Spec.PodOverride.Services) < replicas {
+		return ctrl.Result{}, fmt.Errorf("number of services in podOverride (%d) must be at least the number of replicas (%d)", len(instance.Spec.PodOverride.Services), replicas)
 	}
 
 	Log.Info("Creating per-pod services using podOverride configuration")
 
+	ports := []corev1.ServicePort{
+		{Name: "amqp", Port: 5672, TargetPort: intstr.FromInt(5672)},
+		{Name: "amqps", Port: 5671, TargetPort: intstr.FromInt(5671)},
+	}
+	if instance.Spec.Streams.IsEnabled() {
+		ports = append(ports,
+			corev1.ServicePort{Name: "stream", Port: rabbitmqv1beta1.StreamPort, TargetPort: intstr.FromInt(rabbitmqv1beta1.StreamPort)},
+			corev1.ServicePort{Name: "streams", Port: rabbitmqv1beta1.StreamTLSPort, TargetPort: intstr.FromInt(rabbitmqv1beta1.StreamTLSPort)},
+		)
+	}
+
 	var serviceHostnames []string
 	var requeueNeeded bool
 	for i := 0; i < replicas; i++ {
@@ -574,10 +737,7 @@ func (r *Reconciler) reconcilePerPodServices(ctx context.Context, instance *rabb
 				Selector: map[string]string{
 					appsv1.StatefulSetPodNameLabel: podName,
 				},
-				Ports: []corev1.ServicePort{
-					{Name: "amqp", Port: 5672, TargetPort: intstr.FromInt(5672)},
-					{Name: "amqps", Port: 5671, TargetPort: intstr.FromInt(5671)},
-				},
+				Ports: ports,
 			}),
 			5,
 			&instance.Spec.PodOverride.Services[i],
@@ -840,6 +1000,12 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&topologyv1.Topology{},
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSrc),
 			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&rabbitmqv1beta1.RabbitMQShovel{},
+			handler.EnqueueRequestsFromMapFunc(r.linkToRabbitMqMapFunc),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&rabbitmqv1beta1.RabbitMQFederationUpstream{},
+			handler.EnqueueRequestsFromMapFunc(r.linkToRabbitMqMapFunc),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Complete(r)
 }
 
@@ -0,0 +1,151 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	rabbitmqv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	rabbitmqv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	rabbitmqapi "github.com/openstack-k8s-operators/infra-operator/pkg/rabbitmq/api"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+)
+
+// featureFlagsInterval - how often the nodes are checked while stable feature flags are pending
+const featureFlagsInterval = 30 * time.Second
+
+// reconcileFeatureFlags records the plugins enabled on the nodes of the cluster and enables
+// all stable feature flags once every node runs the same RabbitMQ version, e.g. after an
+// upgrade got rolled out to all pods. Enabling a feature flag cannot be undone, so flags are
+// never enabled while a node still runs another version. Like the orphan sweep it is best
+// effort, errors are logged and the feature flags are checked again later.
+func (r *Reconciler) reconcileFeatureFlags(
+	ctx context.Context,
+	instance *rabbitmqv1beta1.RabbitMq,
+	helper *helper.Helper,
+	rabbit *rabbitmqv2.RabbitmqCluster,
+) ctrl.Result {
+	Log := r.GetLogger(ctx)
+
+	apiClient, err := getDefaultUserAPIClient(ctx, helper, rabbit)
+	if err != nil {
+		Log.Error(err, "Failed to get the RabbitMQ management API client to check the feature flags")
+		return ctrl.Result{RequeueAfter: featureFlagsInterval}
+	}
+	nodes, err := apiClient.ListNodes(ctx)
+	if err != nil {
+		Log.Error(err, "Failed to list the RabbitMQ nodes")
+		return ctrl.Result{RequeueAfter: featureFlagsInterval}
+	}
+	instance.Status.EnabledPlugins = enabledPlugins(nodes)
+
+	flags, err := apiClient.ListFeatureFlags(ctx)
+	if err != nil {
+		Log.Error(err, "Failed to list the RabbitMQ feature flags")
+		return ctrl.Result{RequeueAfter: featureFlagsInterval}
+	}
+	pending := pendingFeatureFlags(flags)
+	if len(pending) == 0 {
+		instance.Status.PendingFeatureFlags = nil
+		return ctrl.Result{}
+	}
+
+	version, ok := clusterVersion(nodes, ptr.Deref(rabbit.Spec.Replicas, 1))
+	if !ok {
+		Log.Info("Waiting for all nodes to run the same RabbitMQ version to enable the feature flags", "featureFlags", pending)
+		instance.Status.PendingFeatureFlags = pending
+		return ctrl.Result{RequeueAfter: featureFlagsInterval}
+	}
+
+	for _, flag := range flags {
+		// Unavailable feature flags are not supported by all nodes yet
+		if flag.Stability != rabbitmqapi.FeatureFlagStabilityStable || flag.State != rabbitmqapi.FeatureFlagStateDisabled {
+			continue
+		}
+		Log.Info("Enabling feature flag", "featureFlag", flag.Name, "version", version)
+		if err := apiClient.EnableFeatureFlag(ctx, flag.Name); err != nil {
+			Log.Error(err, "Failed to enable feature flag", "featureFlag", flag.Name)
+			continue
+		}
+		pending = slices.DeleteFunc(pending, func(name string) bool { return name == flag.Name })
+	}
+
+	if len(pending) == 0 {
+		instance.Status.PendingFeatureFlags = nil
+		return ctrl.Result{}
+	}
+	instance.Status.PendingFeatureFlags = pending
+	return ctrl.Result{RequeueAfter: featureFlagsInterval}
+}
+
+// enabledPlugins returns the sorted plugins enabled on all running nodes
+func enabledPlugins(nodes []rabbitmqapi.Node) []string {
+	var plugins []string
+	first := true
+	for _, node := range nodes {
+		if !node.Running {
+			continue
+		}
+		if first {
+			plugins = slices.Clone(node.EnabledPlugins)
+			first = false
+			continue
+		}
+		plugins = slices.DeleteFunc(plugins, func(plugin string) bool {
+			return !slices.Contains(node.EnabledPlugins, plugin)
+		})
+	}
+	sort.Strings(plugins)
+	return plugins
+}
+
+// pendingFeatureFlags returns the sorted stable feature flags which are not enabled
+func pendingFeatureFlags(flags []rabbitmqapi.FeatureFlag) []string {
+	pending := []string{}
+	for _, flag := range flags {
+		if flag.Stability == rabbitmqapi.FeatureFlagStabilityStable && flag.State != rabbitmqapi.FeatureFlagStateEnabled {
+			pending = append(pending, flag.Name)
+		}
+	}
+	sort.Strings(pending)
+	return pending
+}
+
+// clusterVersion returns the RabbitMQ version of the cluster if all replicas are running
+// and every node runs the same version
+func clusterVersion(nodes []rabbitmqapi.Node, replicas int32) (string, bool) {
+	version := ""
+	running := int32(0)
+	for _, node := range nodes {
+		if !node.Running {
+			return "", false
+		}
+		nodeVersion := node.RabbitMQVersion()
+		if nodeVersion == "" || (version != "" && nodeVersion != version) {
+			return "", false
+		}
+		version = nodeVersion
+		running++
+	}
+	return version, running > 0 && running == replicas
+}
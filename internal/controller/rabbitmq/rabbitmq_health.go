@@ -0,0 +1,190 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	rabbitmqv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	rabbitmqv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	rabbitmqapi "github.com/openstack-k8s-operators/infra-operator/pkg/rabbitmq/api"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+)
+
+// healthCheckInterval - how often the health checks of the cluster are run
+const healthCheckInterval = time.Minute
+
+// reconcileHealth runs the health checks of the cluster through the management API and reports
+// resource alarms, network partitions and quorum critical queues in the status and in the
+// BrokerHealthy condition. The result of the last health check is reused until the next one is
+// due, errors are reported in the condition and the health check is retried at the next interval.
+// The condition is informational, an unhealthy broker doesn't make the RabbitMq not ready.
+func (r *Reconciler) reconcileHealth(
+	ctx context.Context,
+	instance *rabbitmqv1beta1.RabbitMq,
+	helper *helper.Helper,
+	rabbit *rabbitmqv2.RabbitmqCluster,
+) ctrl.Result {
+	Log := r.GetLogger(ctx)
+
+	if health := instance.Status.Health; health != nil && health.LastCheckTime != nil {
+		if next := time.Until(health.LastCheckTime.Add(healthCheckInterval)); next > 0 {
+			setBrokerHealthyCondition(instance)
+			return ctrl.Result{RequeueAfter: next}
+		}
+	}
+
+	health, err := r.checkHealth(ctx, helper, rabbit)
+	if err != nil {
+		Log.Error(err, "Failed to check the health of the RabbitMQ cluster")
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			rabbitmqv1beta1.BrokerHealthyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			rabbitmqv1beta1.BrokerHealthyErrorMessage,
+			err.Error()))
+		return ctrl.Result{RequeueAfter: healthCheckInterval}
+	}
+	health.LastCheckTime = &metav1.Time{Time: time.Now()}
+	instance.Status.Health = health
+	setBrokerHealthyCondition(instance)
+
+	return ctrl.Result{RequeueAfter: healthCheckInterval}
+}
+
+// checkHealth runs the health checks and collects the network partitions reported by the nodes
+// of the cluster. The local alarm and quorum critical checks only check the node serving the
+// request, they are run against every node.
+func (r *Reconciler) checkHealth(
+	ctx context.Context,
+	helper *helper.Helper,
+	rabbit *rabbitmqv2.RabbitmqCluster,
+) (*rabbitmqv1beta1.RabbitMqHealth, error) {
+	apiClient, err := getDefaultUserAPIClient(ctx, helper, rabbit)
+	if err != nil {
+		return nil, err
+	}
+
+	health := &rabbitmqv1beta1.RabbitMqHealth{}
+	result, err := apiClient.CheckAlarms(ctx)
+	if err != nil {
+		return nil, err
+	}
+	addHealthCheckResult(health, result)
+
+	for ordinal := int32(0); ordinal < ptr.Deref(rabbit.Spec.Replicas, 1); ordinal++ {
+		podName := fmt.Sprintf("%s-server-%d", rabbit.Name, ordinal)
+		nodeClient, err := r.nodeAPIClient(ctx, helper, rabbit, podName)
+		if err != nil {
+			return nil, err
+		}
+		for _, check := range []func(context.Context) (*rabbitmqapi.HealthCheck, error){
+			nodeClient.CheckLocalAlarms,
+			nodeClient.CheckNodeIsQuorumCritical,
+		} {
+			result, err := check(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", podName, err)
+			}
+			addHealthCheckResult(health, result)
+		}
+	}
+
+	nodes, err := apiClient.ListNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		for _, partition := range node.Partitions {
+			health.Partitions = appendUnique(health.Partitions, partition)
+		}
+	}
+
+	sort.Strings(health.Alarms)
+	sort.Strings(health.Partitions)
+	sort.Strings(health.QuorumCriticalQueues)
+	return health, nil
+}
+
+// addHealthCheckResult adds the alarms and quorum critical queues reported by a health check
+func addHealthCheckResult(health *rabbitmqv1beta1.RabbitMqHealth, result *rabbitmqapi.HealthCheck) {
+	for _, alarm := range result.Alarms {
+		health.Alarms = appendUnique(health.Alarms, fmt.Sprintf("%s/%s", alarm.Node, alarm.Resource))
+	}
+	for _, queue := range result.Queues {
+		health.QuorumCriticalQueues = appendUnique(health.QuorumCriticalQueues, fmt.Sprintf("%s/%s", queue.Vhost, queue.Name))
+	}
+}
+
+// nodeAPIClient returns the management API client of the node running in the pod
+func (r *Reconciler) nodeAPIClient(
+	ctx context.Context,
+	helper *helper.Helper,
+	rabbit *rabbitmqv2.RabbitmqCluster,
+	podName string,
+) (*rabbitmqapi.Client, error) {
+	host := fmt.Sprintf("%s.%s-nodes.%s.svc", podName, rabbit.Name, rabbit.Namespace)
+	if r.NodeHost != nil {
+		host = r.NodeHost(rabbit, podName)
+	}
+	return getNodeAPIClient(ctx, helper, rabbit, host)
+}
+
+// setBrokerHealthyCondition sets the BrokerHealthy condition from the result of the last health check
+func setBrokerHealthyCondition(instance *rabbitmqv1beta1.RabbitMq) {
+	health := instance.Status.Health
+
+	var problems []string
+	if len(health.Alarms) > 0 {
+		problems = append(problems, "alarms in effect: "+strings.Join(health.Alarms, ", "))
+	}
+	if len(health.Partitions) > 0 {
+		problems = append(problems, "partitioned nodes: "+strings.Join(health.Partitions, ", "))
+	}
+	if len(health.QuorumCriticalQueues) > 0 {
+		problems = append(problems, "quorum critical queues: "+strings.Join(health.QuorumCriticalQueues, ", "))
+	}
+
+	if len(problems) == 0 {
+		instance.Status.Conditions.MarkTrue(rabbitmqv1beta1.BrokerHealthyCondition, rabbitmqv1beta1.BrokerHealthyMessage)
+		return
+	}
+	instance.Status.Conditions.Set(condition.FalseCondition(
+		rabbitmqv1beta1.BrokerHealthyCondition,
+		condition.ErrorReason,
+		condition.SeverityWarning,
+		rabbitmqv1beta1.BrokerUnhealthyMessage,
+		strings.Join(problems, "; ")))
+}
+
+// appendUnique appends the value to the list if it is not in the list yet
+func appendUnique(list []string, value string) []string {
+	if slices.Contains(list, value) {
+		return list
+	}
+	return append(list, value)
+}
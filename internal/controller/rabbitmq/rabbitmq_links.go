@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	rabbitmqclusterv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rabbitmqv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+)
+
+const (
+	// shovelPlugin - plugin running the dynamic shovels declared in a cluster
+	shovelPlugin = "rabbitmq_shovel"
+	// federationPlugin - plugin running the federation links of the upstreams declared in a cluster
+	federationPlugin = "rabbitmq_federation"
+)
+
+// clusterLinks - plugins and CA certificates needed by the shovels and federation links running
+// in a cluster
+type clusterLinks struct {
+	// plugins - rabbitmq_shovel and rabbitmq_federation, if any shovel or upstream runs in the cluster
+	plugins []string
+	// peerCASecrets - CA secrets of the TLS enabled peers with a different CA than the cluster
+	peerCASecrets []string
+}
+
+// getClusterLinks returns the plugins and peer CA secrets of the shovels declared in the
+// cluster as their source, and of the federation upstreams declared in it as their downstream
+func (r *Reconciler) getClusterLinks(ctx context.Context, instance *rabbitmqv1beta1.RabbitMq) (*clusterLinks, error) {
+	links := &clusterLinks{}
+	peers := []string{}
+
+	shovelList := &rabbitmqv1beta1.RabbitMQShovelList{}
+	if err := r.List(ctx, shovelList, client.InNamespace(instance.Namespace)); err != nil {
+		return nil, err
+	}
+	for _, shovel := range shovelList.Items {
+		if shovel.Spec.Source.RabbitmqClusterName != instance.Name {
+			continue
+		}
+		if !slices.Contains(links.plugins, shovelPlugin) {
+			links.plugins = append(links.plugins, shovelPlugin)
+		}
+		peers = append(peers, shovel.Spec.Destination.RabbitmqClusterName)
+	}
+
+	upstreamList := &rabbitmqv1beta1.RabbitMQFederationUpstreamList{}
+	if err := r.List(ctx, upstreamList, client.InNamespace(instance.Namespace)); err != nil {
+		return nil, err
+	}
+	for _, upstream := range upstreamList.Items {
+		if upstream.Spec.RabbitmqClusterName != instance.Name {
+			continue
+		}
+		if !slices.Contains(links.plugins, federationPlugin) {
+			links.plugins = append(links.plugins, federationPlugin)
+		}
+		peers = append(peers, upstream.Spec.Upstream.RabbitmqClusterName)
+	}
+
+	if instance.Spec.TLS.SecretName == "" {
+		return links, nil
+	}
+	ownCASecret := getCASecretName(instance.Spec.TLS)
+	for _, peer := range peers {
+		if peer == instance.Name {
+			continue
+		}
+		peerCR := &rabbitmqv1beta1.RabbitMq{}
+		err := r.Get(ctx, types.NamespacedName{Name: peer, Namespace: instance.Namespace}, peerCR)
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				// the link waits for the peer to be created
+				continue
+			}
+			return nil, err
+		}
+		if peerCR.Spec.TLS.SecretName == "" {
+			continue
+		}
+		caSecret := getCASecretName(peerCR.Spec.TLS)
+		if caSecret != ownCASecret && !slices.Contains(links.peerCASecrets, caSecret) {
+			links.peerCASecrets = append(links.peerCASecrets, caSecret)
+		}
+	}
+	// keep the order stable to not restart the pods on every reconcile
+	slices.Sort(links.peerCASecrets)
+
+	return links, nil
+}
+
+// linkToRabbitMqMapFunc maps shovels and federation upstreams to the RabbitMq they run in
+func (r *Reconciler) linkToRabbitMqMapFunc(_ context.Context, obj client.Object) []reconcile.Request {
+	clusterName := ""
+	switch link := obj.(type) {
+	case *rabbitmqv1beta1.RabbitMQShovel:
+		clusterName = link.Spec.Source.RabbitmqClusterName
+	case *rabbitmqv1beta1.RabbitMQFederationUpstream:
+		clusterName = link.Spec.RabbitmqClusterName
+	}
+	if clusterName == "" {
+		return nil
+	}
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{Name: clusterName, Namespace: obj.GetNamespace()},
+	}}
+}
+
+// getCASecretName returns the secret with the CA of a TLS enabled cluster, which is the TLS
+// secret itself if the cluster has no separate CA secret
+func getCASecretName(tls rabbitmqclusterv2.TLSSpec) string {
+	if tls.CaSecretName != "" {
+		return tls.CaSecretName
+	}
+	return tls.SecretName
+}
+
+// linkNamesRegex returns a permission regex matching exactly the given names and patterns,
+// names are quoted while patterns are used as they are. Without any it matches nothing.
+func linkNamesRegex(patterns []string, names ...string) string {
+	alternatives := slices.Clone(patterns)
+	for _, name := range names {
+		if name != "" {
+			alternatives = append(alternatives, regexp.QuoteMeta(name))
+		}
+	}
+	if len(alternatives) == 0 {
+		return "^$"
+	}
+	return "^(" + strings.Join(alternatives, "|") + ")$"
+}
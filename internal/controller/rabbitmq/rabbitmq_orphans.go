@@ -0,0 +1,254 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	rabbitmqv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rabbitmqv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	rabbitmqapi "github.com/openstack-k8s-operators/infra-operator/pkg/rabbitmq/api"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	oko_secret "github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+)
+
+// reconcileOrphans periodically sweeps RabbitMQ for users, vhosts and policies which are not
+// managed by any CR of the cluster, reports them in the status and deletes them if requested.
+// The sweep is best effort, errors are logged and the sweep is retried at the next interval.
+func (r *Reconciler) reconcileOrphans(
+	ctx context.Context,
+	instance *rabbitmqv1beta1.RabbitMq,
+	helper *helper.Helper,
+	rabbit *rabbitmqv2.RabbitmqCluster,
+) ctrl.Result {
+	Log := r.GetLogger(ctx)
+
+	interval := instance.Spec.OrphanCleanup.GetInterval()
+	if interval <= 0 {
+		instance.Status.Orphans = nil
+		return ctrl.Result{}
+	}
+
+	if instance.Status.Orphans != nil && instance.Status.Orphans.LastSweepTime != nil {
+		if next := time.Until(instance.Status.Orphans.LastSweepTime.Add(interval)); next > 0 {
+			return ctrl.Result{RequeueAfter: next}
+		}
+	}
+
+	orphans, err := r.sweepOrphans(ctx, instance, helper, rabbit)
+	if err != nil {
+		Log.Error(err, "Failed to sweep RabbitMQ for orphaned objects")
+		return ctrl.Result{RequeueAfter: interval}
+	}
+	orphans.LastSweepTime = &metav1.Time{Time: time.Now()}
+	instance.Status.Orphans = orphans
+
+	return ctrl.Result{RequeueAfter: interval}
+}
+
+// sweepOrphans lists the users, vhosts and policies in RabbitMQ and returns the ones which
+// are not managed by any CR, after deleting them if requested
+func (r *Reconciler) sweepOrphans(
+	ctx context.Context,
+	instance *rabbitmqv1beta1.RabbitMq,
+	helper *helper.Helper,
+	rabbit *rabbitmqv2.RabbitmqCluster,
+) (*rabbitmqv1beta1.RabbitMqOrphans, error) {
+	Log := r.GetLogger(ctx)
+
+	rabbitSecret, _, err := oko_secret.GetSecret(ctx, helper, rabbit.Status.DefaultUser.SecretReference.Name, instance.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	apiClient, err := getAPIClient(ctx, helper, rabbit, rabbitSecret)
+	if err != nil {
+		return nil, err
+	}
+	defaultUser := string(rabbitSecret.Data["username"])
+
+	// List the objects in RabbitMQ before the CRs. CRs are created before their objects in
+	// RabbitMQ, so an object created during the sweep always has its CR in the lists below.
+	users, err := apiClient.ListUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	vhosts, err := apiClient.ListVhosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	policies, err := apiClient.ListPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userList := &rabbitmqv1beta1.RabbitMQUserList{}
+	if err := r.List(ctx, userList, client.InNamespace(instance.Namespace)); err != nil {
+		return nil, err
+	}
+	vhostList := &rabbitmqv1beta1.RabbitMQVhostList{}
+	if err := r.List(ctx, vhostList, client.InNamespace(instance.Namespace)); err != nil {
+		return nil, err
+	}
+	policyList := &rabbitmqv1beta1.RabbitMQPolicyList{}
+	if err := r.List(ctx, policyList, client.InNamespace(instance.Namespace)); err != nil {
+		return nil, err
+	}
+
+	orphans := findOrphans(instance.Name, defaultUser, users, vhosts, policies, userList.Items, vhostList.Items, policyList.Items)
+	if !instance.Spec.OrphanCleanup.ShouldDelete() {
+		if len(orphans.Users)+len(orphans.Vhosts)+len(orphans.Policies) > 0 {
+			Log.Info("Found orphaned objects in RabbitMQ", "users", orphans.Users, "vhosts", orphans.Vhosts, "policies", orphans.Policies)
+		}
+		return orphans, nil
+	}
+
+	// Delete policies first, policies of orphaned vhosts get deleted with the vhost
+	remaining := &rabbitmqv1beta1.RabbitMqOrphans{}
+	orphanedVhosts := map[string]bool{}
+	for _, vhost := range orphans.Vhosts {
+		orphanedVhosts[vhost] = true
+	}
+	for _, policy := range policies {
+		key := policyKey(policy.Vhost, policy.Name)
+		if !slices.Contains(orphans.Policies, key) || orphanedVhosts[policy.Vhost] {
+			continue
+		}
+		Log.Info("Deleting orphaned policy from RabbitMQ", "vhost", policy.Vhost, "policy", policy.Name)
+		if err := apiClient.DeletePolicy(ctx, policy.Vhost, policy.Name); err != nil {
+			Log.Error(err, "Failed to delete orphaned policy", "vhost", policy.Vhost, "policy", policy.Name)
+			remaining.Policies = append(remaining.Policies, key)
+		}
+	}
+	for _, user := range orphans.Users {
+		Log.Info("Deleting orphaned user from RabbitMQ", "user", user)
+		if err := apiClient.DeleteUser(ctx, user); err != nil {
+			Log.Error(err, "Failed to delete orphaned user", "user", user)
+			remaining.Users = append(remaining.Users, user)
+		}
+	}
+	for _, vhost := range orphans.Vhosts {
+		Log.Info("Deleting orphaned vhost from RabbitMQ", "vhost", vhost)
+		if err := apiClient.DeleteVhost(ctx, vhost); err != nil {
+			Log.Error(err, "Failed to delete orphaned vhost", "vhost", vhost)
+			remaining.Vhosts = append(remaining.Vhosts, vhost)
+			for _, policy := range policies {
+				if key := policyKey(policy.Vhost, policy.Name); policy.Vhost == vhost && slices.Contains(orphans.Policies, key) {
+					remaining.Policies = append(remaining.Policies, key)
+				}
+			}
+		}
+	}
+	sort.Strings(remaining.Policies)
+
+	return remaining, nil
+}
+
+// findOrphans returns the users, vhosts and policies in RabbitMQ which are not managed by any
+// RabbitMQUser, RabbitMQVhost or RabbitMQPolicy of the cluster. The default user and the
+// default vhost "/" are never orphans.
+func findOrphans(
+	clusterName string,
+	defaultUser string,
+	users []rabbitmqapi.User,
+	vhosts []rabbitmqapi.Vhost,
+	policies []rabbitmqapi.Policy,
+	userCRs []rabbitmqv1beta1.RabbitMQUser,
+	vhostCRs []rabbitmqv1beta1.RabbitMQVhost,
+	policyCRs []rabbitmqv1beta1.RabbitMQPolicy,
+) *rabbitmqv1beta1.RabbitMqOrphans {
+	managedUsers := map[string]bool{defaultUser: true}
+	for _, user := range userCRs {
+		if user.Spec.RabbitmqClusterName != clusterName {
+			continue
+		}
+		managedUsers[user.Spec.Username] = true
+		if user.Status.Username != "" {
+			managedUsers[user.Status.Username] = true
+		}
+	}
+
+	managedVhosts := map[string]bool{"/": true}
+	vhostNames := map[string]string{}
+	for _, vhost := range vhostCRs {
+		if vhost.Spec.RabbitmqClusterName != clusterName {
+			continue
+		}
+		name := vhost.Spec.Name
+		if name == "" {
+			name = "/"
+		}
+		managedVhosts[name] = true
+		vhostNames[vhost.Name] = name
+	}
+
+	// Policies whose vhost can't be resolved are treated as managed in every vhost
+	managedPolicies := map[string]bool{}
+	managedInAnyVhost := map[string]bool{}
+	for _, policy := range policyCRs {
+		if policy.Spec.RabbitmqClusterName != clusterName {
+			continue
+		}
+		name := policy.Spec.Name
+		if name == "" {
+			name = policy.Name
+		}
+		vhost := "/"
+		if policy.Spec.VhostRef != "" {
+			var ok bool
+			if vhost, ok = vhostNames[policy.Spec.VhostRef]; !ok {
+				managedInAnyVhost[name] = true
+				continue
+			}
+		}
+		managedPolicies[policyKey(vhost, name)] = true
+	}
+
+	orphans := &rabbitmqv1beta1.RabbitMqOrphans{}
+	for _, user := range users {
+		if !managedUsers[user.Name] {
+			orphans.Users = append(orphans.Users, user.Name)
+		}
+	}
+	for _, vhost := range vhosts {
+		if !managedVhosts[vhost.Name] {
+			orphans.Vhosts = append(orphans.Vhosts, vhost.Name)
+		}
+	}
+	for _, policy := range policies {
+		if !managedPolicies[policyKey(policy.Vhost, policy.Name)] && !managedInAnyVhost[policy.Name] {
+			orphans.Policies = append(orphans.Policies, policyKey(policy.Vhost, policy.Name))
+		}
+	}
+	sort.Strings(orphans.Users)
+	sort.Strings(orphans.Vhosts)
+	sort.Strings(orphans.Policies)
+
+	return orphans
+}
+
+// policyKey returns the key of a policy in the orphans status
+func policyKey(vhost, name string) string {
+	return fmt.Sprintf("%s/%s", vhost, name)
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	rabbitmqv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rabbitmqv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	rabbitmqapi "github.com/openstack-k8s-operators/infra-operator/pkg/rabbitmq/api"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	oko_secret "github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+)
+
+// queueMigrationInterval - how often the classic queues are checked while waiting for them to drain
+const queueMigrationInterval = 10 * time.Second
+
+// queueMigrationRescanPeriod - how long the vhosts are checked for classic queues after the clients
+// got switched to quorum queues, for clients which were not restarted with the new configuration yet
+const queueMigrationRescanPeriod = 10 * time.Minute
+
+// classicQueueArguments are only supported by classic queues and get dropped from the
+// arguments of the quorum queues replacing them
+var classicQueueArguments = []string{
+	"x-max-priority",
+	"x-queue-mode",
+	"x-queue-master-locator",
+	"x-queue-version",
+}
+
+// reconcileQueueMigration migrates the classic queues of all vhosts to quorum queues after the
+// QueueType changed from Mirrored to Quorum. The queues of a vhost are only migrated once all of
+// them are drained of consumers and messages, until then the clients keep using the classic queues.
+// Durable queues are recreated as quorum queues, transient ones get recreated by the clients.
+// Clients can declare classic queues again in vhosts which were already migrated until they get
+// switched to quorum queues, so all vhosts are checked on every run. It returns true once no
+// vhost has classic queues left.
+func (r *Reconciler) reconcileQueueMigration(
+	ctx context.Context,
+	instance *rabbitmqv1beta1.RabbitMq,
+	helper *helper.Helper,
+	rabbit *rabbitmqv2.RabbitmqCluster,
+) (bool, error) {
+	Log := r.GetLogger(ctx)
+
+	migration := instance.Status.QueueMigration
+	if !instance.Status.QueueMigration.IsRescanning() &&
+		(migration == nil || migration.Phase != rabbitmqv1beta1.QueueMigrationPhaseDraining) {
+		Log.Info("QueueType changed from Mirrored to Quorum. Starting migration of the classic queues")
+		migration = &rabbitmqv1beta1.RabbitMqQueueMigration{
+			Phase:     rabbitmqv1beta1.QueueMigrationPhaseDraining,
+			StartTime: &metav1.Time{Time: time.Now()},
+		}
+		instance.Status.QueueMigration = migration
+	}
+
+	rabbitSecret, _, err := oko_secret.GetSecret(ctx, helper, rabbit.Status.DefaultUser.SecretReference.Name, instance.Namespace)
+	if err != nil {
+		return false, err
+	}
+	apiClient, err := getAPIClient(ctx, helper, rabbit, rabbitSecret)
+	if err != nil {
+		return false, err
+	}
+
+	vhosts, err := apiClient.ListVhosts(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	done := true
+	for _, vhost := range vhosts {
+		state := migration.GetVhost(vhost.Name)
+
+		queues, err := apiClient.ListQueues(ctx, vhost.Name)
+		if err != nil {
+			return false, err
+		}
+		classicQueues, pending := classicQueuesToMigrate(queues)
+		if len(pending) == 0 && len(classicQueues) > 0 {
+			var migrated int32
+			migrated, pending, err = r.migrateVhostQueues(ctx, apiClient, vhost.Name)
+			state.Migrated += migrated
+			if err != nil {
+				return false, err
+			}
+		}
+		state.Pending = pending
+		if len(pending) > 0 {
+			Log.Info("Waiting for classic queues to drain", "vhost", vhost.Name, "queues", pending)
+			state.Phase = rabbitmqv1beta1.QueueMigrationPhaseDraining
+			done = false
+			continue
+		}
+		state.Phase = rabbitmqv1beta1.QueueMigrationPhaseCompleted
+	}
+
+	return done, nil
+}
+
+// migrateVhostQueues replaces the classic queues of a vhost by quorum queues. Client connections to
+// the vhost are blocked meanwhile, otherwise a client could declare a classic queue again between its
+// deletion and the declaration of the quorum queue. It returns the number of migrated queues and the
+// classic queues which got consumers or messages before the connections were closed.
+func (r *Reconciler) migrateVhostQueues(
+	ctx context.Context,
+	apiClient *rabbitmqapi.Client,
+	vhost string,
+) (migrated int32, pending []string, err error) {
+	Log := r.GetLogger(ctx)
+
+	limits, err := apiClient.GetVhostLimits(ctx, vhost)
+	if err != nil {
+		return 0, nil, err
+	}
+	Log.Info("Blocking client connections to migrate the classic queues", "vhost", vhost)
+	if err := apiClient.SetVhostLimit(ctx, vhost, rabbitmqapi.VhostLimitMaxConnections, 0); err != nil {
+		return 0, nil, err
+	}
+	defer func() {
+		var restoreErr error
+		if limit, ok := limits[rabbitmqapi.VhostLimitMaxConnections]; ok {
+			restoreErr = apiClient.SetVhostLimit(ctx, vhost, rabbitmqapi.VhostLimitMaxConnections, limit)
+		} else {
+			restoreErr = apiClient.DeleteVhostLimit(ctx, vhost, rabbitmqapi.VhostLimitMaxConnections)
+		}
+		if restoreErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to unblock client connections to vhost %s: %w", vhost, restoreErr))
+		}
+	}()
+
+	connections, err := apiClient.ListVhostConnections(ctx, vhost)
+	if err != nil {
+		return 0, nil, err
+	}
+	for _, connection := range connections {
+		if err := apiClient.CloseConnection(ctx, connection.Name); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	// The queues could have been used before the connections got closed
+	queues, err := apiClient.ListQueues(ctx, vhost)
+	if err != nil {
+		return 0, nil, err
+	}
+	classicQueues, pending := classicQueuesToMigrate(queues)
+	if len(pending) > 0 {
+		return 0, pending, nil
+	}
+
+	for _, queue := range classicQueues {
+		Log.Info("Migrating classic queue to quorum queue", "vhost", vhost, "queue", queue.Name)
+		// Only delete empty queues, in case a message got published to the queue in the meantime
+		if err := apiClient.DeleteQueue(ctx, vhost, queue.Name, true); err != nil {
+			return migrated, nil, err
+		}
+		if queue.Durable && !queue.AutoDelete {
+			if err := apiClient.CreateOrUpdateQueue(ctx, vhost, queue.Name, true, false, quorumQueueArguments(queue.Arguments)); err != nil {
+				return migrated, nil, err
+			}
+		}
+		migrated++
+	}
+	return migrated, nil, nil
+}
+
+// transportURLsSwitched returns true if all TransportURLs of the cluster switched their clients to
+// quorum queues
+func (r *Reconciler) transportURLsSwitched(ctx context.Context, instance *rabbitmqv1beta1.RabbitMq) (bool, error) {
+	transportURLs := &rabbitmqv1beta1.TransportURLList{}
+	if err := r.List(ctx, transportURLs, client.InNamespace(instance.Namespace)); err != nil {
+		return false, err
+	}
+	for _, transportURL := range transportURLs.Items {
+		if transportURL.Spec.RabbitmqClusterName == instance.Name &&
+			transportURL.Status.QueueType != rabbitmqv1beta1.QueueTypeQuorum {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// reconcileQueueMigrationRescan migrates the classic queues declared by clients which still used the
+// configuration from before the switch to quorum queues. The migration completes once all TransportURLs
+// switched, the queueMigrationRescanPeriod passed and no vhost has classic queues left.
+func (r *Reconciler) reconcileQueueMigrationRescan(
+	ctx context.Context,
+	instance *rabbitmqv1beta1.RabbitMq,
+	helper *helper.Helper,
+	rabbit *rabbitmqv2.RabbitmqCluster,
+) (bool, error) {
+	Log := r.GetLogger(ctx)
+
+	migrated, err := r.reconcileQueueMigration(ctx, instance, helper, rabbit)
+	if err != nil || !migrated {
+		return false, err
+	}
+
+	migration := instance.Status.QueueMigration
+	if migration.SwitchTime != nil && time.Since(migration.SwitchTime.Time) < queueMigrationRescanPeriod {
+		return false, nil
+	}
+	switched, err := r.transportURLsSwitched(ctx, instance)
+	if err != nil || !switched {
+		return false, err
+	}
+
+	Log.Info("Migration of the classic queues to quorum queues completed")
+	migration.Phase = rabbitmqv1beta1.QueueMigrationPhaseCompleted
+	migration.CompletionTime = &metav1.Time{Time: time.Now()}
+	return true, nil
+}
+
+// classicQueuesToMigrate returns the classic queues which need to be replaced, and the names
+// of the ones which still have consumers or messages. Exclusive queues go away with their
+// connection and are ignored.
+func classicQueuesToMigrate(queues []rabbitmqapi.Queue) ([]rabbitmqapi.Queue, []string) {
+	classicQueues := []rabbitmqapi.Queue{}
+	pending := []string{}
+	for _, queue := range queues {
+		if queue.Type != "classic" || queue.Exclusive {
+			continue
+		}
+		classicQueues = append(classicQueues, queue)
+		if queue.Consumers > 0 || queue.Messages > 0 {
+			pending = append(pending, queue.Name)
+		}
+	}
+	return classicQueues, pending
+}
+
+// quorumQueueArguments returns the arguments of a classic queue for the quorum queue replacing it
+func quorumQueueArguments(arguments map[string]interface{}) map[string]interface{} {
+	quorumArguments := maps.Clone(arguments)
+	if quorumArguments == nil {
+		quorumArguments = map[string]interface{}{}
+	}
+	for _, argument := range classicQueueArguments {
+		delete(quorumArguments, argument)
+	}
+	quorumArguments["x-queue-type"] = "quorum"
+	return quorumArguments
+}
@@ -0,0 +1,376 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	rabbitmqv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	rabbitmqv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	rabbitmqapi "github.com/openstack-k8s-operators/infra-operator/pkg/rabbitmq/api"
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+)
+
+// scaleDownInterval - how often the scale down is checked while waiting for the cluster, the
+// quorum queues or the pods
+const scaleDownInterval = 10 * time.Second
+
+// holdReplicasForScaleDown keeps the replicas of the RabbitmqCluster while the quorum queue
+// members on the departing nodes get removed. The replicas only get reduced once all members
+// are removed, the rabbitmq cluster-operator itself refuses to scale down. Scaling to zero is
+// supported by the cluster-operator and keeps the queue members, it is not handled here.
+func (r *Reconciler) holdReplicasForScaleDown(
+	ctx context.Context,
+	instance *rabbitmqv1beta1.RabbitMq,
+	rabbitmqCluster *rabbitmqv2.RabbitmqCluster,
+) error {
+	Log := r.GetLogger(ctx)
+
+	current := &rabbitmqv2.RabbitmqCluster{}
+	err := r.Get(ctx, types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, current)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	currentReplicas := ptr.Deref(current.Spec.Replicas, 1)
+	desiredReplicas := ptr.Deref(rabbitmqCluster.Spec.Replicas, 1)
+
+	scaleDown := instance.Status.ScaleDown
+	if scaleDown == nil {
+		if desiredReplicas == 0 || desiredReplicas >= currentReplicas {
+			return nil
+		}
+		Log.Info("Replicas reduced. Starting scale down", "from", currentReplicas, "to", desiredReplicas)
+		scaleDown = &rabbitmqv1beta1.RabbitMqScaleDown{
+			Replicas:       currentReplicas,
+			TargetReplicas: desiredReplicas,
+			Phase:          rabbitmqv1beta1.ScaleDownPhaseShrinkingQueues,
+			StartTime:      &metav1.Time{Time: time.Now()},
+		}
+		instance.Status.ScaleDown = scaleDown
+	}
+
+	// Once the members are removed the replicas get reduced
+	if scaleDown.Phase != rabbitmqv1beta1.ScaleDownPhaseShrinkingQueues {
+		return nil
+	}
+
+	if desiredReplicas == 0 || desiredReplicas >= scaleDown.Replicas {
+		// Nodes whose members were already removed get members again when queues are grown
+		Log.Info("Replicas restored. Cancelling scale down", "shrunkNodes", scaleDown.ShrunkNodes)
+		instance.Status.ScaleDown = nil
+		instance.Status.Conditions.Remove(rabbitmqv1beta1.ScaleDownReadyCondition)
+		return nil
+	}
+
+	scaleDown.TargetReplicas = desiredReplicas
+	rabbitmqCluster.Spec.Replicas = ptr.To(scaleDown.Replicas)
+	return nil
+}
+
+// reconcileScaleDown removes the departing nodes of a scale down. It checks that every quorum
+// queue keeps a majority of online members on the remaining nodes, then removes the quorum queue
+// members on the departing nodes, like rabbitmq-queues shrink does. Afterwards the replicas of the
+// RabbitmqCluster and its StatefulSet get reduced, which removes the pods, the per-pod services
+// of the departed pods get deleted and the departed nodes get removed from the cluster. Each step
+// is reported in the ScaleDownReady condition.
+func (r *Reconciler) reconcileScaleDown(
+	ctx context.Context,
+	instance *rabbitmqv1beta1.RabbitMq,
+	helper *helper.Helper,
+	rabbit *rabbitmqv2.RabbitmqCluster,
+	clusterReady bool,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	scaleDown := instance.Status.ScaleDown
+	if scaleDown == nil {
+		return ctrl.Result{}, nil
+	}
+
+	if scaleDown.Phase == rabbitmqv1beta1.ScaleDownPhaseShrinkingQueues {
+		if !clusterReady {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1beta1.ScaleDownReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				rabbitmqv1beta1.ScaleDownWaitingMessage))
+			return ctrl.Result{RequeueAfter: scaleDownInterval}, nil
+		}
+
+		departingNodes := []string{}
+		for ordinal := scaleDown.TargetReplicas; ordinal < scaleDown.Replicas; ordinal++ {
+			departingNodes = append(departingNodes, rabbitmqNodeName(instance, ordinal))
+		}
+
+		apiClient, err := getDefaultUserAPIClient(ctx, helper, rabbit)
+		if err != nil {
+			return ctrl.Result{}, r.scaleDownError(instance, err)
+		}
+		queues, err := apiClient.ListAllQueues(ctx)
+		if err != nil {
+			return ctrl.Result{}, r.scaleDownError(instance, err)
+		}
+		if atRisk := quorumQueuesAtRisk(queues, departingNodes); len(atRisk) > 0 {
+			Log.Info("Quorum queues would lose their majority, waiting", "queues", atRisk)
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1beta1.ScaleDownReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityWarning,
+				rabbitmqv1beta1.ScaleDownQuorumAtRiskMessage,
+				strings.Join(atRisk, ", ")))
+			return ctrl.Result{RequeueAfter: scaleDownInterval}, nil
+		}
+
+		for _, node := range departingNodes {
+			if slices.Contains(scaleDown.ShrunkNodes, node) {
+				continue
+			}
+			Log.Info("Removing quorum queue members", "node", node)
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1beta1.ScaleDownReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				rabbitmqv1beta1.ScaleDownShrinkingMessage,
+				node))
+			if err := apiClient.ShrinkQuorumQueues(ctx, node); err != nil {
+				return ctrl.Result{}, r.scaleDownError(instance, err)
+			}
+			scaleDown.ShrunkNodes = append(scaleDown.ShrunkNodes, node)
+		}
+
+		// The replicas of the RabbitmqCluster get reduced by the next reconcile
+		scaleDown.Phase = rabbitmqv1beta1.ScaleDownPhaseRemovingPods
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	departingPods := []string{}
+	for ordinal := scaleDown.TargetReplicas; ordinal < scaleDown.Replicas; ordinal++ {
+		departingPods = append(departingPods, fmt.Sprintf("%s-server-%d", instance.Name, ordinal))
+	}
+	instance.Status.Conditions.Set(condition.FalseCondition(
+		rabbitmqv1beta1.ScaleDownReadyCondition,
+		condition.RequestedReason,
+		condition.SeverityInfo,
+		rabbitmqv1beta1.ScaleDownRemovingPodsMessage,
+		strings.Join(departingPods, ", ")))
+
+	// The cluster-operator doesn't touch the StatefulSet while its replicas are higher than
+	// the ones of the RabbitmqCluster, and continues as usual once they got reduced here
+	sts := &appsv1.StatefulSet{}
+	err := r.Get(ctx, types.NamespacedName{Name: instance.Name + "-server", Namespace: instance.Namespace}, sts)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return ctrl.Result{}, r.scaleDownError(instance, err)
+	}
+	if err == nil && ptr.Deref(sts.Spec.Replicas, 1) > scaleDown.TargetReplicas {
+		Log.Info("Scaling down StatefulSet", "statefulset", sts.Name, "replicas", scaleDown.TargetReplicas)
+		sts.Spec.Replicas = ptr.To(scaleDown.TargetReplicas)
+		if err := r.Update(ctx, sts); err != nil {
+			if k8s_errors.IsConflict(err) {
+				return ctrl.Result{Requeue: true}, nil
+			}
+			return ctrl.Result{}, r.scaleDownError(instance, err)
+		}
+	}
+
+	for _, podName := range departingPods {
+		pod := &corev1.Pod{}
+		err := r.Get(ctx, types.NamespacedName{Name: podName, Namespace: instance.Namespace}, pod)
+		if err == nil {
+			Log.Info("Waiting for pod to be removed", "pod", podName)
+			return ctrl.Result{RequeueAfter: scaleDownInterval}, nil
+		}
+		if !k8s_errors.IsNotFound(err) {
+			return ctrl.Result{}, r.scaleDownError(instance, err)
+		}
+	}
+
+	// The per-pod services of the remaining pods are kept by reconcilePerPodServices
+	for _, podName := range departingPods {
+		svc := &corev1.Service{}
+		err := r.Get(ctx, types.NamespacedName{Name: podName, Namespace: instance.Namespace}, svc)
+		if k8s_errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return ctrl.Result{}, r.scaleDownError(instance, err)
+		}
+		if !metav1.IsControlledBy(svc, instance) {
+			continue
+		}
+		if err := r.Delete(ctx, svc); err != nil && !k8s_errors.IsNotFound(err) {
+			return ctrl.Result{}, r.scaleDownError(instance, err)
+		}
+	}
+
+	// The departed nodes stay members of the cluster until they are forgotten, e.g. the feature
+	// flags don't get enabled while they are listed as stopped nodes
+	if err := r.forgetDepartedNodes(ctx, instance, helper, rabbit, scaleDown); err != nil {
+		return ctrl.Result{}, r.scaleDownError(instance, err)
+	}
+
+	Log.Info("Scale down completed", "replicas", scaleDown.TargetReplicas)
+	instance.Status.ScaleDown = nil
+	instance.Status.Conditions.MarkTrue(rabbitmqv1beta1.ScaleDownReadyCondition, rabbitmqv1beta1.ScaleDownReadyMessage)
+	return ctrl.Result{}, nil
+}
+
+// forgetDepartedNodes removes the stopped nodes of the departed pods from the cluster by
+// running rabbitmqctl forget_cluster_node on the first remaining node. Nodes which are not
+// members of the cluster anymore are skipped, so this can be retried.
+func (r *Reconciler) forgetDepartedNodes(
+	ctx context.Context,
+	instance *rabbitmqv1beta1.RabbitMq,
+	helper *helper.Helper,
+	rabbit *rabbitmqv2.RabbitmqCluster,
+	scaleDown *rabbitmqv1beta1.RabbitMqScaleDown,
+) error {
+	Log := r.GetLogger(ctx)
+
+	apiClient, err := getDefaultUserAPIClient(ctx, helper, rabbit)
+	if err != nil {
+		return err
+	}
+	nodes, err := apiClient.ListNodes(ctx)
+	if err != nil {
+		return err
+	}
+
+	departedNodes := []string{}
+	for ordinal := scaleDown.TargetReplicas; ordinal < scaleDown.Replicas; ordinal++ {
+		departedNodes = append(departedNodes, rabbitmqNodeName(instance, ordinal))
+	}
+
+	execInPod := r.ExecInPod
+	if execInPod == nil {
+		execInPod = r.execInPod
+	}
+	for _, node := range nodes {
+		if !slices.Contains(departedNodes, node.Name) {
+			continue
+		}
+		if node.Running {
+			return fmt.Errorf("departed node %s is still running", node.Name)
+		}
+		Log.Info("Removing departed node from the cluster", "node", node.Name)
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			rabbitmqv1beta1.ScaleDownReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			rabbitmqv1beta1.ScaleDownForgettingNodesMessage,
+			node.Name))
+		err := execInPod(ctx, instance.Namespace, instance.Name+"-server-0", "rabbitmq",
+			[]string{"rabbitmqctl", "forget_cluster_node", node.Name})
+		if err != nil {
+			return fmt.Errorf("failed to forget node %s: %w", node.Name, err)
+		}
+	}
+	return nil
+}
+
+// execInPod runs a command in a container of a pod and returns an error with the output on
+// stderr if the command fails
+func (r *Reconciler) execInPod(ctx context.Context, namespace, pod, container string, command []string) error {
+	req := r.Kclient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(r.config, http.MethodPost, req.URL())
+	if err != nil {
+		return err
+	}
+	var stdout, stderr bytes.Buffer
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: &stdout, Stderr: &stderr})
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// scaleDownError reports an error of the scale down in the ScaleDownReady condition
+func (r *Reconciler) scaleDownError(instance *rabbitmqv1beta1.RabbitMq, err error) error {
+	instance.Status.Conditions.Set(condition.FalseCondition(
+		rabbitmqv1beta1.ScaleDownReadyCondition,
+		condition.ErrorReason,
+		condition.SeverityWarning,
+		rabbitmqv1beta1.ScaleDownErrorMessage,
+		err.Error()))
+	return err
+}
+
+// rabbitmqNodeName returns the name of the RabbitMQ node running in the pod with the ordinal,
+// as set by the cluster-operator
+func rabbitmqNodeName(instance *rabbitmqv1beta1.RabbitMq, ordinal int32) string {
+	return fmt.Sprintf("rabbit@%s-server-%d.%s-nodes.%s", instance.Name, ordinal, instance.Name, instance.Namespace)
+}
+
+// quorumQueuesAtRisk returns the quorum queues with members on the departing nodes which would
+// not keep a majority of online members on the remaining nodes. Removing their members would
+// leave the queues without a leader or without any member.
+func quorumQueuesAtRisk(queues []rabbitmqapi.Queue, departingNodes []string) []string {
+	atRisk := []string{}
+	for _, queue := range queues {
+		if queue.Type != "quorum" {
+			continue
+		}
+		remaining := 0
+		remainingOnline := 0
+		for _, member := range queue.Members {
+			if slices.Contains(departingNodes, member) {
+				continue
+			}
+			remaining++
+			if slices.Contains(queue.Online, member) {
+				remainingOnline++
+			}
+		}
+		if remaining == len(queue.Members) {
+			continue
+		}
+		if remaining == 0 || remainingOnline*2 <= remaining {
+			atRisk = append(atRisk, fmt.Sprintf("%s/%s", queue.Vhost, queue.Name))
+		}
+	}
+	return atRisk
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	rabbitmqv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+)
+
+const (
+	// streamsPolicyLabel - label of the retention policies of the streams, the value is the name of the RabbitMq
+	streamsPolicyLabel = "rabbitmq.openstack.org/streams"
+	// streamsPolicyName - name of the retention policy of the streams in RabbitMQ
+	streamsPolicyName = "streams"
+	// streamsVhostRequeue - how long to wait for the RabbitMQVhost of a stream vhost
+	streamsVhostRequeue = 10 * time.Second
+)
+
+// reconcileStreams manages the retention policy of the streams in the stream vhosts as
+// RabbitMQPolicy CRs owned by the RabbitMq. Policies of vhosts which are no longer stream
+// vhosts, or of all vhosts once the retention settings are removed, get deleted. Stream
+// vhosts other than "/" are found by their RabbitMQVhost, which is usually created by a
+// TransportURL, reconciliation is retried until it exists.
+func (r *Reconciler) reconcileStreams(
+	ctx context.Context,
+	instance *rabbitmqv1beta1.RabbitMq,
+	helper *helper.Helper,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	result := ctrl.Result{}
+	desired := map[string]bool{}
+
+	if definition := instance.Spec.Streams.PolicyDefinition(); definition != nil {
+		definitionJSON, err := json.Marshal(definition)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		vhostList := &rabbitmqv1beta1.RabbitMQVhostList{}
+		if err := r.List(ctx, vhostList, client.InNamespace(instance.Namespace)); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		for _, vhost := range instance.Spec.Streams.Vhosts {
+			policyName := instance.Name + "-streams"
+			vhostRef := ""
+			if vhost != "/" {
+				vhostRef = findVhostCR(vhostList.Items, instance.Name, vhost)
+				if vhostRef == "" {
+					Log.Info("Waiting for the RabbitMQVhost of the stream vhost", "vhost", vhost)
+					result = ctrl.Result{RequeueAfter: streamsVhostRequeue}
+					continue
+				}
+				policyName += "-" + vhostRef
+			}
+			desired[policyName] = true
+
+			policy := &rabbitmqv1beta1.RabbitMQPolicy{}
+			policy.Name = policyName
+			policy.Namespace = instance.Namespace
+			_, err := controllerutil.CreateOrPatch(ctx, r.Client, policy, func() error {
+				if policy.Labels == nil {
+					policy.Labels = map[string]string{}
+				}
+				policy.Labels[streamsPolicyLabel] = instance.Name
+				policy.Spec.RabbitmqClusterName = instance.Name
+				policy.Spec.VhostRef = vhostRef
+				policy.Spec.Name = streamsPolicyName
+				policy.Spec.Pattern = ".*"
+				policy.Spec.Definition = apiextensionsv1.JSON{Raw: definitionJSON}
+				// Higher than the ha-all policy of Mirrored, RabbitMQ applies a single policy
+				policy.Spec.Priority = 1
+				policy.Spec.ApplyTo = "streams"
+				return controllerutil.SetControllerReference(instance, policy, helper.GetScheme())
+			})
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	policyList := &rabbitmqv1beta1.RabbitMQPolicyList{}
+	if err := r.List(ctx, policyList, client.InNamespace(instance.Namespace),
+		client.MatchingLabels{streamsPolicyLabel: instance.Name}); err != nil {
+		return ctrl.Result{}, err
+	}
+	for i := range policyList.Items {
+		policy := &policyList.Items[i]
+		if desired[policy.Name] {
+			continue
+		}
+		Log.Info("Deleting retention policy of streams", "policy", policy.Name)
+		if err := r.Delete(ctx, policy); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// findVhostCR returns the name of the RabbitMQVhost of the vhost in the cluster, empty if there is none
+func findVhostCR(vhosts []rabbitmqv1beta1.RabbitMQVhost, clusterName, vhost string) string {
+	for _, v := range vhosts {
+		if v.Spec.RabbitmqClusterName == clusterName && v.Spec.Name == vhost && v.DeletionTimestamp.IsZero() {
+			return v.Name
+		}
+	}
+	return ""
+}
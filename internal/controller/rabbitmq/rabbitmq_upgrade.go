@@ -0,0 +1,507 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	rabbitmqv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	rabbitmqv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	rabbitmqapi "github.com/openstack-k8s-operators/infra-operator/pkg/rabbitmq/api"
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+)
+
+const (
+	// upgradeInterval - how often the upgrade is checked while waiting for the checks or the nodes
+	upgradeInterval = 10 * time.Second
+	// upgradeNodeTimeout - how long a restarted node has to run the new image before the upgrade
+	// gets paused
+	upgradeNodeTimeout = 15 * time.Minute
+)
+
+// versionRegexp matches the major and minor version at the start of an image tag or a RabbitMQ
+// version, e.g. 4.1 in 4.1.0-management
+var versionRegexp = regexp.MustCompile(`^v?(\d+)\.(\d+)(\.\d+)?`)
+
+// holdPodsForUpgrade keeps the pods of the cluster on the previous container image while an
+// upgrade is in progress. The new image is rolled out by the partition of the StatefulSet, which
+// reconcileUpgrade lowers one pod at a time once the checks pass. Without the partition the
+// StatefulSet would restart all pods without any checks as soon as the image changes. Restoring
+// the previous image only cancels the upgrade until the first pod got restarted, afterwards the
+// upgrade gets paused. A paused upgrade is resumed with the UpgradeResumeAnnotation.
+func (r *Reconciler) holdPodsForUpgrade(
+	ctx context.Context,
+	instance *rabbitmqv1beta1.RabbitMq,
+	rabbitmqCluster *rabbitmqv2.RabbitmqCluster,
+) error {
+	Log := r.GetLogger(ctx)
+
+	current := &rabbitmqv2.RabbitmqCluster{}
+	err := r.Get(ctx, types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, current)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	replicas := ptr.Deref(current.Spec.Replicas, 1)
+	desiredImage := rabbitmqCluster.Spec.Image
+
+	upgrade := instance.Status.Upgrade
+	if upgrade == nil {
+		delete(instance.Annotations, rabbitmqv1beta1.UpgradeResumeAnnotation)
+		if current.Spec.Image == "" || current.Spec.Image == desiredImage || replicas == 0 {
+			return nil
+		}
+		Log.Info("Container image changed. Starting upgrade", "from", current.Spec.Image, "to", desiredImage)
+		upgrade = &rabbitmqv1beta1.RabbitMqUpgrade{
+			FromImage: current.Spec.Image,
+			ToImage:   desiredImage,
+			ToVersion: imageVersion(desiredImage),
+			Phase:     rabbitmqv1beta1.UpgradePhasePreflightChecks,
+			Partition: replicas,
+			StartTime: &metav1.Time{Time: time.Now()},
+		}
+		instance.Status.Upgrade = upgrade
+	} else if upgrade.ToImage != desiredImage {
+		// LastRestartTime is kept when the upgrade starts over, restarted pods keep the image
+		// they got until the partition gets lowered again
+		restarted := upgrade.Partition < replicas || upgrade.LastRestartTime != nil
+		if desiredImage == upgrade.FromImage && restarted {
+			// The StatefulSet would roll back all restarted pods at once, and RabbitMQ doesn't
+			// support downgrades. The restarted pods keep the new image until it gets restored.
+			message := fmt.Sprintf("restoring %s is not supported once nodes got restarted, set %s again and resume the upgrade",
+				desiredImage, upgrade.ToImage)
+			if upgrade.Phase != rabbitmqv1beta1.UpgradePhasePaused || upgrade.Message != message {
+				Log.Info("Container image restored after nodes got restarted. Pausing upgrade", "image", desiredImage, "partition", upgrade.Partition)
+			}
+			upgrade.Phase = rabbitmqv1beta1.UpgradePhasePaused
+			upgrade.Message = message
+			rabbitmqCluster.Spec.Image = upgrade.ToImage
+		} else if desiredImage == upgrade.FromImage {
+			Log.Info("Container image restored. Cancelling upgrade", "image", desiredImage)
+			instance.Status.Upgrade = nil
+			instance.Status.Conditions.Remove(rabbitmqv1beta1.UpgradeReadyCondition)
+			return nil
+		} else {
+			// Pods which already run the previous new image are kept until the checks pass again
+			Log.Info("Container image changed during the upgrade. Restarting upgrade", "from", upgrade.FromImage, "to", desiredImage)
+			upgrade.ToImage = desiredImage
+			upgrade.ToVersion = imageVersion(desiredImage)
+			upgrade.Phase = rabbitmqv1beta1.UpgradePhasePreflightChecks
+			upgrade.Message = ""
+			upgrade.Partition = replicas
+		}
+	} else if _, ok := instance.Annotations[rabbitmqv1beta1.UpgradeResumeAnnotation]; ok {
+		delete(instance.Annotations, rabbitmqv1beta1.UpgradeResumeAnnotation)
+		if upgrade.Phase == rabbitmqv1beta1.UpgradePhasePaused {
+			// Restarted nodes get the timeout again to come back with the new image
+			Log.Info("Resuming upgrade", "image", upgrade.ToImage, "partition", upgrade.Partition)
+			upgrade.Phase = rabbitmqv1beta1.UpgradePhasePreflightChecks
+			if upgrade.Partition < replicas {
+				upgrade.Phase = rabbitmqv1beta1.UpgradePhaseRollingRestart
+				upgrade.LastRestartTime = &metav1.Time{Time: time.Now()}
+			}
+			upgrade.Message = ""
+		}
+	}
+
+	if rabbitmqCluster.Spec.Override.StatefulSet.Spec == nil {
+		rabbitmqCluster.Spec.Override.StatefulSet.Spec = &rabbitmqv2.StatefulSetSpec{}
+	}
+	rabbitmqCluster.Spec.Override.StatefulSet.Spec.UpdateStrategy = &appsv1.StatefulSetUpdateStrategy{
+		Type: appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+			Partition: ptr.To(upgrade.Partition),
+		},
+	}
+	return nil
+}
+
+// reconcileUpgrade upgrades the cluster to a new container image. Before the first node gets
+// restarted it checks that all nodes run the same RabbitMQ version, that the new version is a
+// supported upgrade, that all stable feature flags are enabled and that no alarms are in effect.
+// The nodes are then restarted one at a time, each only once the previously restarted nodes run
+// the new image and the node-is-quorum-critical health check of the node passes. Incompatible
+// versions and nodes which don't come back pause the upgrade. Each step is reported in the
+// UpgradeReady condition.
+func (r *Reconciler) reconcileUpgrade(
+	ctx context.Context,
+	instance *rabbitmqv1beta1.RabbitMq,
+	helper *helper.Helper,
+	rabbit *rabbitmqv2.RabbitmqCluster,
+	clusterReady bool,
+) (ctrl.Result, error) {
+	upgrade := instance.Status.Upgrade
+	if upgrade == nil {
+		return ctrl.Result{}, nil
+	}
+
+	switch upgrade.Phase {
+	case rabbitmqv1beta1.UpgradePhasePaused:
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			rabbitmqv1beta1.UpgradeReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			rabbitmqv1beta1.UpgradePausedMessage,
+			upgrade.ToImage,
+			upgrade.Message))
+		return ctrl.Result{}, nil
+	case rabbitmqv1beta1.UpgradePhasePreflightChecks:
+		return r.upgradePreflightChecks(ctx, instance, helper, rabbit, clusterReady)
+	}
+	return r.upgradeRollingRestart(ctx, instance, helper, rabbit)
+}
+
+// upgradePreflightChecks runs the checks before the first node gets restarted. Checks which can
+// pass later, like feature flags which get enabled by reconcileFeatureFlags, are retried.
+func (r *Reconciler) upgradePreflightChecks(
+	ctx context.Context,
+	instance *rabbitmqv1beta1.RabbitMq,
+	helper *helper.Helper,
+	rabbit *rabbitmqv2.RabbitmqCluster,
+	clusterReady bool,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+	upgrade := instance.Status.Upgrade
+
+	if !clusterReady {
+		return r.upgradeWaiting(instance, "the cluster is not ready"), nil
+	}
+	if instance.Status.ScaleDown != nil {
+		return r.upgradeWaiting(instance, "a scale down is in progress"), nil
+	}
+
+	apiClient, err := getDefaultUserAPIClient(ctx, helper, rabbit)
+	if err != nil {
+		return ctrl.Result{}, r.upgradeError(instance, err)
+	}
+	nodes, err := apiClient.ListNodes(ctx)
+	if err != nil {
+		return ctrl.Result{}, r.upgradeError(instance, err)
+	}
+	upgrade.Nodes = nodeVersions(nodes)
+
+	version, ok := clusterVersion(nodes, ptr.Deref(rabbit.Spec.Replicas, 1))
+	if !ok {
+		return r.upgradeWaiting(instance, "not all nodes are running the same RabbitMQ version"), nil
+	}
+	upgrade.FromVersion = version
+
+	// Tags with another major version are rather product versions, like 18.0 of the OpenStack
+	// images, the new version is then taken from the first restarted node
+	if fromMajor, _, _ := parseVersion(upgrade.FromVersion); upgrade.ToVersion != "" {
+		if toMajor, _, _ := parseVersion(upgrade.ToVersion); toMajor != fromMajor {
+			upgrade.ToVersion = ""
+		}
+	}
+	if upgrade.ToVersion != "" {
+		if err := checkUpgradeVersion(upgrade.FromVersion, upgrade.ToVersion); err != nil {
+			r.upgradePause(ctx, instance, err.Error())
+			return ctrl.Result{}, nil
+		}
+	}
+
+	flags, err := apiClient.ListFeatureFlags(ctx)
+	if err != nil {
+		return ctrl.Result{}, r.upgradeError(instance, err)
+	}
+	if pending := pendingFeatureFlags(flags); len(pending) > 0 {
+		return r.upgradeWaiting(instance, "stable feature flags are not enabled yet: "+strings.Join(pending, ", ")), nil
+	}
+
+	if alarms := instance.GetAlarms(); len(alarms) > 0 {
+		return r.upgradeWaiting(instance, "alarms are in effect: "+strings.Join(alarms, ", ")), nil
+	}
+
+	Log.Info("Pre-flight checks passed. Starting rolling restart", "from", upgrade.FromVersion, "to", upgrade.ToImage)
+	upgrade.Phase = rabbitmqv1beta1.UpgradePhaseRollingRestart
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// upgradeRollingRestart restarts the next node once all previously restarted nodes run the new
+// image. The partition of the StatefulSet gets lowered by the next reconcile.
+func (r *Reconciler) upgradeRollingRestart(
+	ctx context.Context,
+	instance *rabbitmqv1beta1.RabbitMq,
+	helper *helper.Helper,
+	rabbit *rabbitmqv2.RabbitmqCluster,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+	upgrade := instance.Status.Upgrade
+
+	replicas := ptr.Deref(rabbit.Spec.Replicas, 1)
+	if upgrade.Partition > replicas {
+		upgrade.Partition = replicas
+	}
+
+	sts := &appsv1.StatefulSet{}
+	err := r.Get(ctx, types.NamespacedName{Name: instance.Name + "-server", Namespace: instance.Namespace}, sts)
+	if err != nil {
+		return ctrl.Result{}, r.upgradeError(instance, err)
+	}
+
+	apiClient, err := getDefaultUserAPIClient(ctx, helper, rabbit)
+	if err != nil {
+		return ctrl.Result{}, r.upgradeError(instance, err)
+	}
+	nodes, err := apiClient.ListNodes(ctx)
+	if err != nil {
+		return ctrl.Result{}, r.upgradeError(instance, err)
+	}
+	upgrade.Nodes = nodeVersions(nodes)
+
+	for ordinal := upgrade.Partition; ordinal < replicas; ordinal++ {
+		podName := fmt.Sprintf("%s-server-%d", instance.Name, ordinal)
+		version, restarted, err := r.upgradedNodeVersion(ctx, instance, sts, nodes, ordinal)
+		if err != nil {
+			return ctrl.Result{}, r.upgradeError(instance, err)
+		}
+		if !restarted {
+			if upgrade.LastRestartTime != nil && time.Since(upgrade.LastRestartTime.Time) > upgradeNodeTimeout {
+				r.upgradePause(ctx, instance, fmt.Sprintf("%s is not running the new image after %s", podName, upgradeNodeTimeout))
+				return ctrl.Result{}, nil
+			}
+			Log.Info("Waiting for node to run the new image", "pod", podName)
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1beta1.UpgradeReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				rabbitmqv1beta1.UpgradeRestartingMessage,
+				upgrade.ToImage,
+				podName))
+			return ctrl.Result{RequeueAfter: upgradeInterval}, nil
+		}
+
+		// Without a version in the image tag the first restarted node tells the new version
+		if upgrade.ToVersion == "" {
+			upgrade.ToVersion = version
+		}
+		if version != upgrade.ToVersion && !strings.HasPrefix(version, upgrade.ToVersion+".") {
+			r.upgradePause(ctx, instance, fmt.Sprintf("%s runs RabbitMQ %s instead of %s", podName, version, upgrade.ToVersion))
+			return ctrl.Result{}, nil
+		}
+		if err := checkUpgradeVersion(upgrade.FromVersion, version); err != nil {
+			r.upgradePause(ctx, instance, err.Error())
+			return ctrl.Result{}, nil
+		}
+	}
+
+	if upgrade.Partition == 0 {
+		Log.Info("Upgrade completed", "image", upgrade.ToImage, "version", upgrade.ToVersion)
+		instance.Status.Upgrade = nil
+		instance.Status.Conditions.MarkTrue(rabbitmqv1beta1.UpgradeReadyCondition, rabbitmqv1beta1.UpgradeReadyMessage)
+		return ctrl.Result{}, nil
+	}
+
+	next := upgrade.Partition - 1
+	podName := fmt.Sprintf("%s-server-%d", instance.Name, next)
+	nodeClient, err := r.nodeAPIClient(ctx, helper, rabbit, podName)
+	if err != nil {
+		return ctrl.Result{}, r.upgradeError(instance, err)
+	}
+	result, err := nodeClient.CheckNodeIsQuorumCritical(ctx)
+	if err != nil {
+		return ctrl.Result{}, r.upgradeError(instance, err)
+	}
+	if !result.OK() {
+		queues := []string{}
+		for _, queue := range result.Queues {
+			queues = append(queues, fmt.Sprintf("%s/%s", queue.Vhost, queue.Name))
+		}
+		Log.Info("Node is quorum critical, waiting", "pod", podName, "queues", queues)
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			rabbitmqv1beta1.UpgradeReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityWarning,
+			rabbitmqv1beta1.UpgradeQuorumCriticalMessage,
+			upgrade.ToImage,
+			podName,
+			strings.Join(queues, ", ")))
+		return ctrl.Result{RequeueAfter: upgradeInterval}, nil
+	}
+
+	Log.Info("Restarting node with the new image", "pod", podName, "image", upgrade.ToImage)
+	instance.Status.Conditions.Set(condition.FalseCondition(
+		rabbitmqv1beta1.UpgradeReadyCondition,
+		condition.RequestedReason,
+		condition.SeverityInfo,
+		rabbitmqv1beta1.UpgradeRestartingMessage,
+		upgrade.ToImage,
+		podName))
+	upgrade.Partition = next
+	upgrade.LastRestartTime = &metav1.Time{Time: time.Now()}
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// upgradedNodeVersion returns the RabbitMQ version of the node running in the pod with the
+// ordinal, and whether the pod runs the update revision of the StatefulSet, is ready and its
+// node is running
+func (r *Reconciler) upgradedNodeVersion(
+	ctx context.Context,
+	instance *rabbitmqv1beta1.RabbitMq,
+	sts *appsv1.StatefulSet,
+	nodes []rabbitmqapi.Node,
+	ordinal int32,
+) (string, bool, error) {
+	pod := &corev1.Pod{}
+	err := r.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("%s-server-%d", instance.Name, ordinal), Namespace: instance.Namespace}, pod)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	if pod.Labels[appsv1.ControllerRevisionHashLabelKey] != sts.Status.UpdateRevision || !isPodReady(pod) {
+		return "", false, nil
+	}
+
+	nodeName := rabbitmqNodeName(instance, ordinal)
+	for _, node := range nodes {
+		if node.Name == nodeName && node.Running {
+			version := node.RabbitMQVersion()
+			return version, version != "", nil
+		}
+	}
+	return "", false, nil
+}
+
+// isPodReady returns true if the Ready condition of the pod is True
+func isPodReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// upgradeWaiting reports a pre-flight check which didn't pass yet in the UpgradeReady condition
+func (r *Reconciler) upgradeWaiting(instance *rabbitmqv1beta1.RabbitMq, reason string) ctrl.Result {
+	instance.Status.Conditions.Set(condition.FalseCondition(
+		rabbitmqv1beta1.UpgradeReadyCondition,
+		condition.RequestedReason,
+		condition.SeverityInfo,
+		rabbitmqv1beta1.UpgradePreflightMessage,
+		instance.Status.Upgrade.ToImage,
+		reason))
+	return ctrl.Result{RequeueAfter: upgradeInterval}
+}
+
+// upgradePause pauses the upgrade, no further nodes get restarted until the ContainerImage changes
+// or the upgrade gets resumed with the UpgradeResumeAnnotation
+func (r *Reconciler) upgradePause(ctx context.Context, instance *rabbitmqv1beta1.RabbitMq, reason string) {
+	upgrade := instance.Status.Upgrade
+	r.GetLogger(ctx).Info("Pausing upgrade", "image", upgrade.ToImage, "reason", reason)
+	upgrade.Phase = rabbitmqv1beta1.UpgradePhasePaused
+	upgrade.Message = reason
+	instance.Status.Conditions.Set(condition.FalseCondition(
+		rabbitmqv1beta1.UpgradeReadyCondition,
+		condition.ErrorReason,
+		condition.SeverityWarning,
+		rabbitmqv1beta1.UpgradePausedMessage,
+		upgrade.ToImage,
+		reason))
+}
+
+// upgradeError reports an error of the upgrade in the UpgradeReady condition
+func (r *Reconciler) upgradeError(instance *rabbitmqv1beta1.RabbitMq, err error) error {
+	instance.Status.Conditions.Set(condition.FalseCondition(
+		rabbitmqv1beta1.UpgradeReadyCondition,
+		condition.ErrorReason,
+		condition.SeverityWarning,
+		rabbitmqv1beta1.UpgradeErrorMessage,
+		err.Error()))
+	return err
+}
+
+// nodeVersions returns the RabbitMQ version of each node sorted by node name
+func nodeVersions(nodes []rabbitmqapi.Node) []rabbitmqv1beta1.RabbitMqNodeVersion {
+	versions := []rabbitmqv1beta1.RabbitMqNodeVersion{}
+	for _, node := range nodes {
+		version := ""
+		if node.Running {
+			version = node.RabbitMQVersion()
+		}
+		versions = append(versions, rabbitmqv1beta1.RabbitMqNodeVersion{Name: node.Name, Version: version})
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Name < versions[j].Name })
+	return versions
+}
+
+// imageVersion returns the RabbitMQ version in the tag of the image, empty if the tag doesn't
+// start with a version like 4.1.0 or 4.1.0-management
+func imageVersion(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	name := image[strings.LastIndex(image, "/")+1:]
+	_, tag, found := strings.Cut(name, ":")
+	if !found {
+		return ""
+	}
+	match := versionRegexp.FindString(tag)
+	return strings.TrimPrefix(match, "v")
+}
+
+// checkUpgradeVersion returns an error unless upgrading from the version to the other one is
+// supported: a patch release of the same minor version or the next minor version of the same
+// major version. Downgrades and skipping minor versions are not supported by RabbitMQ.
+func checkUpgradeVersion(from, to string) error {
+	fromMajor, fromMinor, ok := parseVersion(from)
+	if !ok {
+		return fmt.Errorf("unknown RabbitMQ version %q", from)
+	}
+	toMajor, toMinor, ok := parseVersion(to)
+	if !ok {
+		return fmt.Errorf("unknown RabbitMQ version %q", to)
+	}
+	if toMajor != fromMajor || toMinor < fromMinor || toMinor > fromMinor+1 {
+		return fmt.Errorf("upgrading RabbitMQ from %s to %s is not supported, only patch releases and the next minor version are", from, to)
+	}
+	return nil
+}
+
+// parseVersion returns the major and minor version of a RabbitMQ version
+func parseVersion(version string) (int, int, bool) {
+	match := versionRegexp.FindStringSubmatch(version)
+	if match == nil {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err := strconv.Atoi(match[2])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
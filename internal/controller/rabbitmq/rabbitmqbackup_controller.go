@@ -0,0 +1,464 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	oko_secret "github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+	rabbitmqclusterv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// backupNameLabel - label on the Secrets and Jobs of a RabbitMQBackup
+	backupNameLabel = "rabbitmqbackup.openstack.org/name"
+
+	// backupTimeFormat - format of the timestamp suffix of the backup names
+	backupTimeFormat = "20060102150405"
+
+	// backupMountPath - where the PersistentVolumeClaim of the backups is mounted in the Jobs
+	backupMountPath = "/var/lib/rabbitmq-backups"
+
+	// backupDefinitionsMountPath - where the definitions of a backup are mounted in the backup Job
+	backupDefinitionsMountPath = "/var/lib/rabbitmq-definitions"
+)
+
+// RabbitMQBackupReconciler reconciles a RabbitMQBackup object
+//
+//nolint:revive
+type RabbitMQBackupReconciler struct {
+	client.Client
+	Kclient kubernetes.Interface
+	Scheme  *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqbackups,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqbackups/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqbackups/finalizers,verbs=update
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=rabbitmq.com,resources=rabbitmqclusters,verbs=get;list;watch
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile reconciles a RabbitMQBackup object
+func (r *RabbitMQBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	Log := log.FromContext(ctx)
+
+	instance := &rabbitmqv1.RabbitMQBackup{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	h, _ := helper.NewHelper(instance, r.Client, r.Kclient, r.Scheme, Log)
+
+	// Save a copy of the conditions so that we can restore the LastTransitionTime
+	// when a condition's state doesn't change
+	savedConditions := instance.Status.Conditions.DeepCopy()
+
+	// Initialize status conditions
+	cl := condition.CreateList(
+		condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
+		condition.UnknownCondition(rabbitmqv1.RabbitMQBackupReadyCondition, condition.InitReason, rabbitmqv1.RabbitMQBackupReadyInitMessage),
+	)
+	instance.Status.Conditions.Init(&cl)
+	instance.Status.ObservedGeneration = instance.Generation
+
+	defer func() {
+		// Restore condition timestamps if they haven't changed
+		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
+
+		if instance.Status.Conditions.IsUnknown(condition.ReadyCondition) {
+			instance.Status.Conditions.Set(instance.Status.Conditions.Mirror(condition.ReadyCondition))
+		}
+		if err := h.PatchInstance(ctx, instance); err != nil {
+			Log.Error(err, "Failed to patch instance")
+		}
+	}()
+
+	// The backup Secrets and Jobs are owned by the instance and get garbage
+	// collected, the files on a PersistentVolumeClaim are kept
+	if !instance.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	return r.reconcileNormal(ctx, instance, h)
+}
+
+func (r *RabbitMQBackupReconciler) reconcileNormal(ctx context.Context, instance *rabbitmqv1.RabbitMQBackup, h *helper.Helper) (ctrl.Result, error) {
+	// A backup written to a PersistentVolumeClaim is in progress until its Job completed
+	if latest := r.latestBackup(instance); latest != nil && !instance.Status.IsComplete(latest) {
+		done, err := r.checkBackupJob(ctx, instance, latest)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQBackupReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQBackupReadyErrorMessage, err.Error()))
+			return ctrl.Result{}, err
+		}
+		if !done {
+			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQBackupReadyCondition, condition.RequestedReason, condition.SeverityInfo, rabbitmqv1.RabbitMQBackupReadyRunningMessage, latest.Name))
+			return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+		}
+		instance.Status.LastBackupTime = latest.Time.DeepCopy()
+	}
+
+	// Retention might have been reduced since the last backup
+	if err := r.pruneBackups(ctx, instance); err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQBackupReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQBackupReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Check if a backup is due
+	now := time.Now().UTC()
+	if instance.Spec.Schedule == "" {
+		instance.Status.NextBackupTime = nil
+		if instance.Status.LastBackupTime != nil {
+			return r.markReady(instance, ctrl.Result{})
+		}
+	} else {
+		last := instance.CreationTimestamp.Time
+		if instance.Status.LastBackupTime != nil {
+			last = instance.Status.LastBackupTime.Time
+		}
+		next, err := instance.Spec.NextBackup(last)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQBackupReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQBackupReadyErrorMessage, err.Error()))
+			return ctrl.Result{}, err
+		}
+		instance.Status.NextBackupTime = &metav1.Time{Time: next}
+		if now.Before(next) {
+			return r.markReady(instance, ctrl.Result{RequeueAfter: next.Sub(now)})
+		}
+	}
+
+	// Get the RabbitMQ cluster
+	rabbit := &rabbitmqclusterv2.RabbitmqCluster{}
+	err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.RabbitmqClusterName, Namespace: instance.Namespace}, rabbit)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQBackupReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQBackupReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Check if cluster is ready for operations
+	if readinessErr := checkClusterReadiness(rabbit); readinessErr != nil {
+		if readinessErr.IsWaiting {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1.RabbitMQBackupReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				"RabbitMQ backup waiting for dependencies %s",
+				readinessErr.Reason))
+			log.FromContext(ctx).Info("Waiting for RabbitMQ cluster to be ready", "cluster", instance.Spec.RabbitmqClusterName)
+		} else {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1.RabbitMQBackupReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				rabbitmqv1.RabbitMQBackupReadyErrorMessage,
+				readinessErr.Reason))
+		}
+		return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+	}
+
+	// Get admin credentials
+	rabbitSecret, _, err := oko_secret.GetSecret(ctx, h, rabbit.Status.DefaultUser.SecretReference.Name, instance.Namespace)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQBackupReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQBackupReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Create API client
+	apiClient, err := getAPIClient(ctx, h, rabbit, rabbitSecret)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQBackupReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQBackupReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	definitions, err := apiClient.GetDefinitions(ctx)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQBackupReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQBackupReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	backup := rabbitmqv1.RabbitMQBackupEntry{
+		Name: fmt.Sprintf("%s-%s", instance.Name, now.Format(backupTimeFormat)),
+		Time: metav1.Time{Time: now},
+	}
+
+	if instance.Spec.Storage.Type == rabbitmqv1.RabbitMQBackupStoragePVC {
+		err = r.createBackupJob(ctx, instance, backup.Name, definitions)
+	} else {
+		err = r.createBackupSecret(ctx, instance, instance, backup.Name, definitions)
+	}
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQBackupReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQBackupReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	instance.Status.Backups = append(instance.Status.Backups, backup)
+	log.FromContext(ctx).Info("Created RabbitMQ backup", "backup", backup.Name)
+
+	if instance.Spec.Storage.Type == rabbitmqv1.RabbitMQBackupStoragePVC {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQBackupReadyCondition, condition.RequestedReason, condition.SeverityInfo, rabbitmqv1.RabbitMQBackupReadyRunningMessage, backup.Name))
+		return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+	}
+	instance.Status.LastBackupTime = backup.Time.DeepCopy()
+
+	if err := r.pruneBackups(ctx, instance); err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQBackupReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQBackupReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	if instance.Spec.Schedule != "" {
+		next, err := instance.Spec.NextBackup(now)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQBackupReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQBackupReadyErrorMessage, err.Error()))
+			return ctrl.Result{}, err
+		}
+		instance.Status.NextBackupTime = &metav1.Time{Time: next}
+		return r.markReady(instance, ctrl.Result{RequeueAfter: next.Sub(now)})
+	}
+
+	return r.markReady(instance, ctrl.Result{})
+}
+
+// markReady marks the backup ready and returns the given result
+func (r *RabbitMQBackupReconciler) markReady(instance *rabbitmqv1.RabbitMQBackup, result ctrl.Result) (ctrl.Result, error) {
+	instance.Status.Conditions.MarkTrue(rabbitmqv1.RabbitMQBackupReadyCondition, rabbitmqv1.RabbitMQBackupReadyMessage)
+	instance.Status.Conditions.MarkTrue(condition.ReadyCondition, condition.ReadyMessage)
+	return result, nil
+}
+
+// latestBackup returns the latest backup, completed or not, or nil if there are no backups
+func (r *RabbitMQBackupReconciler) latestBackup(instance *rabbitmqv1.RabbitMQBackup) *rabbitmqv1.RabbitMQBackupEntry {
+	if len(instance.Status.Backups) == 0 {
+		return nil
+	}
+	return &instance.Status.Backups[len(instance.Status.Backups)-1]
+}
+
+// pruneBackups deletes the oldest backups beyond the retention. Files on a
+// PersistentVolumeClaim are pruned by the backup Jobs.
+func (r *RabbitMQBackupReconciler) pruneBackups(ctx context.Context, instance *rabbitmqv1.RabbitMQBackup) error {
+	retention := int(max(instance.Spec.Retention, 1))
+	for len(instance.Status.Backups) > retention {
+		oldest := instance.Status.Backups[0]
+		if instance.Spec.Storage.Type != rabbitmqv1.RabbitMQBackupStoragePVC {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      oldest.Name,
+					Namespace: instance.Namespace,
+				},
+			}
+			if err := r.Delete(ctx, secret); err != nil && !k8s_errors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete backup %s: %w", oldest.Name, err)
+			}
+		}
+		instance.Status.Backups = instance.Status.Backups[1:]
+		log.FromContext(ctx).Info("Deleted RabbitMQ backup", "backup", oldest.Name)
+	}
+	return nil
+}
+
+// createBackupSecret stores the definitions of a backup in a Secret owned by owner
+func (r *RabbitMQBackupReconciler) createBackupSecret(ctx context.Context, instance *rabbitmqv1.RabbitMQBackup, owner client.Object, name string, definitions []byte) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: instance.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		secret.Labels = map[string]string{backupNameLabel: instance.Name}
+		secret.Data = map[string][]byte{
+			rabbitmqv1.RabbitMQBackupDefinitionsKey: definitions,
+		}
+		return controllerutil.SetControllerReference(owner, secret, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create backup secret %s: %w", name, err)
+	}
+	return nil
+}
+
+// createBackupJob creates a Job which writes the definitions of a backup to the
+// PersistentVolumeClaim and deletes the files beyond the retention. The definitions
+// get passed in a Secret owned by the Job.
+func (r *RabbitMQBackupReconciler) createBackupJob(ctx context.Context, instance *rabbitmqv1.RabbitMQBackup, name string, definitions []byte) error {
+	image, err := getBackupImage(ctx, r.Client, instance.Namespace, instance.Spec.RabbitmqClusterName)
+	if err != nil {
+		return err
+	}
+
+	// Keep the files of this backup only, the names end with the timestamp
+	pattern := fmt.Sprintf("%s-%s.json", instance.Name, strings.Repeat("[0-9]", len(backupTimeFormat)))
+	script := fmt.Sprintf(
+		"set -e; cp %[1]s/%[2]s %[3]s/%[4]s.json.tmp; mv %[3]s/%[4]s.json.tmp %[3]s/%[4]s.json; "+
+			"cd %[3]s; ls -1 %[5]s | sort -r | tail -n +%[6]d | xargs -r rm -f",
+		backupDefinitionsMountPath, rabbitmqv1.RabbitMQBackupDefinitionsKey, backupMountPath, name, pattern, max(instance.Spec.Retention, 1)+1)
+
+	job := backupJob(instance.Namespace, name, instance.Name, image, instance.Spec.Storage.ClaimName, script)
+	job.Spec.Template.Spec.Volumes = append(job.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "definitions",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: name},
+		},
+	})
+	job.Spec.Template.Spec.Containers[0].VolumeMounts = append(job.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "definitions",
+		MountPath: backupDefinitionsMountPath,
+		ReadOnly:  true,
+	})
+	if err := controllerutil.SetControllerReference(instance, job, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, job); err != nil && !k8s_errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create backup job %s: %w", name, err)
+	}
+
+	// The pod waits for the Secret to be mounted, it gets deleted together with the Job
+	return r.createBackupSecret(ctx, instance, job, name, definitions)
+}
+
+// checkBackupJob returns true if the Job writing a backup to the PersistentVolumeClaim
+// completed. Completed Jobs get deleted, a failed backup is dropped so it gets retried.
+func (r *RabbitMQBackupReconciler) checkBackupJob(ctx context.Context, instance *rabbitmqv1.RabbitMQBackup, backup *rabbitmqv1.RabbitMQBackupEntry) (bool, error) {
+	job := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{Name: backup.Name, Namespace: instance.Namespace}, job)
+	if k8s_errors.IsNotFound(err) {
+		// Already cleaned up
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	succeeded, failed := jobFinished(job)
+	if !succeeded && !failed {
+		return false, nil
+	}
+	if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !k8s_errors.IsNotFound(err) {
+		return false, err
+	}
+	if failed {
+		instance.Status.Backups = instance.Status.Backups[:len(instance.Status.Backups)-1]
+		return false, fmt.Errorf("backup job %s failed", backup.Name)
+	}
+	return true, nil
+}
+
+// getBackupImage returns the image of the RabbitMq cluster used by the backup and restore
+// Jobs, the image has the shell tools they need
+func getBackupImage(ctx context.Context, c client.Client, namespace, clusterName string) (string, error) {
+	rabbitmq := &rabbitmqv1.RabbitMq{}
+	err := c.Get(ctx, types.NamespacedName{Name: clusterName, Namespace: namespace}, rabbitmq)
+	if k8s_errors.IsNotFound(err) || (err == nil && rabbitmq.Spec.ContainerImage == "") {
+		return rabbitmqv1.RabbitMqContainerImage, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return rabbitmq.Spec.ContainerImage, nil
+}
+
+// backupJob returns a Job running script with the PersistentVolumeClaim of the backups mounted
+func backupJob(namespace, name, backupName, image, claimName, script string) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{backupNameLabel: backupName},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To[int32](2),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{backupNameLabel: backupName},
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:    "backup",
+						Image:   image,
+						Command: []string{"/bin/sh", "-c", script},
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: ptr.To(false),
+							Capabilities: &corev1.Capabilities{
+								Drop: []corev1.Capability{"ALL"},
+							},
+						},
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:      "backups",
+							MountPath: backupMountPath,
+						}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "backups",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// jobFinished returns whether a Job succeeded or failed
+func jobFinished(job *batchv1.Job) (succeeded bool, failed bool) {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			succeeded = true
+		case batchv1.JobFailed:
+			failed = true
+		}
+	}
+	return succeeded, failed
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RabbitMQBackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&rabbitmqv1.RabbitMQBackup{}).
+		Owns(&corev1.Secret{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
@@ -0,0 +1,323 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	rabbitmqapi "github.com/openstack-k8s-operators/infra-operator/pkg/rabbitmq/api"
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	oko_secret "github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+	rabbitmqclusterv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const bindingFinalizer = "rabbitmqbinding.openstack.org/finalizer"
+
+// RabbitMQBindingReconciler reconciles a RabbitMQBinding object
+//
+//nolint:revive
+type RabbitMQBindingReconciler struct {
+	client.Client
+	Kclient kubernetes.Interface
+	Scheme  *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqbindings,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqbindings/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqbindings/finalizers,verbs=update
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqvhosts,verbs=get;list;watch
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=rabbitmq.com,resources=rabbitmqclusters,verbs=get;list;watch
+
+// Reconcile reconciles a RabbitMQBinding object
+func (r *RabbitMQBindingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	Log := log.FromContext(ctx)
+
+	instance := &rabbitmqv1.RabbitMQBinding{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	h, _ := helper.NewHelper(instance, r.Client, r.Kclient, r.Scheme, Log)
+
+	// Save a copy of the conditions so that we can restore the LastTransitionTime
+	// when a condition's state doesn't change
+	savedConditions := instance.Status.Conditions.DeepCopy()
+
+	// Initialize status conditions
+	cl := condition.CreateList(
+		condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
+		condition.UnknownCondition(rabbitmqv1.RabbitMQBindingReadyCondition, condition.InitReason, rabbitmqv1.RabbitMQBindingReadyInitMessage),
+	)
+	instance.Status.Conditions.Init(&cl)
+	instance.Status.ObservedGeneration = instance.Generation
+
+	defer func() {
+		// Restore condition timestamps if they haven't changed
+		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
+
+		if instance.Status.Conditions.IsUnknown(condition.ReadyCondition) {
+			instance.Status.Conditions.Set(instance.Status.Conditions.Mirror(condition.ReadyCondition))
+		}
+		if err := h.PatchInstance(ctx, instance); err != nil {
+			Log.Error(err, "Failed to patch instance")
+		}
+	}()
+
+	// Handle deletion
+	if !instance.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, instance, h)
+	}
+
+	// Add finalizer if not being deleted
+	if controllerutil.AddFinalizer(instance, bindingFinalizer) {
+		// Finalizer was added, update will trigger reconcile
+		return ctrl.Result{}, nil
+	}
+
+	return r.reconcileNormal(ctx, instance, h)
+}
+
+func (r *RabbitMQBindingReconciler) reconcileNormal(ctx context.Context, instance *rabbitmqv1.RabbitMQBinding, h *helper.Helper) (ctrl.Result, error) {
+	// Determine vhost name
+	vhostName := "/"
+	if instance.Spec.VhostRef != "" {
+		vhost := &rabbitmqv1.RabbitMQVhost{}
+		err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.VhostRef, Namespace: instance.Namespace}, vhost)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQBindingReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQBindingReadyErrorMessage, err.Error()))
+			return ctrl.Result{}, err
+		}
+		vhostName = vhost.Spec.Name
+	}
+
+	// Get RabbitMQ cluster
+	rabbit := &rabbitmqclusterv2.RabbitmqCluster{}
+	err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.RabbitmqClusterName, Namespace: instance.Namespace}, rabbit)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQBindingReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQBindingReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Check if cluster is ready for operations
+	if readinessErr := checkClusterReadiness(rabbit); readinessErr != nil {
+		if readinessErr.IsWaiting {
+			// Cluster is starting up - set waiting condition
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1.RabbitMQBindingReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				"RabbitMQ binding waiting for dependencies %s",
+				readinessErr.Reason))
+			log.FromContext(ctx).Info("Waiting for RabbitMQ cluster to be ready", "cluster", instance.Spec.RabbitmqClusterName)
+		} else {
+			// Cluster is being deleted - set error condition
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1.RabbitMQBindingReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				rabbitmqv1.RabbitMQBindingReadyErrorMessage,
+				readinessErr.Reason))
+		}
+		return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+	}
+
+	// Get admin credentials
+	rabbitSecret, _, err := oko_secret.GetSecret(ctx, h, rabbit.Status.DefaultUser.SecretReference.Name, instance.Namespace)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQBindingReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQBindingReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Create API client
+	apiClient, err := getAPIClient(ctx, h, rabbit, rabbitSecret)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQBindingReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQBindingReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	arguments := map[string]interface{}{}
+	if instance.Spec.Arguments != nil {
+		if err := json.Unmarshal(instance.Spec.Arguments.Raw, &arguments); err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQBindingReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQBindingReadyErrorMessage, err.Error()))
+			return ctrl.Result{}, err
+		}
+	}
+
+	// RabbitMQ creates a new binding on every POST, only create the binding if
+	// there is no binding with the same routing key and arguments yet
+	bindings, err := apiClient.ListBindings(ctx, vhostName, instance.Spec.Source, instance.Spec.DestinationType, instance.Spec.Destination)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQBindingReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQBindingReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	if len(matchingBindings(bindings, instance.Spec.RoutingKey, arguments)) == 0 {
+		err = apiClient.CreateBinding(ctx, vhostName, instance.Spec.Source, instance.Spec.DestinationType, instance.Spec.Destination, instance.Spec.RoutingKey, arguments)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQBindingReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQBindingReadyErrorMessage, err.Error()))
+			return ctrl.Result{}, err
+		}
+	}
+
+	instance.Status.Conditions.MarkTrue(rabbitmqv1.RabbitMQBindingReadyCondition, rabbitmqv1.RabbitMQBindingReadyMessage)
+	instance.Status.Conditions.MarkTrue(condition.ReadyCondition, condition.ReadyMessage)
+
+	return ctrl.Result{}, nil
+}
+
+func (r *RabbitMQBindingReconciler) reconcileDelete(ctx context.Context, instance *rabbitmqv1.RabbitMQBinding, h *helper.Helper) (ctrl.Result, error) {
+	vhostName := "/"
+	if instance.Spec.VhostRef != "" {
+		vhost := &rabbitmqv1.RabbitMQVhost{}
+		err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.VhostRef, Namespace: instance.Namespace}, vhost)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			// Log non-NotFound errors but continue with deletion
+			log.FromContext(ctx).Error(err, "Failed to get vhost", "vhost", instance.Spec.VhostRef)
+		}
+		if vhost.Spec.Name != "" {
+			vhostName = vhost.Spec.Name
+		}
+	}
+
+	// Get RabbitMQ cluster
+	rabbit := &rabbitmqclusterv2.RabbitmqCluster{}
+	err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.RabbitmqClusterName, Namespace: instance.Namespace}, rabbit)
+
+	// If cluster is being deleted or not found, skip cleanup and just remove finalizer
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		// Error getting cluster - return error to retry
+		return ctrl.Result{}, err
+	}
+
+	if k8s_errors.IsNotFound(err) || !rabbit.DeletionTimestamp.IsZero() {
+		// Cluster doesn't exist or is being deleted - nothing to clean up
+		controllerutil.RemoveFinalizer(instance, bindingFinalizer)
+		return ctrl.Result{}, nil
+	}
+
+	// Cluster exists and is not being deleted - perform cleanup
+	// Get admin credentials
+	rabbitSecret, _, err := oko_secret.GetSecret(ctx, h, rabbit.Status.DefaultUser.SecretReference.Name, instance.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Create API client
+	apiClient, err := getAPIClient(ctx, h, rabbit, rabbitSecret)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQBindingReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQBindingReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	arguments := map[string]interface{}{}
+	if instance.Spec.Arguments != nil {
+		if err := json.Unmarshal(instance.Spec.Arguments.Raw, &arguments); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Delete the bindings matching the spec from RabbitMQ. If the source or
+	// destination is already gone, RabbitMQ removed the binding with it.
+	bindings, err := apiClient.ListBindings(ctx, vhostName, instance.Spec.Source, instance.Spec.DestinationType, instance.Spec.Destination)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list bindings from RabbitMQ, will retry", "source", instance.Spec.Source, "destination", instance.Spec.Destination, "vhost", vhostName)
+		return ctrl.Result{}, err
+	}
+	for _, binding := range matchingBindings(bindings, instance.Spec.RoutingKey, arguments) {
+		// Note: DeleteBinding already treats 404 as success
+		if err := apiClient.DeleteBinding(ctx, vhostName, instance.Spec.Source, instance.Spec.DestinationType, instance.Spec.Destination, binding.PropertiesKey); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to delete binding from RabbitMQ, will retry", "source", instance.Spec.Source, "destination", instance.Spec.Destination, "vhost", vhostName)
+			return ctrl.Result{}, err
+		}
+	}
+
+	controllerutil.RemoveFinalizer(instance, bindingFinalizer)
+	return ctrl.Result{}, nil
+}
+
+// matchingBindings returns the bindings with the given routing key and arguments
+func matchingBindings(bindings []rabbitmqapi.Binding, routingKey string, arguments map[string]interface{}) []rabbitmqapi.Binding {
+	matches := []rabbitmqapi.Binding{}
+	for _, binding := range bindings {
+		bindingArguments := binding.Arguments
+		if bindingArguments == nil {
+			bindingArguments = map[string]interface{}{}
+		}
+		if binding.RoutingKey == routingKey && reflect.DeepEqual(bindingArguments, arguments) {
+			matches = append(matches, binding)
+		}
+	}
+	return matches
+}
+
+// clusterToBindingMapFunc maps RabbitMQ cluster changes to binding reconciliation requests
+// Works with both RabbitmqCluster (cluster-operator) and RabbitMq CRs
+func (r *RabbitMQBindingReconciler) clusterToBindingMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	clusterName := obj.GetName()
+	clusterNamespace := obj.GetNamespace()
+
+	bindingList := &rabbitmqv1.RabbitMQBindingList{}
+	if err := r.List(ctx, bindingList, client.InNamespace(clusterNamespace)); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list bindings for cluster watch", "cluster", clusterName)
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, binding := range bindingList.Items {
+		// Reconcile bindings that reference this cluster
+		if binding.Spec.RabbitmqClusterName == clusterName {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      binding.Name,
+					Namespace: binding.Namespace,
+				},
+			})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RabbitMQBindingReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&rabbitmqv1.RabbitMQBinding{}).
+		Watches(&rabbitmqclusterv2.RabbitmqCluster{},
+			handler.EnqueueRequestsFromMapFunc(r.clusterToBindingMapFunc)).
+		Watches(&rabbitmqv1.RabbitMq{},
+			handler.EnqueueRequestsFromMapFunc(r.clusterToBindingMapFunc)).
+		Complete(r)
+}
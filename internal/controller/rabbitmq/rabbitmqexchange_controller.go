@@ -0,0 +1,290 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	oko_secret "github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+	rabbitmqclusterv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const exchangeFinalizer = "rabbitmqexchange.openstack.org/finalizer"
+
+// RabbitMQExchangeReconciler reconciles a RabbitMQExchange object
+//
+//nolint:revive
+type RabbitMQExchangeReconciler struct {
+	client.Client
+	Kclient kubernetes.Interface
+	Scheme  *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqexchanges,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqexchanges/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqexchanges/finalizers,verbs=update
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqvhosts,verbs=get;list;watch
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=rabbitmq.com,resources=rabbitmqclusters,verbs=get;list;watch
+
+// Reconcile reconciles a RabbitMQExchange object
+func (r *RabbitMQExchangeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	Log := log.FromContext(ctx)
+
+	instance := &rabbitmqv1.RabbitMQExchange{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	h, _ := helper.NewHelper(instance, r.Client, r.Kclient, r.Scheme, Log)
+
+	// Save a copy of the conditions so that we can restore the LastTransitionTime
+	// when a condition's state doesn't change
+	savedConditions := instance.Status.Conditions.DeepCopy()
+
+	// Initialize status conditions
+	cl := condition.CreateList(
+		condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
+		condition.UnknownCondition(rabbitmqv1.RabbitMQExchangeReadyCondition, condition.InitReason, rabbitmqv1.RabbitMQExchangeReadyInitMessage),
+	)
+	instance.Status.Conditions.Init(&cl)
+	instance.Status.ObservedGeneration = instance.Generation
+
+	defer func() {
+		// Restore condition timestamps if they haven't changed
+		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
+
+		if instance.Status.Conditions.IsUnknown(condition.ReadyCondition) {
+			instance.Status.Conditions.Set(instance.Status.Conditions.Mirror(condition.ReadyCondition))
+		}
+		if err := h.PatchInstance(ctx, instance); err != nil {
+			Log.Error(err, "Failed to patch instance")
+		}
+	}()
+
+	// Handle deletion
+	if !instance.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, instance, h)
+	}
+
+	// Add finalizer if not being deleted
+	if controllerutil.AddFinalizer(instance, exchangeFinalizer) {
+		// Finalizer was added, update will trigger reconcile
+		return ctrl.Result{}, nil
+	}
+
+	return r.reconcileNormal(ctx, instance, h)
+}
+
+func (r *RabbitMQExchangeReconciler) reconcileNormal(ctx context.Context, instance *rabbitmqv1.RabbitMQExchange, h *helper.Helper) (ctrl.Result, error) {
+	// Exchange name is defaulted by webhook
+	exchangeName := instance.Spec.Name
+
+	// Determine vhost name
+	vhostName := "/"
+	if instance.Spec.VhostRef != "" {
+		vhost := &rabbitmqv1.RabbitMQVhost{}
+		err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.VhostRef, Namespace: instance.Namespace}, vhost)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQExchangeReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQExchangeReadyErrorMessage, err.Error()))
+			return ctrl.Result{}, err
+		}
+		vhostName = vhost.Spec.Name
+	}
+
+	// Get RabbitMQ cluster
+	rabbit := &rabbitmqclusterv2.RabbitmqCluster{}
+	err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.RabbitmqClusterName, Namespace: instance.Namespace}, rabbit)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQExchangeReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQExchangeReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Check if cluster is ready for operations
+	if readinessErr := checkClusterReadiness(rabbit); readinessErr != nil {
+		if readinessErr.IsWaiting {
+			// Cluster is starting up - set waiting condition
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1.RabbitMQExchangeReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				"RabbitMQ exchange waiting for dependencies %s",
+				readinessErr.Reason))
+			log.FromContext(ctx).Info("Waiting for RabbitMQ cluster to be ready", "cluster", instance.Spec.RabbitmqClusterName)
+		} else {
+			// Cluster is being deleted - set error condition
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1.RabbitMQExchangeReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				rabbitmqv1.RabbitMQExchangeReadyErrorMessage,
+				readinessErr.Reason))
+		}
+		return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+	}
+
+	// Get admin credentials
+	rabbitSecret, _, err := oko_secret.GetSecret(ctx, h, rabbit.Status.DefaultUser.SecretReference.Name, instance.Namespace)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQExchangeReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQExchangeReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Create API client
+	apiClient, err := getAPIClient(ctx, h, rabbit, rabbitSecret)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQExchangeReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQExchangeReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Declare the exchange
+	arguments := map[string]interface{}{}
+	if instance.Spec.Arguments != nil {
+		if err := json.Unmarshal(instance.Spec.Arguments.Raw, &arguments); err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQExchangeReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQExchangeReadyErrorMessage, err.Error()))
+			return ctrl.Result{}, err
+		}
+	}
+	err = apiClient.CreateOrUpdateExchange(ctx, vhostName, exchangeName, instance.Spec.Type, instance.Spec.Durable, instance.Spec.AutoDelete, instance.Spec.Internal, arguments)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQExchangeReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQExchangeReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	instance.Status.Conditions.MarkTrue(rabbitmqv1.RabbitMQExchangeReadyCondition, rabbitmqv1.RabbitMQExchangeReadyMessage)
+	instance.Status.Conditions.MarkTrue(condition.ReadyCondition, condition.ReadyMessage)
+
+	return ctrl.Result{}, nil
+}
+
+func (r *RabbitMQExchangeReconciler) reconcileDelete(ctx context.Context, instance *rabbitmqv1.RabbitMQExchange, h *helper.Helper) (ctrl.Result, error) {
+	exchangeName := instance.Spec.Name
+	if exchangeName == "" {
+		exchangeName = instance.Name
+	}
+
+	vhostName := "/"
+	if instance.Spec.VhostRef != "" {
+		vhost := &rabbitmqv1.RabbitMQVhost{}
+		err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.VhostRef, Namespace: instance.Namespace}, vhost)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			// Log non-NotFound errors but continue with deletion
+			log.FromContext(ctx).Error(err, "Failed to get vhost", "vhost", instance.Spec.VhostRef)
+		}
+		if vhost.Spec.Name != "" {
+			vhostName = vhost.Spec.Name
+		}
+	}
+
+	// Get RabbitMQ cluster
+	rabbit := &rabbitmqclusterv2.RabbitmqCluster{}
+	err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.RabbitmqClusterName, Namespace: instance.Namespace}, rabbit)
+
+	// If cluster is being deleted or not found, skip cleanup and just remove finalizer
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		// Error getting cluster - return error to retry
+		return ctrl.Result{}, err
+	}
+
+	if k8s_errors.IsNotFound(err) || !rabbit.DeletionTimestamp.IsZero() {
+		// Cluster doesn't exist or is being deleted - nothing to clean up
+		controllerutil.RemoveFinalizer(instance, exchangeFinalizer)
+		return ctrl.Result{}, nil
+	}
+
+	// Cluster exists and is not being deleted - perform cleanup
+	// Get admin credentials
+	rabbitSecret, _, err := oko_secret.GetSecret(ctx, h, rabbit.Status.DefaultUser.SecretReference.Name, instance.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Create API client
+	apiClient, err := getAPIClient(ctx, h, rabbit, rabbitSecret)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQExchangeReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQExchangeReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Delete exchange from RabbitMQ, bindings of the exchange get removed by RabbitMQ
+	// Note: DeleteExchange already treats 404 as success
+	if err := apiClient.DeleteExchange(ctx, vhostName, exchangeName); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to delete exchange from RabbitMQ, will retry", "exchange", exchangeName, "vhost", vhostName)
+		return ctrl.Result{}, err
+	}
+
+	controllerutil.RemoveFinalizer(instance, exchangeFinalizer)
+	return ctrl.Result{}, nil
+}
+
+// clusterToExchangeMapFunc maps RabbitMQ cluster changes to exchange reconciliation requests
+// Works with both RabbitmqCluster (cluster-operator) and RabbitMq CRs
+func (r *RabbitMQExchangeReconciler) clusterToExchangeMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	clusterName := obj.GetName()
+	clusterNamespace := obj.GetNamespace()
+
+	exchangeList := &rabbitmqv1.RabbitMQExchangeList{}
+	if err := r.List(ctx, exchangeList, client.InNamespace(clusterNamespace)); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list exchanges for cluster watch", "cluster", clusterName)
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, exchange := range exchangeList.Items {
+		// Reconcile exchanges that reference this cluster
+		if exchange.Spec.RabbitmqClusterName == clusterName {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      exchange.Name,
+					Namespace: exchange.Namespace,
+				},
+			})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RabbitMQExchangeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&rabbitmqv1.RabbitMQExchange{}).
+		Watches(&rabbitmqclusterv2.RabbitmqCluster{},
+			handler.EnqueueRequestsFromMapFunc(r.clusterToExchangeMapFunc)).
+		Watches(&rabbitmqv1.RabbitMq{},
+			handler.EnqueueRequestsFromMapFunc(r.clusterToExchangeMapFunc)).
+		Complete(r)
+}
@@ -0,0 +1,344 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	rabbitmqapi "github.com/openstack-k8s-operators/infra-operator/pkg/rabbitmq/api"
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	oko_secret "github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+	rabbitmqclusterv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const federationUpstreamFinalizer = "rabbitmqfederationupstream.openstack.org/finalizer"
+
+// RabbitMQFederationUpstreamReconciler reconciles a RabbitMQFederationUpstream object
+//
+//nolint:revive
+type RabbitMQFederationUpstreamReconciler struct {
+	client.Client
+	Kclient kubernetes.Interface
+	Scheme  *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqfederationupstreams,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqfederationupstreams/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqfederationupstreams/finalizers,verbs=update
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqvhosts,verbs=get;list;watch
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqusers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=rabbitmq.com,resources=rabbitmqclusters,verbs=get;list;watch
+
+// Reconcile reconciles a RabbitMQFederationUpstream object
+func (r *RabbitMQFederationUpstreamReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	Log := log.FromContext(ctx)
+
+	instance := &rabbitmqv1.RabbitMQFederationUpstream{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	h, _ := helper.NewHelper(instance, r.Client, r.Kclient, r.Scheme, Log)
+
+	// Save a copy of the conditions so that we can restore the LastTransitionTime
+	// when a condition's state doesn't change
+	savedConditions := instance.Status.Conditions.DeepCopy()
+
+	// Initialize status conditions
+	cl := condition.CreateList(
+		condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
+		condition.UnknownCondition(rabbitmqv1.RabbitMQFederationUpstreamReadyCondition, condition.InitReason, rabbitmqv1.RabbitMQFederationUpstreamReadyInitMessage),
+	)
+	instance.Status.Conditions.Init(&cl)
+	instance.Status.ObservedGeneration = instance.Generation
+
+	defer func() {
+		// Restore condition timestamps if they haven't changed
+		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
+
+		if instance.Status.Conditions.IsUnknown(condition.ReadyCondition) {
+			instance.Status.Conditions.Set(instance.Status.Conditions.Mirror(condition.ReadyCondition))
+		}
+		if err := h.PatchInstance(ctx, instance); err != nil {
+			Log.Error(err, "Failed to patch instance")
+		}
+	}()
+
+	// Handle deletion
+	if !instance.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, instance, h)
+	}
+
+	// Add finalizer if not being deleted
+	if controllerutil.AddFinalizer(instance, federationUpstreamFinalizer) {
+		// Finalizer was added, update will trigger reconcile
+		return ctrl.Result{}, nil
+	}
+
+	return r.reconcileNormal(ctx, instance, h)
+}
+
+func (r *RabbitMQFederationUpstreamReconciler) reconcileNormal(ctx context.Context, instance *rabbitmqv1.RabbitMQFederationUpstream, h *helper.Helper) (ctrl.Result, error) {
+	// Upstream name is defaulted by webhook
+	upstreamName := instance.Spec.Name
+
+	// Determine downstream and upstream vhost names
+	vhostName, err := getVhostName(ctx, r.Client, instance.Namespace, instance.Spec.VhostRef)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQFederationUpstreamReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQFederationUpstreamReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	upstreamVhostName, err := getVhostName(ctx, r.Client, instance.Namespace, instance.Spec.Upstream.VhostRef)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQFederationUpstreamReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQFederationUpstreamReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Get downstream and upstream RabbitMQ clusters, the upstream gets declared in the downstream cluster
+	clusters := map[string]*rabbitmqclusterv2.RabbitmqCluster{}
+	for _, clusterName := range []string{instance.Spec.RabbitmqClusterName, instance.Spec.Upstream.RabbitmqClusterName} {
+		rabbit := &rabbitmqclusterv2.RabbitmqCluster{}
+		err := r.Get(ctx, types.NamespacedName{Name: clusterName, Namespace: instance.Namespace}, rabbit)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQFederationUpstreamReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQFederationUpstreamReadyErrorMessage, err.Error()))
+			return ctrl.Result{}, err
+		}
+
+		// Check if cluster is ready for operations
+		if readinessErr := checkClusterReadiness(rabbit); readinessErr != nil {
+			if readinessErr.IsWaiting {
+				// Cluster is starting up - set waiting condition
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					rabbitmqv1.RabbitMQFederationUpstreamReadyCondition,
+					condition.RequestedReason,
+					condition.SeverityInfo,
+					"RabbitMQ federation upstream waiting for dependencies %s",
+					readinessErr.Reason))
+				log.FromContext(ctx).Info("Waiting for RabbitMQ cluster to be ready", "cluster", clusterName)
+			} else {
+				// Cluster is being deleted - set error condition
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					rabbitmqv1.RabbitMQFederationUpstreamReadyCondition,
+					condition.ErrorReason,
+					condition.SeverityWarning,
+					rabbitmqv1.RabbitMQFederationUpstreamReadyErrorMessage,
+					readinessErr.Reason))
+			}
+			return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+		}
+		clusters[clusterName] = rabbit
+	}
+	rabbit := clusters[instance.Spec.RabbitmqClusterName]
+
+	// The federation links connect to the upstream with their own user, which can only
+	// manage the federation objects and consume from the upstream exchange and queue
+	username, password, ready, err := reconcileLinkUser(ctx, h, instance,
+		instance.Name+"-federation-user", instance.Spec.Upstream.RabbitmqClusterName, instance.Spec.Upstream.VhostRef,
+		"federation-"+instance.Name, federationUpstreamPermissions(instance.Spec))
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQFederationUpstreamReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQFederationUpstreamReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	if !ready {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			rabbitmqv1.RabbitMQFederationUpstreamReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			"RabbitMQ federation upstream waiting for dependencies %s",
+			"user of the federation links"))
+		return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+	}
+
+	// Build the AMQP URIs of the upstream, used by the downstream cluster
+	upstreamURIs, err := getAMQPURIs(ctx, h, rabbit, clusters[instance.Spec.Upstream.RabbitmqClusterName], upstreamVhostName, username, password)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQFederationUpstreamReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQFederationUpstreamReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Get admin credentials
+	rabbitSecret, _, err := oko_secret.GetSecret(ctx, h, rabbit.Status.DefaultUser.SecretReference.Name, instance.Namespace)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQFederationUpstreamReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQFederationUpstreamReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Create API client
+	apiClient, err := getAPIClient(ctx, h, rabbit, rabbitSecret)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQFederationUpstreamReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQFederationUpstreamReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Create or update federation upstream
+	upstream := rabbitmqapi.FederationUpstream{
+		URI:            upstreamURIs,
+		Exchange:       instance.Spec.Exchange,
+		Queue:          instance.Spec.Queue,
+		PrefetchCount:  instance.Spec.PrefetchCount,
+		ReconnectDelay: instance.Spec.ReconnectDelay,
+		AckMode:        instance.Spec.AckMode,
+		MaxHops:        instance.Spec.MaxHops,
+	}
+	err = apiClient.CreateOrUpdateFederationUpstream(ctx, vhostName, upstreamName, upstream)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQFederationUpstreamReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQFederationUpstreamReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	instance.Status.Conditions.MarkTrue(rabbitmqv1.RabbitMQFederationUpstreamReadyCondition, rabbitmqv1.RabbitMQFederationUpstreamReadyMessage)
+	instance.Status.Conditions.MarkTrue(condition.ReadyCondition, condition.ReadyMessage)
+
+	return ctrl.Result{}, nil
+}
+
+func (r *RabbitMQFederationUpstreamReconciler) reconcileDelete(ctx context.Context, instance *rabbitmqv1.RabbitMQFederationUpstream, h *helper.Helper) (ctrl.Result, error) {
+	upstreamName := instance.Spec.Name
+	if upstreamName == "" {
+		upstreamName = instance.Name
+	}
+
+	vhostName := "/"
+	if instance.Spec.VhostRef != "" {
+		vhost := &rabbitmqv1.RabbitMQVhost{}
+		err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.VhostRef, Namespace: instance.Namespace}, vhost)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			// Log non-NotFound errors but continue with deletion
+			log.FromContext(ctx).Error(err, "Failed to get vhost", "vhost", instance.Spec.VhostRef)
+		}
+		if vhost.Spec.Name != "" {
+			vhostName = vhost.Spec.Name
+		}
+	}
+
+	// Get downstream RabbitMQ cluster the upstream is declared in
+	rabbit := &rabbitmqclusterv2.RabbitmqCluster{}
+	err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.RabbitmqClusterName, Namespace: instance.Namespace}, rabbit)
+
+	// If cluster is being deleted or not found, skip cleanup and just remove finalizer
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		// Error getting cluster - return error to retry
+		return ctrl.Result{}, err
+	}
+
+	if k8s_errors.IsNotFound(err) || !rabbit.DeletionTimestamp.IsZero() {
+		// Cluster doesn't exist or is being deleted - nothing to clean up
+		controllerutil.RemoveFinalizer(instance, federationUpstreamFinalizer)
+		return ctrl.Result{}, nil
+	}
+
+	// Cluster exists and is not being deleted - perform cleanup
+	// Get admin credentials
+	rabbitSecret, _, err := oko_secret.GetSecret(ctx, h, rabbit.Status.DefaultUser.SecretReference.Name, instance.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Create API client
+	apiClient, err := getAPIClient(ctx, h, rabbit, rabbitSecret)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQFederationUpstreamReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQFederationUpstreamReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Delete federation upstream from RabbitMQ, federation links using it get stopped
+	// Note: DeleteFederationUpstream already treats 404 as success
+	if err := apiClient.DeleteFederationUpstream(ctx, vhostName, upstreamName); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to delete federation upstream from RabbitMQ, will retry", "upstream", upstreamName, "vhost", vhostName)
+		return ctrl.Result{}, err
+	}
+
+	controllerutil.RemoveFinalizer(instance, federationUpstreamFinalizer)
+	return ctrl.Result{}, nil
+}
+
+// federationUpstreamPermissions returns the permissions of the user of the federation links in the
+// upstream. The links declare the "federation: " exchanges and queues they consume from. Without an
+// explicit upstream exchange and queue their names come from the federated objects in the downstream,
+// so the links can read from any exchange and queue of the upstream vhost.
+func federationUpstreamPermissions(spec rabbitmqv1.RabbitMQFederationUpstreamSpec) rabbitmqv1.RabbitMQUserPermissions {
+	federation := []string{"federation: .*"}
+	read := ".*"
+	if spec.Exchange != "" || spec.Queue != "" {
+		read = linkNamesRegex(federation, spec.Exchange, spec.Queue)
+	}
+	return rabbitmqv1.RabbitMQUserPermissions{
+		Configure: linkNamesRegex(federation),
+		Write:     linkNamesRegex(federation),
+		Read:      read,
+	}
+}
+
+// clusterToFederationUpstreamMapFunc maps RabbitMQ cluster changes to federation upstream reconciliation requests
+// Federation upstreams are reconciled on changes of the downstream or upstream cluster, e.g. to
+// update the URIs when the hosts or credentials change
+// Works with both RabbitmqCluster (cluster-operator) and RabbitMq CRs
+func (r *RabbitMQFederationUpstreamReconciler) clusterToFederationUpstreamMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	clusterName := obj.GetName()
+	clusterNamespace := obj.GetNamespace()
+
+	upstreamList := &rabbitmqv1.RabbitMQFederationUpstreamList{}
+	if err := r.List(ctx, upstreamList, client.InNamespace(clusterNamespace)); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list federation upstreams for cluster watch", "cluster", clusterName)
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, upstream := range upstreamList.Items {
+		// Reconcile federation upstreams that reference this cluster
+		if upstream.Spec.RabbitmqClusterName == clusterName || upstream.Spec.Upstream.RabbitmqClusterName == clusterName {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      upstream.Name,
+					Namespace: upstream.Namespace,
+				},
+			})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RabbitMQFederationUpstreamReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&rabbitmqv1.RabbitMQFederationUpstream{}).
+		Owns(&rabbitmqv1.RabbitMQUser{}).
+		Watches(&rabbitmqclusterv2.RabbitmqCluster{},
+			handler.EnqueueRequestsFromMapFunc(r.clusterToFederationUpstreamMapFunc)).
+		Watches(&rabbitmqv1.RabbitMq{},
+			handler.EnqueueRequestsFromMapFunc(r.clusterToFederationUpstreamMapFunc)).
+		Complete(r)
+}
@@ -29,10 +29,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
-	rabbitmqapi "github.com/openstack-k8s-operators/infra-operator/pkg/rabbitmq/api"
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	oko_secret "github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	rabbitmqclusterv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -165,31 +165,51 @@ func (r *RabbitMQPolicyReconciler) reconcileNormal(ctx context.Context, instance
 	}
 
 	// Create API client
-	baseURL := getManagementURL(rabbit, rabbitSecret)
-	tlsEnabled := rabbit.Spec.TLS.SecretName != ""
-	caCert, err := getTLSCACert(ctx, h, rabbit, instance.Namespace)
+	apiClient, err := getAPIClient(ctx, h, rabbit, rabbitSecret)
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQPolicyReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQPolicyReadyErrorMessage, err.Error()))
 		return ctrl.Result{}, err
 	}
-	apiClient := rabbitmqapi.NewClient(baseURL, string(rabbitSecret.Data["username"]), string(rabbitSecret.Data["password"]), tlsEnabled, caCert)
 
-	// Create or update policy
+	// Policy definition from the spec
 	var definition map[string]interface{}
 	if err := json.Unmarshal(instance.Spec.Definition.Raw, &definition); err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQPolicyReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQPolicyReadyErrorMessage, err.Error()))
 		return ctrl.Result{}, err
 	}
-	err = apiClient.CreateOrUpdatePolicy(vhostName, policyName, instance.Spec.Pattern, definition, instance.Spec.Priority, instance.Spec.ApplyTo)
+
+	// Compare the policy in RabbitMQ against the spec to detect changes made outside of the operator
+	desiredHash, err := util.ObjectHash(struct {
+		Vhost string
+		Spec  rabbitmqv1.RabbitMQPolicySpec
+	}{vhostName, instance.Spec})
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQPolicyReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQPolicyReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	drift, err := apiClient.ComparePolicy(ctx, vhostName, policyName, instance.Spec.Pattern, definition, instance.Spec.Priority, instance.Spec.ApplyTo)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQPolicyReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQPolicyReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	if !checkDrift(ctx, instance.Spec.DriftDetection, &instance.Status.Conditions, instance.Status.LastAppliedHash == desiredHash, drift) {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQPolicyReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQPolicyReadyErrorMessage, "state in RabbitMQ differs from the spec"))
+		return driftCheckResult(instance.Spec.DriftDetection), nil
+	}
+
+	// Create or update policy
+	err = apiClient.CreateOrUpdatePolicy(ctx, vhostName, policyName, instance.Spec.Pattern, definition, instance.Spec.Priority, instance.Spec.ApplyTo)
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQPolicyReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQPolicyReadyErrorMessage, err.Error()))
 		return ctrl.Result{}, err
 	}
+	instance.Status.LastAppliedHash = desiredHash
 
 	instance.Status.Conditions.MarkTrue(rabbitmqv1.RabbitMQPolicyReadyCondition, rabbitmqv1.RabbitMQPolicyReadyMessage)
 	instance.Status.Conditions.MarkTrue(condition.ReadyCondition, condition.ReadyMessage)
 
-	return ctrl.Result{}, nil
+	// Requeue to check the policy in RabbitMQ for changes made outside of the operator
+	return driftCheckResult(instance.Spec.DriftDetection), nil
 }
 
 func (r *RabbitMQPolicyReconciler) reconcileDelete(ctx context.Context, instance *rabbitmqv1.RabbitMQPolicy, h *helper.Helper) (ctrl.Result, error) {
@@ -237,18 +257,15 @@ func (r *RabbitMQPolicyReconciler) reconcileDelete(ctx context.Context, instance
 	}
 
 	// Create API client
-	baseURL := getManagementURL(rabbit, rabbitSecret)
-	tlsEnabled := rabbit.Spec.TLS.SecretName != ""
-	caCert, err := getTLSCACert(ctx, h, rabbit, instance.Namespace)
+	apiClient, err := getAPIClient(ctx, h, rabbit, rabbitSecret)
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQPolicyReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQPolicyReadyErrorMessage, err.Error()))
 		return ctrl.Result{}, err
 	}
-	apiClient := rabbitmqapi.NewClient(baseURL, string(rabbitSecret.Data["username"]), string(rabbitSecret.Data["password"]), tlsEnabled, caCert)
 
 	// Delete policy from RabbitMQ
 	// Note: DeletePolicy already treats 404 as success
-	if err := apiClient.DeletePolicy(vhostName, policyName); err != nil {
+	if err := apiClient.DeletePolicy(ctx, vhostName, policyName); err != nil {
 		// Return error to trigger retry - this ensures proper cleanup in normal operations
 		// Trade-off: CR may be stuck in Terminating state if RabbitMQ is persistently unavailable
 		// Rationale:
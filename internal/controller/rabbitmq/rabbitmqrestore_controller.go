@@ -0,0 +1,376 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	rabbitmqapi "github.com/openstack-k8s-operators/infra-operator/pkg/rabbitmq/api"
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	common_rbac "github.com/openstack-k8s-operators/lib-common/modules/common/rbac"
+	oko_secret "github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+	rabbitmqclusterv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// RabbitMQRestoreReconciler reconciles a RabbitMQRestore object
+//
+//nolint:revive
+type RabbitMQRestoreReconciler struct {
+	client.Client
+	Kclient kubernetes.Interface
+	Scheme  *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqrestores,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqrestores/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqrestores/finalizers,verbs=update
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqbackups,verbs=get;list;watch
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=rabbitmq.com,resources=rabbitmqclusters,verbs=get;list;watch
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;update;patch
+
+// Reconcile reconciles a RabbitMQRestore object
+func (r *RabbitMQRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	Log := log.FromContext(ctx)
+
+	instance := &rabbitmqv1.RabbitMQRestore{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	h, _ := helper.NewHelper(instance, r.Client, r.Kclient, r.Scheme, Log)
+
+	// Save a copy of the conditions so that we can restore the LastTransitionTime
+	// when a condition's state doesn't change
+	savedConditions := instance.Status.Conditions.DeepCopy()
+
+	// Initialize status conditions
+	cl := condition.CreateList(
+		condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
+		condition.UnknownCondition(rabbitmqv1.RabbitMQRestoreReadyCondition, condition.InitReason, rabbitmqv1.RabbitMQRestoreReadyInitMessage),
+	)
+	instance.Status.Conditions.Init(&cl)
+	instance.Status.ObservedGeneration = instance.Generation
+
+	defer func() {
+		// Restore condition timestamps if they haven't changed
+		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
+
+		if instance.Status.Conditions.IsUnknown(condition.ReadyCondition) {
+			instance.Status.Conditions.Set(instance.Status.Conditions.Mirror(condition.ReadyCondition))
+		}
+		if err := h.PatchInstance(ctx, instance); err != nil {
+			Log.Error(err, "Failed to patch instance")
+		}
+	}()
+
+	// The restore Job is owned by the instance and gets garbage collected
+	if !instance.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	return r.reconcileNormal(ctx, instance, h)
+}
+
+func (r *RabbitMQRestoreReconciler) reconcileNormal(ctx context.Context, instance *rabbitmqv1.RabbitMQRestore, h *helper.Helper) (ctrl.Result, error) {
+	// A restore runs once
+	if instance.Status.CompletionTime != nil {
+		return r.markReady(instance)
+	}
+
+	// Get the backup to restore
+	backups := &rabbitmqv1.RabbitMQBackup{}
+	err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.BackupRef, Namespace: instance.Namespace}, backups)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQRestoreReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQRestoreReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	backup := backups.Status.GetBackup(instance.Spec.Backup)
+	if backup == nil {
+		if instance.Spec.Backup != "" && !slices.ContainsFunc(backups.Status.Backups, func(b rabbitmqv1.RabbitMQBackupEntry) bool {
+			return b.Name == instance.Spec.Backup
+		}) {
+			// The backup doesn't exist or was already deleted by the retention
+			err := fmt.Errorf("backup %s not found in RabbitMQBackup %s", instance.Spec.Backup, instance.Spec.BackupRef)
+			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQRestoreReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQRestoreReadyErrorMessage, err.Error()))
+			return ctrl.Result{}, nil
+		}
+
+		// The backup is still being taken
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			rabbitmqv1.RabbitMQRestoreReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			"RabbitMQ restore waiting for a backup of %s",
+			instance.Spec.BackupRef))
+		return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+	}
+
+	// Get the RabbitMQ cluster to restore into
+	rabbit := &rabbitmqclusterv2.RabbitmqCluster{}
+	err = r.Get(ctx, types.NamespacedName{Name: instance.Spec.RabbitmqClusterName, Namespace: instance.Namespace}, rabbit)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQRestoreReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQRestoreReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Check if cluster is ready for operations
+	if readinessErr := checkClusterReadiness(rabbit); readinessErr != nil {
+		if readinessErr.IsWaiting {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1.RabbitMQRestoreReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				"RabbitMQ restore waiting for dependencies %s",
+				readinessErr.Reason))
+			log.FromContext(ctx).Info("Waiting for RabbitMQ cluster to be ready", "cluster", instance.Spec.RabbitmqClusterName)
+		} else {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1.RabbitMQRestoreReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				rabbitmqv1.RabbitMQRestoreReadyErrorMessage,
+				readinessErr.Reason))
+		}
+		return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+	}
+
+	// Load the definitions of the backup
+	var definitions []byte
+	if backups.Spec.Storage.Type == rabbitmqv1.RabbitMQBackupStoragePVC {
+		definitions, err = r.readBackupFromPVC(ctx, h, instance, backups, backup.Name)
+	} else {
+		definitions, err = r.readBackupFromSecret(ctx, h, instance, backup.Name)
+	}
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQRestoreReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQRestoreReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	if definitions == nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQRestoreReadyCondition, condition.RequestedReason, condition.SeverityInfo, rabbitmqv1.RabbitMQRestoreReadyRunningMessage, backup.Name))
+		return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+	}
+
+	if len(instance.Spec.Vhosts) > 0 {
+		definitions, err = rabbitmqapi.FilterDefinitions(definitions, instance.Spec.Vhosts)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQRestoreReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQRestoreReadyErrorMessage, err.Error()))
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Get admin credentials
+	rabbitSecret, _, err := oko_secret.GetSecret(ctx, h, rabbit.Status.DefaultUser.SecretReference.Name, instance.Namespace)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQRestoreReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQRestoreReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Create API client
+	apiClient, err := getAPIClient(ctx, h, rabbit, rabbitSecret)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQRestoreReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQRestoreReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// The default user of the cluster keeps its credentials, e.g. when importing the backup of
+	// another cluster whose default user has the same name
+	definitions, err = rabbitmqapi.RemoveUserDefinitions(definitions, string(rabbitSecret.Data["username"]))
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQRestoreReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQRestoreReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	if err := apiClient.ImportDefinitions(ctx, definitions); err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQRestoreReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQRestoreReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	log.FromContext(ctx).Info("Restored RabbitMQ backup", "backup", backup.Name, "cluster", instance.Spec.RabbitmqClusterName)
+
+	instance.Status.RestoredBackup = backup.Name
+	instance.Status.CompletionTime = &metav1.Time{Time: time.Now().UTC()}
+
+	return r.markReady(instance)
+}
+
+// markReady marks the restore completed
+func (r *RabbitMQRestoreReconciler) markReady(instance *rabbitmqv1.RabbitMQRestore) (ctrl.Result, error) {
+	instance.Status.Conditions.MarkTrue(rabbitmqv1.RabbitMQRestoreReadyCondition, rabbitmqv1.RabbitMQRestoreReadyMessage)
+	instance.Status.Conditions.MarkTrue(condition.ReadyCondition, condition.ReadyMessage)
+	return ctrl.Result{}, nil
+}
+
+// readBackupFromSecret returns the definitions of a backup stored in a Secret
+func (r *RabbitMQRestoreReconciler) readBackupFromSecret(ctx context.Context, h *helper.Helper, instance *rabbitmqv1.RabbitMQRestore, name string) ([]byte, error) {
+	secret, _, err := oko_secret.GetSecret(ctx, h, name, instance.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backup %s: %w", name, err)
+	}
+	definitions, ok := secret.Data[rabbitmqv1.RabbitMQBackupDefinitionsKey]
+	if !ok {
+		return nil, fmt.Errorf("backup %s has no %s key", name, rabbitmqv1.RabbitMQBackupDefinitionsKey)
+	}
+	return definitions, nil
+}
+
+// readBackupFromPVC returns the definitions of a backup stored on a PersistentVolumeClaim.
+// The operator can't mount the claim, a Job copies the backup file into a Secret owned by the
+// restore. The service account of the Job may only update this Secret. Returns nil until the
+// Job completed.
+func (r *RabbitMQRestoreReconciler) readBackupFromPVC(ctx context.Context, h *helper.Helper, instance *rabbitmqv1.RabbitMQRestore, backups *rabbitmqv1.RabbitMQBackup, name string) ([]byte, error) {
+	jobName := fmt.Sprintf("%s-restore", instance.Name)
+
+	// The Secret is created empty, the Job only patches it
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: instance.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		return controllerutil.SetControllerReference(instance, secret, r.Scheme)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create restore secret %s: %w", jobName, err)
+	}
+
+	rbacRules := []rbacv1.PolicyRule{
+		{
+			APIGroups:     []string{""},
+			ResourceNames: []string{jobName},
+			Resources:     []string{"secrets"},
+			Verbs:         []string{"get", "patch"},
+		},
+	}
+	rbacResult, err := common_rbac.ReconcileRbac(ctx, h, instance, rbacRules)
+	if err != nil {
+		return nil, err
+	} else if (rbacResult != ctrl.Result{}) {
+		return nil, nil
+	}
+
+	job := &batchv1.Job{}
+	err = r.Get(ctx, types.NamespacedName{Name: jobName, Namespace: instance.Namespace}, job)
+	if k8s_errors.IsNotFound(err) {
+		image, err := getBackupImage(ctx, r.Client, instance.Namespace, backups.Spec.RabbitmqClusterName)
+		if err != nil {
+			return nil, err
+		}
+		job = backupJob(instance.Namespace, jobName, backups.Name, image, backups.Spec.Storage.ClaimName,
+			restoreJobScript(instance.Namespace, jobName, name))
+		job.Spec.Template.Spec.ServiceAccountName = instance.RbacResourceName()
+		job.Spec.Template.Spec.Containers[0].VolumeMounts[0].ReadOnly = true
+		if err := controllerutil.SetControllerReference(instance, job, r.Scheme); err != nil {
+			return nil, err
+		}
+		if err := r.Create(ctx, job); err != nil {
+			return nil, fmt.Errorf("failed to create restore job %s: %w", jobName, err)
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	succeeded, failed := jobFinished(job)
+	if failed {
+		return nil, fmt.Errorf("restore job %s failed to read backup %s", jobName, name)
+	}
+	if !succeeded {
+		return nil, nil
+	}
+
+	definitions, ok := secret.Data[rabbitmqv1.RabbitMQBackupDefinitionsKey]
+	if !ok {
+		return nil, fmt.Errorf("restore job %s did not write backup %s to secret %s", jobName, name, jobName)
+	}
+	return definitions, nil
+}
+
+// restoreJobScript returns the script of the Job copying a backup file into the Secret of the
+// restore through the Kubernetes API, using the token of its service account
+func restoreJobScript(namespace, secretName, backupName string) string {
+	return fmt.Sprintf(`set -e
+sa=/var/run/secrets/kubernetes.io/serviceaccount
+test -f %[1]s/%[2]s.json
+{ printf '{"data":{"%[3]s":"'; base64 -w 0 %[1]s/%[2]s.json; printf '"}}'; } | \
+curl -sSf -o /dev/null -X PATCH --cacert $sa/ca.crt \
+  -H "Authorization: Bearer $(cat $sa/token)" \
+  -H "Content-Type: application/merge-patch+json" \
+  --data-binary @- https://kubernetes.default.svc/api/v1/namespaces/%[4]s/secrets/%[5]s
+`, backupMountPath, backupName, rabbitmqv1.RabbitMQBackupDefinitionsKey, namespace, secretName)
+}
+
+// backupToRestoreMapFunc maps RabbitMQBackup changes to the restores waiting for one of its backups
+func (r *RabbitMQRestoreReconciler) backupToRestoreMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	restoreList := &rabbitmqv1.RabbitMQRestoreList{}
+	if err := r.List(ctx, restoreList, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list restores for backup watch", "backup", obj.GetName())
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, restore := range restoreList.Items {
+		if restore.Spec.BackupRef == obj.GetName() && restore.Status.CompletionTime == nil {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      restore.Name,
+					Namespace: restore.Namespace,
+				},
+			})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RabbitMQRestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&rabbitmqv1.RabbitMQRestore{}).
+		Owns(&batchv1.Job{}).
+		Watches(&rabbitmqv1.RabbitMQBackup{},
+			handler.EnqueueRequestsFromMapFunc(r.backupToRestoreMapFunc)).
+		Complete(r)
+}
@@ -0,0 +1,369 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rabbitmq
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	rabbitmqapi "github.com/openstack-k8s-operators/infra-operator/pkg/rabbitmq/api"
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	oko_secret "github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+	rabbitmqclusterv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const shovelFinalizer = "rabbitmqshovel.openstack.org/finalizer"
+
+// RabbitMQShovelReconciler reconciles a RabbitMQShovel object
+//
+//nolint:revive
+type RabbitMQShovelReconciler struct {
+	client.Client
+	Kclient kubernetes.Interface
+	Scheme  *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqshovels,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqshovels/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqshovels/finalizers,verbs=update
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqvhosts,verbs=get;list;watch
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqusers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=rabbitmq.com,resources=rabbitmqclusters,verbs=get;list;watch
+
+// Reconcile reconciles a RabbitMQShovel object
+func (r *RabbitMQShovelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	Log := log.FromContext(ctx)
+
+	instance := &rabbitmqv1.RabbitMQShovel{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	h, _ := helper.NewHelper(instance, r.Client, r.Kclient, r.Scheme, Log)
+
+	// Save a copy of the conditions so that we can restore the LastTransitionTime
+	// when a condition's state doesn't change
+	savedConditions := instance.Status.Conditions.DeepCopy()
+
+	// Initialize status conditions
+	cl := condition.CreateList(
+		condition.UnknownCondition(condition.ReadyCondition, condition.InitReason, condition.ReadyInitMessage),
+		condition.UnknownCondition(rabbitmqv1.RabbitMQShovelReadyCondition, condition.InitReason, rabbitmqv1.RabbitMQShovelReadyInitMessage),
+	)
+	instance.Status.Conditions.Init(&cl)
+	instance.Status.ObservedGeneration = instance.Generation
+
+	defer func() {
+		// Restore condition timestamps if they haven't changed
+		condition.RestoreLastTransitionTimes(&instance.Status.Conditions, savedConditions)
+
+		if instance.Status.Conditions.IsUnknown(condition.ReadyCondition) {
+			instance.Status.Conditions.Set(instance.Status.Conditions.Mirror(condition.ReadyCondition))
+		}
+		if err := h.PatchInstance(ctx, instance); err != nil {
+			Log.Error(err, "Failed to patch instance")
+		}
+	}()
+
+	// Handle deletion
+	if !instance.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, instance, h)
+	}
+
+	// Add finalizer if not being deleted
+	if controllerutil.AddFinalizer(instance, shovelFinalizer) {
+		// Finalizer was added, update will trigger reconcile
+		return ctrl.Result{}, nil
+	}
+
+	return r.reconcileNormal(ctx, instance, h)
+}
+
+func (r *RabbitMQShovelReconciler) reconcileNormal(ctx context.Context, instance *rabbitmqv1.RabbitMQShovel, h *helper.Helper) (ctrl.Result, error) {
+	// Shovel name is defaulted by webhook
+	shovelName := instance.Spec.Name
+
+	// Determine source and destination vhost names
+	srcVhostName, err := getVhostName(ctx, r.Client, instance.Namespace, instance.Spec.Source.VhostRef)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQShovelReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQShovelReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	destVhostName, err := getVhostName(ctx, r.Client, instance.Namespace, instance.Spec.Destination.VhostRef)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQShovelReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQShovelReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Get source and destination RabbitMQ clusters, the shovel runs in the source cluster
+	clusters := map[string]*rabbitmqclusterv2.RabbitmqCluster{}
+	for _, clusterName := range []string{instance.Spec.Source.RabbitmqClusterName, instance.Spec.Destination.RabbitmqClusterName} {
+		rabbit := &rabbitmqclusterv2.RabbitmqCluster{}
+		err := r.Get(ctx, types.NamespacedName{Name: clusterName, Namespace: instance.Namespace}, rabbit)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQShovelReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQShovelReadyErrorMessage, err.Error()))
+			return ctrl.Result{}, err
+		}
+
+		// Check if cluster is ready for operations
+		if readinessErr := checkClusterReadiness(rabbit); readinessErr != nil {
+			if readinessErr.IsWaiting {
+				// Cluster is starting up - set waiting condition
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					rabbitmqv1.RabbitMQShovelReadyCondition,
+					condition.RequestedReason,
+					condition.SeverityInfo,
+					"RabbitMQ shovel waiting for dependencies %s",
+					readinessErr.Reason))
+				log.FromContext(ctx).Info("Waiting for RabbitMQ cluster to be ready", "cluster", clusterName)
+			} else {
+				// Cluster is being deleted - set error condition
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					rabbitmqv1.RabbitMQShovelReadyCondition,
+					condition.ErrorReason,
+					condition.SeverityWarning,
+					rabbitmqv1.RabbitMQShovelReadyErrorMessage,
+					readinessErr.Reason))
+			}
+			return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+		}
+		clusters[clusterName] = rabbit
+	}
+	rabbit := clusters[instance.Spec.Source.RabbitmqClusterName]
+
+	// The shovel connects to both ends with its own users, which can only consume from the
+	// source and publish to the destination
+	srcUsername, srcPassword, srcReady, err := reconcileLinkUser(ctx, h, instance,
+		instance.Name+"-shovel-source-user", instance.Spec.Source.RabbitmqClusterName, instance.Spec.Source.VhostRef,
+		"shovel-"+instance.Name+"-source", shovelSourcePermissions(instance.Spec.Source))
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQShovelReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQShovelReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	destUsername, destPassword, destReady, err := reconcileLinkUser(ctx, h, instance,
+		instance.Name+"-shovel-destination-user", instance.Spec.Destination.RabbitmqClusterName, instance.Spec.Destination.VhostRef,
+		"shovel-"+instance.Name+"-destination", shovelDestinationPermissions(instance.Spec.Destination))
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQShovelReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQShovelReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	if !srcReady || !destReady {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			rabbitmqv1.RabbitMQShovelReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			"RabbitMQ shovel waiting for dependencies %s",
+			"users of the shovel"))
+		return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+	}
+
+	// Build the AMQP URIs of both ends, used by the source cluster
+	srcURIs, err := getAMQPURIs(ctx, h, rabbit, rabbit, srcVhostName, srcUsername, srcPassword)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQShovelReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQShovelReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	destURIs, err := getAMQPURIs(ctx, h, rabbit, clusters[instance.Spec.Destination.RabbitmqClusterName], destVhostName, destUsername, destPassword)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQShovelReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQShovelReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Get admin credentials
+	rabbitSecret, _, err := oko_secret.GetSecret(ctx, h, rabbit.Status.DefaultUser.SecretReference.Name, instance.Namespace)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQShovelReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQShovelReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Create API client
+	apiClient, err := getAPIClient(ctx, h, rabbit, rabbitSecret)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQShovelReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQShovelReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Create or update shovel
+	shovel := rabbitmqapi.Shovel{
+		SrcURI:           srcURIs,
+		SrcQueue:         instance.Spec.Source.Queue,
+		SrcExchange:      instance.Spec.Source.Exchange,
+		SrcExchangeKey:   instance.Spec.Source.ExchangeKey,
+		SrcPrefetchCount: instance.Spec.PrefetchCount,
+		SrcDeleteAfter:   instance.Spec.DeleteAfter,
+		DestURI:          destURIs,
+		DestQueue:        instance.Spec.Destination.Queue,
+		DestExchange:     instance.Spec.Destination.Exchange,
+		DestExchangeKey:  instance.Spec.Destination.ExchangeKey,
+		AckMode:          instance.Spec.AckMode,
+		ReconnectDelay:   instance.Spec.ReconnectDelay,
+	}
+	err = apiClient.CreateOrUpdateShovel(ctx, srcVhostName, shovelName, shovel)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQShovelReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQShovelReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	instance.Status.Conditions.MarkTrue(rabbitmqv1.RabbitMQShovelReadyCondition, rabbitmqv1.RabbitMQShovelReadyMessage)
+	instance.Status.Conditions.MarkTrue(condition.ReadyCondition, condition.ReadyMessage)
+
+	return ctrl.Result{}, nil
+}
+
+func (r *RabbitMQShovelReconciler) reconcileDelete(ctx context.Context, instance *rabbitmqv1.RabbitMQShovel, h *helper.Helper) (ctrl.Result, error) {
+	shovelName := instance.Spec.Name
+	if shovelName == "" {
+		shovelName = instance.Name
+	}
+
+	vhostName := "/"
+	if instance.Spec.Source.VhostRef != "" {
+		vhost := &rabbitmqv1.RabbitMQVhost{}
+		err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.Source.VhostRef, Namespace: instance.Namespace}, vhost)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			// Log non-NotFound errors but continue with deletion
+			log.FromContext(ctx).Error(err, "Failed to get vhost", "vhost", instance.Spec.Source.VhostRef)
+		}
+		if vhost.Spec.Name != "" {
+			vhostName = vhost.Spec.Name
+		}
+	}
+
+	// Get source RabbitMQ cluster the shovel runs in
+	rabbit := &rabbitmqclusterv2.RabbitmqCluster{}
+	err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.Source.RabbitmqClusterName, Namespace: instance.Namespace}, rabbit)
+
+	// If cluster is being deleted or not found, skip cleanup and just remove finalizer
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		// Error getting cluster - return error to retry
+		return ctrl.Result{}, err
+	}
+
+	if k8s_errors.IsNotFound(err) || !rabbit.DeletionTimestamp.IsZero() {
+		// Cluster doesn't exist or is being deleted - nothing to clean up
+		controllerutil.RemoveFinalizer(instance, shovelFinalizer)
+		return ctrl.Result{}, nil
+	}
+
+	// Cluster exists and is not being deleted - perform cleanup
+	// Get admin credentials
+	rabbitSecret, _, err := oko_secret.GetSecret(ctx, h, rabbit.Status.DefaultUser.SecretReference.Name, instance.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Create API client
+	apiClient, err := getAPIClient(ctx, h, rabbit, rabbitSecret)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQShovelReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQShovelReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	// Delete shovel from RabbitMQ
+	// Note: DeleteShovel already treats 404 as success
+	if err := apiClient.DeleteShovel(ctx, vhostName, shovelName); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to delete shovel from RabbitMQ, will retry", "shovel", shovelName, "vhost", vhostName)
+		return ctrl.Result{}, err
+	}
+
+	controllerutil.RemoveFinalizer(instance, shovelFinalizer)
+	return ctrl.Result{}, nil
+}
+
+// shovelSourcePermissions returns the permissions of the user consuming from the source. An
+// exchange source gets consumed through a server named queue bound to the exchange.
+func shovelSourcePermissions(source rabbitmqv1.RabbitMQShovelEndpoint) rabbitmqv1.RabbitMQUserPermissions {
+	serverNamed := []string{`amq\.gen-.*`}
+	return rabbitmqv1.RabbitMQUserPermissions{
+		Configure: linkNamesRegex(serverNamed, source.Queue),
+		Write:     linkNamesRegex(serverNamed),
+		Read:      linkNamesRegex(serverNamed, source.Queue, source.Exchange),
+	}
+}
+
+// shovelDestinationPermissions returns the permissions of the user publishing to the destination.
+// A destination queue gets declared and published to through the default exchange.
+func shovelDestinationPermissions(destination rabbitmqv1.RabbitMQShovelEndpoint) rabbitmqv1.RabbitMQUserPermissions {
+	write := []string{}
+	if destination.Queue != "" {
+		write = append(write, `amq\.default`)
+	}
+	return rabbitmqv1.RabbitMQUserPermissions{
+		Configure: linkNamesRegex(nil, destination.Queue),
+		Write:     linkNamesRegex(write, destination.Queue, destination.Exchange),
+		Read:      linkNamesRegex(nil),
+	}
+}
+
+// clusterToShovelMapFunc maps RabbitMQ cluster changes to shovel reconciliation requests
+// Shovels are reconciled on changes of the source or destination cluster, e.g. to
+// update the URIs when the hosts or credentials change
+// Works with both RabbitmqCluster (cluster-operator) and RabbitMq CRs
+func (r *RabbitMQShovelReconciler) clusterToShovelMapFunc(ctx context.Context, obj client.Object) []reconcile.Request {
+	clusterName := obj.GetName()
+	clusterNamespace := obj.GetNamespace()
+
+	shovelList := &rabbitmqv1.RabbitMQShovelList{}
+	if err := r.List(ctx, shovelList, client.InNamespace(clusterNamespace)); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list shovels for cluster watch", "cluster", clusterName)
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, shovel := range shovelList.Items {
+		// Reconcile shovels that reference this cluster
+		if shovel.Spec.Source.RabbitmqClusterName == clusterName || shovel.Spec.Destination.RabbitmqClusterName == clusterName {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      shovel.Name,
+					Namespace: shovel.Namespace,
+				},
+			})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RabbitMQShovelReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&rabbitmqv1.RabbitMQShovel{}).
+		Owns(&rabbitmqv1.RabbitMQUser{}).
+		Watches(&rabbitmqclusterv2.RabbitmqCluster{},
+			handler.EnqueueRequestsFromMapFunc(r.clusterToShovelMapFunc)).
+		Watches(&rabbitmqv1.RabbitMq{},
+			handler.EnqueueRequestsFromMapFunc(r.clusterToShovelMapFunc)).
+		Complete(r)
+}
@@ -35,15 +35,18 @@ import (
 
 	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
 	rabbitmqapi "github.com/openstack-k8s-operators/infra-operator/pkg/rabbitmq/api"
+	"github.com/openstack-k8s-operators/infra-operator/pkg/rabbitmq/secretprovider"
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/object"
 	oko_secret "github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	rabbitmqclusterv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
 )
 
 // userFinalizer is the controller-level finalizer for RabbitMQUser resources.
@@ -55,6 +58,10 @@ const userFinalizer = "rabbitmquser.openstack.org/finalizer"
 // credentialSecretNameField is the field index for the credential secret
 const credentialSecretNameField = ".spec.secret"
 
+// externalSecretRefreshInterval is how often passwords of external secret providers are read
+// again, changes of them don't trigger a reconcile
+const externalSecretRefreshInterval = 5 * time.Minute
+
 // generatePassword generates a random password
 func generatePassword(length int) (string, error) {
 	bytes := make([]byte, length)
@@ -72,6 +79,8 @@ type RabbitMQUserReconciler struct {
 	client.Client
 	Kclient kubernetes.Interface
 	Scheme  *runtime.Scheme
+	// SecretProviders are the external secret providers by name, see ExternalSecretSource
+	SecretProviders map[string]secretprovider.Provider
 }
 
 //+kubebuilder:rbac:groups=rabbitmq.openstack.org,resources=rabbitmqusers,verbs=get;list;watch;create;update;patch;delete
@@ -178,7 +187,10 @@ func (r *RabbitMQUserReconciler) reconcileNormal(ctx context.Context, instance *
 	Log := log.FromContext(ctx)
 
 	var password string
+	var passwordHash string
+	var hashingAlgorithm string
 	var secretName string
+	var secretVersion string
 
 	// Username is now always stored in spec.Username by the webhook
 	username := instance.Spec.Username
@@ -257,7 +269,71 @@ func (r *RabbitMQUserReconciler) reconcileNormal(ctx context.Context, instance *
 	}
 
 	// Determine credentials source
-	if instance.Spec.Secret != nil && *instance.Spec.Secret != "" {
+	if hashed := instance.Spec.HashedCredentials; hashed != nil {
+		// Use the precomputed password hash, the password itself is never known to the operator
+		secretName = hashed.Secret
+		hashSecret, _, err := oko_secret.GetSecret(ctx, h, secretName, instance.Namespace)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1.RabbitMQUserReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				rabbitmqv1.RabbitMQUserReadyErrorMessage,
+				fmt.Sprintf("failed to get password hash secret %s: %v", secretName, err)))
+			return ctrl.Result{}, err
+		}
+
+		hashKey := hashed.PasswordHashKey
+		if hashKey == "" {
+			hashKey = "password_hash"
+		}
+		hashBytes, ok := hashSecret.Data[hashKey]
+		if !ok {
+			err := fmt.Errorf("password hash key %q not found in secret %s", hashKey, secretName)
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1.RabbitMQUserReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				rabbitmqv1.RabbitMQUserReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+		passwordHash = strings.TrimSpace(string(hashBytes))
+		hashingAlgorithm = hashed.HashingAlgorithm
+		if hashingAlgorithm == "" {
+			hashingAlgorithm = rabbitmqapi.HashingAlgorithmSHA256
+		}
+		secretVersion = hashSecret.ResourceVersion
+	} else if external := instance.Spec.ExternalSecret; external != nil {
+		// Read the password from the external secret provider, there is no secret in the namespace
+		providerName := external.Provider
+		if providerName == "" {
+			providerName = rabbitmqv1.ExternalSecretProviderFile
+		}
+		provider, ok := r.SecretProviders[providerName]
+		if !ok {
+			err := fmt.Errorf("external secret provider %q is not configured", providerName)
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1.RabbitMQUserReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				rabbitmqv1.RabbitMQUserReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+		externalSecret, err := provider.GetSecret(ctx, external.Path)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1.RabbitMQUserReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				rabbitmqv1.RabbitMQUserReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+		password = externalSecret.Password
+		secretVersion = externalSecret.Version
+	} else if instance.Spec.Secret != nil && *instance.Spec.Secret != "" {
 		// Use user-provided secret
 		secretName = *instance.Spec.Secret
 		userSecret, _, err := oko_secret.GetSecret(ctx, h, secretName, instance.Namespace)
@@ -293,6 +369,7 @@ func (r *RabbitMQUserReconciler) reconcileNormal(ctx context.Context, instance *
 			return ctrl.Result{}, err
 		}
 		password = string(passwordBytes)
+		secretVersion = userSecret.ResourceVersion
 	} else {
 		// Existing auto-generation logic for backward compatibility
 		secretName = fmt.Sprintf("rabbitmq-user-%s", instance.Name)
@@ -331,6 +408,7 @@ func (r *RabbitMQUserReconciler) reconcileNormal(ctx context.Context, instance *
 			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQUserReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQUserReadyErrorMessage, err.Error()))
 			return ctrl.Result{}, err
 		}
+		secretVersion = secret.ResourceVersion
 	}
 
 	// Update status with credentials info immediately, before attempting RabbitMQ operations
@@ -351,35 +429,101 @@ func (r *RabbitMQUserReconciler) reconcileNormal(ctx context.Context, instance *
 	}
 
 	// Create API client
-	baseURL := getManagementURL(rabbit, rabbitSecret)
-	tlsEnabled := rabbit.Spec.TLS.SecretName != ""
-	caCert, err := getTLSCACert(ctx, h, rabbit, instance.Namespace)
+	apiClient, err := getAPIClient(ctx, h, rabbit, rabbitSecret)
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQUserReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQUserReadyErrorMessage, err.Error()))
 		return ctrl.Result{}, err
 	}
-	apiClient := rabbitmqapi.NewClient(baseURL, string(rabbitSecret.Data["username"]), string(rabbitSecret.Data["password"]), tlsEnabled, caCert)
 
 	// If vhost changed and there was a previous vhost, delete permissions from old vhost first
 	vhostChanged := instance.Status.Vhost != vhostName
 	oldPermissionsDeleted := true
 	if vhostChanged && instance.Status.Vhost != "" {
 		Log.Info("Vhost changed, deleting permissions from old vhost", "old_vhost", instance.Status.Vhost, "new_vhost", vhostName, "username", username)
-		if err := apiClient.DeletePermissions(instance.Status.Vhost, username); err != nil {
+		if err := apiClient.DeletePermissions(ctx, instance.Status.Vhost, username); err != nil {
 			// Track that old permissions weren't deleted - we'll retry on next reconciliation
 			// We continue to set new permissions so the user works in the new vhost,
 			// but we won't update status.Vhost until old permissions are cleaned up
 			oldPermissionsDeleted = false
 			Log.Error(err, "Failed to delete permissions from old vhost, will retry", "old_vhost", instance.Status.Vhost, "username", username)
+		} else if err := apiClient.DeleteTopicPermissions(ctx, instance.Status.Vhost, username); err != nil {
+			oldPermissionsDeleted = false
+			Log.Error(err, "Failed to delete topic permissions from old vhost, will retry", "old_vhost", instance.Status.Vhost, "username", username)
 		}
 	}
 
-	// Always create/update user - CreateOrUpdateUser is idempotent
 	tags := instance.Spec.Tags
 	if tags == nil {
 		tags = []string{}
 	}
-	err = apiClient.CreateOrUpdateUser(username, password, tags)
+
+	// Record whether the user already exists in RabbitMQ before it gets written the first time
+	if instance.Spec.Adoption.IsEnabled() && instance.Status.Adopted == nil {
+		current, err := apiClient.GetUser(ctx, username)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQUserReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQUserReadyErrorMessage, err.Error()))
+			return ctrl.Result{}, err
+		}
+		instance.Status.Adopted = ptr.To(current != nil)
+		if current != nil {
+			Log.Info("Adopting existing RabbitMQ user", "username", username)
+		}
+	}
+
+	// Compare the user in RabbitMQ against the spec to detect changes made outside of the operator.
+	// The secret version is part of the hash, so password changes in the secret always get applied.
+	desiredHash, err := util.ObjectHash(struct {
+		Spec          rabbitmqv1.RabbitMQUserSpec
+		Vhost         string
+		SecretName    string
+		SecretVersion string
+	}{instance.Spec, vhostName, secretName, secretVersion})
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQUserReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQUserReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	var drift []string
+	if passwordHash != "" {
+		drift, err = apiClient.CompareUserHash(ctx, username, passwordHash, hashingAlgorithm, tags)
+	} else {
+		drift, err = apiClient.CompareUser(ctx, username, password, tags)
+	}
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQUserReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQUserReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	permissionsDrift, err := apiClient.ComparePermissions(ctx, vhostName, username,
+		instance.Spec.Permissions.Configure,
+		instance.Spec.Permissions.Write,
+		instance.Spec.Permissions.Read)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQUserReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQUserReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	drift = append(drift, permissionsDrift...)
+	topicPermissionsDrift, err := apiClient.CompareTopicPermissions(ctx, vhostName, username, userTopicPermissions(instance.Spec.TopicPermissions))
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQUserReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQUserReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	drift = append(drift, topicPermissionsDrift...)
+	limitsDrift, err := apiClient.CompareUserLimits(ctx, username, userLimits(instance.Spec.Limits))
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQUserReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQUserReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	drift = append(drift, limitsDrift...)
+	if !checkDrift(ctx, instance.Spec.DriftDetection, &instance.Status.Conditions, instance.Status.LastAppliedHash == desiredHash, drift) {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQUserReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQUserReadyErrorMessage, "state in RabbitMQ differs from the spec"))
+		return userResult(instance), nil
+	}
+
+	// Always create/update user - CreateOrUpdateUser is idempotent
+	if passwordHash != "" {
+		err = apiClient.CreateOrUpdateUserWithHash(ctx, username, passwordHash, hashingAlgorithm, tags)
+	} else {
+		err = apiClient.CreateOrUpdateUser(ctx, username, password, tags)
+	}
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQUserReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQUserReadyErrorMessage, err.Error()))
 		return ctrl.Result{}, err
@@ -389,7 +533,7 @@ func (r *RabbitMQUserReconciler) reconcileNormal(ctx context.Context, instance *
 	// Note: instance.Spec.Permissions is never nil because the field doesn't use omitempty.
 	// Individual permission fields (Configure/Write/Read) are guaranteed to have values
 	// either from user input or from kubebuilder defaults (".*" for full permissions).
-	err = apiClient.SetPermissions(vhostName, username,
+	err = apiClient.SetPermissions(ctx, vhostName, username,
 		instance.Spec.Permissions.Configure,
 		instance.Spec.Permissions.Write,
 		instance.Spec.Permissions.Read)
@@ -398,15 +542,158 @@ func (r *RabbitMQUserReconciler) reconcileNormal(ctx context.Context, instance *
 		return ctrl.Result{}, err
 	}
 
+	// Topic permissions and limits are only changed where they differ from RabbitMQ,
+	// and removed from the user again when they get cleared from the spec
+	if err := reconcileUserTopicPermissions(ctx, apiClient, vhostName, username, instance.Spec.TopicPermissions); err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQUserReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQUserReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	if err := reconcileUserLimits(ctx, apiClient, username, instance.Spec.Limits); err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQUserReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQUserReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+
 	// Only update status.Vhost if old permissions were successfully deleted
 	// This ensures we keep track of the old vhost and retry cleanup on next reconciliation
 	if oldPermissionsDeleted {
 		instance.Status.Vhost = vhostName
 	}
+	instance.Status.LastAppliedHash = desiredHash
 	instance.Status.Conditions.MarkTrue(rabbitmqv1.RabbitMQUserReadyCondition, rabbitmqv1.RabbitMQUserReadyMessage)
 	instance.Status.Conditions.MarkTrue(condition.ReadyCondition, condition.ReadyMessage)
 
-	return ctrl.Result{}, nil
+	return userResult(instance), nil
+}
+
+// userResult returns the result which requeues the reconcile for the next drift check, or to
+// read the password of an external secret provider again
+func userResult(instance *rabbitmqv1.RabbitMQUser) ctrl.Result {
+	result := driftCheckResult(instance.Spec.DriftDetection)
+	if instance.Spec.ExternalSecret != nil &&
+		(result.RequeueAfter == 0 || result.RequeueAfter > externalSecretRefreshInterval) {
+		result.RequeueAfter = externalSecretRefreshInterval
+	}
+	return result
+}
+
+// reconcileUserTopicPermissions ensures the topic permissions of the user on the vhost
+// match the desired ones. The management API can only delete all topic permissions of
+// a user on a vhost at once, so they get cleared and re-applied if an exchange got
+// removed from the desired list.
+func reconcileUserTopicPermissions(
+	ctx context.Context,
+	apiClient *rabbitmqapi.Client,
+	vhost string,
+	username string,
+	topicPermissions []rabbitmqv1.RabbitMQUserTopicPermission,
+) error {
+	Log := log.FromContext(ctx)
+
+	current, err := apiClient.ListTopicPermissions(ctx, vhost, username)
+	if err != nil {
+		return err
+	}
+
+	desired := map[string]bool{}
+	for _, topicPerm := range topicPermissions {
+		desired[topicPerm.Exchange] = true
+	}
+
+	currentByExchange := map[string]rabbitmqapi.TopicPermission{}
+	for _, topicPerm := range current {
+		currentByExchange[topicPerm.Exchange] = topicPerm
+	}
+	for _, topicPerm := range current {
+		if !desired[topicPerm.Exchange] {
+			Log.Info("Removing stale topic permissions", "username", username, "vhost", vhost, "exchange", topicPerm.Exchange)
+			if err := apiClient.DeleteTopicPermissions(ctx, vhost, username); err != nil {
+				return err
+			}
+			currentByExchange = map[string]rabbitmqapi.TopicPermission{}
+			break
+		}
+	}
+
+	for _, topicPerm := range topicPermissions {
+		if currentPerm, ok := currentByExchange[topicPerm.Exchange]; ok &&
+			currentPerm.Write == topicPerm.Write && currentPerm.Read == topicPerm.Read {
+			continue
+		}
+		Log.Info("Setting topic permissions", "username", username, "vhost", vhost, "exchange", topicPerm.Exchange)
+		if err := apiClient.SetTopicPermissions(ctx, vhost, username, topicPerm.Exchange, topicPerm.Write, topicPerm.Read); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reconcileUserLimits sets the configured limits on the user and removes the ones
+// which are not set (anymore). Only limits which differ from RabbitMQ get changed.
+func reconcileUserLimits(ctx context.Context, apiClient *rabbitmqapi.Client, username string, limits *rabbitmqv1.RabbitMQUserLimits) error {
+	Log := log.FromContext(ctx)
+
+	currentLimits, err := apiClient.GetUserLimits(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if limits == nil {
+		limits = &rabbitmqv1.RabbitMQUserLimits{}
+	}
+
+	for _, l := range []struct {
+		name  string
+		value *int64
+	}{
+		{rabbitmqapi.UserLimitMaxConnections, limits.MaxConnections},
+		{rabbitmqapi.UserLimitMaxChannels, limits.MaxChannels},
+	} {
+		currentValue, isSet := currentLimits[l.name]
+		switch {
+		case l.value == nil && isSet:
+			Log.Info("Removing user limit", "username", username, "limit", l.name)
+			if err := apiClient.DeleteUserLimit(ctx, username, l.name); err != nil {
+				return err
+			}
+		case l.value != nil && (!isSet || currentValue != *l.value):
+			Log.Info("Setting user limit", "username", username, "limit", l.name, "value", *l.value)
+			if err := apiClient.SetUserLimit(ctx, username, l.name, *l.value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// userTopicPermissions returns the topic permissions of the spec as expected by the management API
+func userTopicPermissions(topicPermissions []rabbitmqv1.RabbitMQUserTopicPermission) []rabbitmqapi.TopicPermission {
+	perms := []rabbitmqapi.TopicPermission{}
+	for _, topicPerm := range topicPermissions {
+		perms = append(perms, rabbitmqapi.TopicPermission{
+			Exchange: topicPerm.Exchange,
+			Write:    topicPerm.Write,
+			Read:     topicPerm.Read,
+		})
+	}
+	return perms
+}
+
+// userLimits returns the limits of the spec keyed by the limit name of the management API
+func userLimits(limits *rabbitmqv1.RabbitMQUserLimits) map[string]int64 {
+	result := map[string]int64{}
+	if limits == nil {
+		return result
+	}
+	if limits.MaxConnections != nil {
+		result[rabbitmqapi.UserLimitMaxConnections] = *limits.MaxConnections
+	}
+	if limits.MaxChannels != nil {
+		result[rabbitmqapi.UserLimitMaxChannels] = *limits.MaxChannels
+	}
+	return result
 }
 
 func (r *RabbitMQUserReconciler) reconcileDelete(ctx context.Context, instance *rabbitmqv1.RabbitMQUser, h *helper.Helper) (ctrl.Result, error) {
@@ -525,6 +812,14 @@ func (r *RabbitMQUserReconciler) reconcileDelete(ctx context.Context, instance *
 		}
 	}
 
+	// Adopted users were not created by the operator, keep them in RabbitMQ unless requested otherwise.
+	// The auto-generated secret is owned by the RabbitMQUser and gets garbage collected.
+	if instance.Spec.Adoption.KeepAdopted(instance.Status.Adopted) {
+		Log.Info("Keeping adopted user in RabbitMQ", "user", instance.Name, "username", username)
+		controllerutil.RemoveFinalizer(instance, userFinalizer)
+		return ctrl.Result{}, nil
+	}
+
 	// Get RabbitMQ cluster
 	rabbit := &rabbitmqclusterv2.RabbitmqCluster{}
 	err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.RabbitmqClusterName, Namespace: instance.Namespace}, rabbit)
@@ -566,22 +861,19 @@ func (r *RabbitMQUserReconciler) reconcileDelete(ctx context.Context, instance *
 	}
 
 	// Create API client
-	baseURL := getManagementURL(rabbit, rabbitSecret)
-	tlsEnabled := rabbit.Spec.TLS.SecretName != ""
-	caCert, err := getTLSCACert(ctx, h, rabbit, instance.Namespace)
+	apiClient, err := getAPIClient(ctx, h, rabbit, rabbitSecret)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	apiClient := rabbitmqapi.NewClient(baseURL, string(rabbitSecret.Data["username"]), string(rabbitSecret.Data["password"]), tlsEnabled, caCert)
 
 	// Delete permissions and user from RabbitMQ
 	// The Delete methods already treat 404 as success
-	if err := apiClient.DeletePermissions(vhostName, username); err != nil {
+	if err := apiClient.DeletePermissions(ctx, vhostName, username); err != nil {
 		// Return error to trigger retry - see rabbitmqpolicy_controller.go for detailed rationale
 		return ctrl.Result{}, fmt.Errorf("failed to delete permissions for user %s from vhost %s in RabbitMQ: %w", username, vhostName, err)
 	}
 
-	if err := apiClient.DeleteUser(username); err != nil {
+	if err := apiClient.DeleteUser(ctx, username); err != nil {
 		// Return error to trigger retry - see rabbitmqpolicy_controller.go for detailed rationale
 		return ctrl.Result{}, fmt.Errorf("failed to delete user %s from RabbitMQ: %w", username, err)
 	}
@@ -696,6 +988,9 @@ func (r *RabbitMQUserReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		&rabbitmqv1.RabbitMQUser{}, credentialSecretNameField,
 		func(rawObj client.Object) []string {
 			user := rawObj.(*rabbitmqv1.RabbitMQUser)
+			if user.Spec.HashedCredentials != nil {
+				return []string{user.Spec.HashedCredentials.Secret}
+			}
 			if user.Spec.Secret == nil || *user.Spec.Secret == "" {
 				return nil
 			}
@@ -33,11 +33,13 @@ import (
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	oko_secret "github.com/openstack-k8s-operators/lib-common/modules/common/secret"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	rabbitmqclusterv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
 )
 
 const (
@@ -158,14 +160,11 @@ func (r *RabbitMQVhostReconciler) reconcileNormal(ctx context.Context, instance
 	}
 
 	// Create API client
-	baseURL := getManagementURL(rabbit, rabbitSecret)
-	tlsEnabled := rabbit.Spec.TLS.SecretName != ""
-	caCert, err := getTLSCACert(ctx, h, rabbit, instance.Namespace)
+	apiClient, err := getAPIClient(ctx, h, rabbit, rabbitSecret)
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQVhostReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQVhostReadyErrorMessage, err.Error()))
 		return ctrl.Result{}, err
 	}
-	apiClient := rabbitmqapi.NewClient(baseURL, string(rabbitSecret.Data["username"]), string(rabbitSecret.Data["password"]), tlsEnabled, caCert)
 
 	// Create vhost
 	vhostName := instance.Spec.Name
@@ -173,18 +172,141 @@ func (r *RabbitMQVhostReconciler) reconcileNormal(ctx context.Context, instance
 		vhostName = "/"
 	}
 
-	if vhostName != "/" {
-		err = apiClient.CreateOrUpdateVhost(vhostName)
+	// Record whether the vhost already exists in RabbitMQ before it gets written the first time
+	if instance.Spec.Adoption.IsEnabled() && instance.Status.Adopted == nil {
+		current, err := apiClient.GetVhost(ctx, vhostName)
 		if err != nil {
 			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQVhostReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQVhostReadyErrorMessage, err.Error()))
 			return ctrl.Result{}, err
 		}
+		instance.Status.Adopted = ptr.To(current != nil)
+		if current != nil {
+			log.FromContext(ctx).Info("Adopting existing RabbitMQ vhost", "vhost", vhostName)
+		}
+	}
+
+	// Compare the vhost in RabbitMQ against the spec to detect changes made outside of the operator
+	desiredHash, err := util.ObjectHash(instance.Spec)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQVhostReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQVhostReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	drift, err := vhostDrift(ctx, apiClient, vhostName, &instance.Spec)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQVhostReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQVhostReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	if !checkDrift(ctx, instance.Spec.DriftDetection, &instance.Status.Conditions, instance.Status.LastAppliedHash == desiredHash, drift) {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQVhostReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQVhostReadyErrorMessage, "state in RabbitMQ differs from the spec"))
+		return driftCheckResult(instance.Spec.DriftDetection), nil
+	}
+
+	err = reconcileVhostSettings(ctx, apiClient, vhostName, &instance.Spec)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQVhostReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQVhostReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
 	}
+	instance.Status.LastAppliedHash = desiredHash
 
 	instance.Status.Conditions.MarkTrue(rabbitmqv1.RabbitMQVhostReadyCondition, rabbitmqv1.RabbitMQVhostReadyMessage)
 	instance.Status.Conditions.MarkTrue(condition.ReadyCondition, condition.ReadyMessage)
 
-	return ctrl.Result{}, nil
+	// Requeue to check the vhost in RabbitMQ for changes made outside of the operator, e.g. through the management UI
+	return driftCheckResult(instance.Spec.DriftDetection), nil
+}
+
+// reconcileVhostSettings creates the vhost if it does not exist and ensures its
+// metadata (description, tags, default queue type) and limits match the spec.
+func reconcileVhostSettings(ctx context.Context, apiClient *rabbitmqapi.Client, vhostName string, spec *rabbitmqv1.RabbitMQVhostSpec) error {
+	Log := log.FromContext(ctx)
+
+	current, err := apiClient.GetVhost(ctx, vhostName)
+	if err != nil {
+		return err
+	}
+
+	if vhostMetadataManaged(vhostName, spec) && (current == nil || vhostMetadataDrifted(current, spec)) {
+		if current != nil {
+			Log.Info("Vhost settings differ from spec, updating", "vhost", vhostName)
+		}
+		if err := apiClient.CreateOrUpdateVhost(ctx, vhostName, spec.Description, spec.Tags, spec.DefaultQueueType); err != nil {
+			return err
+		}
+	}
+
+	currentLimits, err := apiClient.GetVhostLimits(ctx, vhostName)
+	if err != nil {
+		return err
+	}
+
+	limits := spec.Limits
+	if limits == nil {
+		limits = &rabbitmqv1.RabbitMQVhostLimits{}
+	}
+
+	for _, l := range []struct {
+		name  string
+		value *int64
+	}{
+		{rabbitmqapi.VhostLimitMaxConnections, limits.MaxConnections},
+		{rabbitmqapi.VhostLimitMaxQueues, limits.MaxQueues},
+	} {
+		currentValue, isSet := currentLimits[l.name]
+		switch {
+		case l.value == nil && isSet:
+			Log.Info("Removing vhost limit", "vhost", vhostName, "limit", l.name)
+			if err := apiClient.DeleteVhostLimit(ctx, vhostName, l.name); err != nil {
+				return err
+			}
+		case l.value != nil && (!isSet || currentValue != *l.value):
+			Log.Info("Setting vhost limit", "vhost", vhostName, "limit", l.name, "value", *l.value)
+			if err := apiClient.SetVhostLimit(ctx, vhostName, l.name, *l.value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// vhostMetadataManaged returns true if the operator manages the vhost metadata. The default
+// vhost "/" always exists, its metadata is only touched when it is set in the spec.
+func vhostMetadataManaged(vhostName string, spec *rabbitmqv1.RabbitMQVhostSpec) bool {
+	return vhostName != "/" || spec.Description != "" || len(spec.Tags) > 0 || spec.DefaultQueueType != ""
+}
+
+// vhostMetadataDrifted returns true if the vhost metadata in RabbitMQ differs from the spec.
+// The default queue type is only compared when set in the spec, since RabbitMQ
+// can't unset it again.
+func vhostMetadataDrifted(current *rabbitmqapi.Vhost, spec *rabbitmqv1.RabbitMQVhostSpec) bool {
+	return len(rabbitmqapi.DiffVhost(current, spec.Description, spec.Tags, spec.DefaultQueueType)) > 0
+}
+
+// vhostDrift returns the differences between the vhost metadata and limits in RabbitMQ and the spec
+func vhostDrift(ctx context.Context, apiClient *rabbitmqapi.Client, vhostName string, spec *rabbitmqv1.RabbitMQVhostSpec) ([]string, error) {
+	drift := []string{}
+	if vhostMetadataManaged(vhostName, spec) {
+		metadataDrift, err := apiClient.CompareVhost(ctx, vhostName, spec.Description, spec.Tags, spec.DefaultQueueType)
+		if err != nil {
+			return nil, err
+		}
+		drift = append(drift, metadataDrift...)
+	}
+
+	limits := map[string]int64{}
+	if spec.Limits != nil {
+		if spec.Limits.MaxConnections != nil {
+			limits[rabbitmqapi.VhostLimitMaxConnections] = *spec.Limits.MaxConnections
+		}
+		if spec.Limits.MaxQueues != nil {
+			limits[rabbitmqapi.VhostLimitMaxQueues] = *spec.Limits.MaxQueues
+		}
+	}
+	limitsDrift, err := apiClient.CompareVhostLimits(ctx, vhostName, limits)
+	if err != nil {
+		return nil, err
+	}
+	return append(drift, limitsDrift...), nil
 }
 
 func (r *RabbitMQVhostReconciler) reconcileDelete(ctx context.Context, instance *rabbitmqv1.RabbitMQVhost, h *helper.Helper) (ctrl.Result, error) {
@@ -283,6 +405,13 @@ func (r *RabbitMQVhostReconciler) reconcileDelete(ctx context.Context, instance
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	// Adopted vhosts were not created by the operator, keep them in RabbitMQ unless requested otherwise
+	if instance.Spec.Adoption.KeepAdopted(instance.Status.Adopted) {
+		Log.Info("Keeping adopted vhost in RabbitMQ", "vhost", instance.Name)
+		controllerutil.RemoveFinalizer(instance, vhostFinalizer)
+		return ctrl.Result{}, nil
+	}
+
 	// Get RabbitMQ cluster
 	rabbit := &rabbitmqclusterv2.RabbitmqCluster{}
 	err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.RabbitmqClusterName, Namespace: instance.Namespace}, rabbit)
@@ -309,14 +438,11 @@ func (r *RabbitMQVhostReconciler) reconcileDelete(ctx context.Context, instance
 	}
 
 	// Create API client
-	baseURL := getManagementURL(rabbit, rabbitSecret)
-	tlsEnabled := rabbit.Spec.TLS.SecretName != ""
-	caCert, err := getTLSCACert(ctx, h, rabbit, instance.Namespace)
+	apiClient, err := getAPIClient(ctx, h, rabbit, rabbitSecret)
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.RabbitMQVhostReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.RabbitMQVhostReadyErrorMessage, err.Error()))
 		return ctrl.Result{}, err
 	}
-	apiClient := rabbitmqapi.NewClient(baseURL, string(rabbitSecret.Data["username"]), string(rabbitSecret.Data["password"]), tlsEnabled, caCert)
 
 	// Delete vhost (skip default)
 	vhostName := instance.Spec.Name
@@ -325,7 +451,7 @@ func (r *RabbitMQVhostReconciler) reconcileDelete(ctx context.Context, instance
 	}
 	if vhostName != "/" {
 		// DeleteVhost already treats 404 as success
-		if err := apiClient.DeleteVhost(vhostName); err != nil {
+		if err := apiClient.DeleteVhost(ctx, vhostName); err != nil {
 			// Return error to trigger retry - see rabbitmqpolicy_controller.go for detailed rationale
 			return ctrl.Result{}, fmt.Errorf("failed to delete vhost %s from RabbitMQ: %w", vhostName, err)
 		}
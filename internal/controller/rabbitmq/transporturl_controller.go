@@ -19,6 +19,7 @@ package rabbitmq
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -88,8 +89,70 @@ const (
 
 	// Annotation key for tracking when the TransportURL finalizer was removed
 	finalizerRemovedAtAnnotation = "rabbitmq.openstack.org/finalizer-removed-at"
+
+	// Time format of the suffix appended to the username on credential rotation
+	credentialRotationSuffixFormat = "20060102150405"
 )
 
+// reconcileCredentialRotation returns the username of the RabbitMQUser to create for
+// a TransportURL with a credential rotation policy. When a rotation is due a new username
+// gets generated, which results in a new RabbitMQUser with a new password and the old
+// user gets cleaned up by the existing blue/green switchover.
+// Returns the username, the duration until the next rotation and any error encountered.
+func (r *TransportURLReconciler) reconcileCredentialRotation(
+	ctx context.Context,
+	instance *rabbitmqv1.TransportURL,
+) (username string, requeueAfter time.Duration, err error) {
+	Log := r.GetLogger(ctx)
+
+	rotation := instance.Spec.CredentialRotation
+	baseUsername := instance.Spec.Username
+	if baseUsername == "" {
+		baseUsername = instance.Name
+	}
+
+	// Without a previous rotation the first one is scheduled relative to the creation of the TransportURL
+	last := instance.CreationTimestamp.Time
+	if instance.Status.LastCredentialRotation != nil {
+		last = instance.Status.LastCredentialRotation.Time
+	}
+	next, err := rotation.NextRotation(last)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid credential rotation policy: %w", err)
+	}
+
+	// Only rotate once the secret of the TransportURL has the credentials of the last
+	// rotation, so that a switchover still in progress is not interrupted by the next one
+	username = rotatedUsername(baseUsername, instance.Status.LastCredentialRotation)
+	now := time.Now().UTC().Truncate(time.Second)
+	if !next.After(now) && instance.Status.RabbitmqUsername == username {
+		Log.Info("Rotating credentials", "scheduled", next.Format(time.RFC3339))
+		instance.Status.LastCredentialRotation = &metav1.Time{Time: now}
+		if next, err = rotation.NextRotation(now); err != nil {
+			return "", 0, fmt.Errorf("invalid credential rotation policy: %w", err)
+		}
+		username = rotatedUsername(baseUsername, instance.Status.LastCredentialRotation)
+	}
+	instance.Status.NextCredentialRotation = &metav1.Time{Time: next}
+
+	requeueAfter = time.Until(next)
+	if requeueAfter <= 0 {
+		// rotation is due but waits for the TransportURL to get ready
+		requeueAfter = ownerReadinessCheckInterval
+	}
+
+	return username, requeueAfter, nil
+}
+
+// rotatedUsername returns the username of the credentials created by the last rotation,
+// which is the base username until the first rotation
+func rotatedUsername(baseUsername string, lastRotation *metav1.Time) string {
+	if lastRotation == nil {
+		return baseUsername
+	}
+	return fmt.Sprintf("%s-%s", baseUsername, lastRotation.UTC().Format(credentialRotationSuffixFormat))
+}
+
 // isOwnerServiceReady checks if the owner service (Cinder, Nova, etc.) that owns this TransportURL is ready.
 // Returns:
 //   - ready: true if the owner is ready, false if not ready
@@ -496,6 +559,42 @@ func (r *TransportURLReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	return r.reconcileNormal(ctx, instance, helper)
 }
 
+// transportTarget - a RabbitMQ cluster, vhost and user a transport URL gets configured for
+type transportTarget struct {
+	rabbitmqClusterName string
+	userRef             string
+	username            string
+	vhost               string
+	permissions         *rabbitmqv1.TransportURLPermissions
+	// resourcePrefix - prefix of the RabbitMQVhost and RabbitMQUser created for the target
+	resourcePrefix string
+	// rotated - the username is generated by the credential rotation policy
+	rotated bool
+
+	// resolved by reconcileNormal
+	rabbit         *rabbitmqclusterv2.RabbitmqCluster
+	rabbitSecret   *corev1.Secret
+	createdUserRef string
+	vhostRef       string
+	finalUsername  string
+	finalPassword  string
+	vhostName      string
+}
+
+// sharesUserWith returns true if both targets create a RabbitMQUser for the same user of the same cluster
+func (t *transportTarget) sharesUserWith(other *transportTarget) bool {
+	return t.userRef == "" && other.userRef == "" && t.username != "" &&
+		t.username == other.username && t.rabbitmqClusterName == other.rabbitmqClusterName
+}
+
+// vhostOrDefault returns the vhost of the target, "/" if not specified
+func (t *transportTarget) vhostOrDefault() string {
+	if t.vhost == "" {
+		return "/"
+	}
+	return t.vhost
+}
+
 func (r *TransportURLReconciler) reconcileNormal(ctx context.Context, instance *rabbitmqv1.TransportURL, helper *helper.Helper) (ctrl.Result, error) {
 	Log := r.GetLogger(ctx)
 	Log.Info("Reconciling Service")
@@ -503,155 +602,151 @@ func (r *TransportURLReconciler) reconcileNormal(ctx context.Context, instance *
 	// Track if we need to requeue for old user cleanup
 	var requeueAfter time.Duration
 
-	// Get RabbitMQ cluster
-	rabbit, err := getRabbitmqCluster(ctx, helper, instance)
-	if err != nil {
-		return ctrl.Result{}, err
-	}
-
-	// Wait for RabbitMQ cluster to be ready
-	rabbitReady := false
-	for _, condition := range rabbit.Status.Conditions {
-		if condition.Reason == "AllPodsAreReady" && condition.Status == "True" {
-			rabbitReady = true
-			break
-		}
-	}
-	if !rabbitReady {
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			rabbitmqv1.TransportURLReadyCondition,
-			condition.RequestedReason,
-			condition.SeverityInfo,
-			rabbitmqv1.TransportURLInProgressMessage))
-		return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
-	}
-
-	// Get cluster admin secret for connection details
-	rabbitSecret, _, err := oko_secret.GetSecret(ctx, helper, rabbit.Status.DefaultUser.SecretReference.Name, instance.Namespace)
-	if err != nil {
-		if k8s_errors.IsNotFound(err) {
+	// Determine the username, generated from the rotation policy if there is one
+	username := instance.Spec.Username
+	var rotationRequeueAfter time.Duration
+	rotated := instance.Spec.CredentialRotation != nil && instance.Spec.UserRef == ""
+	if rotated {
+		var err error
+		username, rotationRequeueAfter, err = r.reconcileCredentialRotation(ctx, instance)
+		if err != nil {
 			instance.Status.Conditions.Set(condition.FalseCondition(
 				rabbitmqv1.TransportURLReadyCondition,
 				condition.ErrorReason,
 				condition.SeverityWarning,
-				rabbitmqv1.TransportURLInProgressMessage))
-			return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+				rabbitmqv1.TransportURLReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
 		}
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			rabbitmqv1.TransportURLReadyCondition,
-			condition.ErrorReason,
-			condition.SeverityWarning,
-			rabbitmqv1.TransportURLReadyErrorMessage,
-			err.Error()))
-		return ctrl.Result{}, err
-	}
-
-	// Extract connection details from secret
-	port, ok := rabbitSecret.Data["port"]
-	if !ok {
-		err := fmt.Errorf("port does not exist in rabbitmq secret %s", rabbitSecret.Name)
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			rabbitmqv1.TransportURLReadyCondition,
-			condition.ErrorReason,
-			condition.SeverityWarning,
-			rabbitmqv1.TransportURLReadyErrorMessage,
-			err.Error()))
-		return ctrl.Result{}, err
+	} else {
+		instance.Status.LastCredentialRotation = nil
+		instance.Status.NextCredentialRotation = nil
 	}
 
-	adminUsername, ok := rabbitSecret.Data["username"]
-	if !ok {
-		err := fmt.Errorf("username does not exist in rabbitmq secret %s", rabbitSecret.Name)
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			rabbitmqv1.TransportURLReadyCondition,
-			condition.ErrorReason,
-			condition.SeverityWarning,
-			rabbitmqv1.TransportURLReadyErrorMessage,
-			err.Error()))
-		return ctrl.Result{}, err
+	// The RPC target and the optional notifications target
+	rpcTarget := &transportTarget{
+		rabbitmqClusterName: instance.Spec.RabbitmqClusterName,
+		userRef:             instance.Spec.UserRef,
+		username:            username,
+		vhost:               instance.Spec.Vhost,
+		permissions:         instance.Spec.Permissions,
+		resourcePrefix:      instance.Name,
+		rotated:             rotated,
 	}
-
-	adminPassword, ok := rabbitSecret.Data["password"]
-	if !ok {
-		err := fmt.Errorf("password does not exist in rabbitmq secret %s", rabbitSecret.Name)
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			rabbitmqv1.TransportURLReadyCondition,
-			condition.ErrorReason,
-			condition.SeverityWarning,
-			rabbitmqv1.TransportURLReadyErrorMessage,
-			err.Error()))
-		return ctrl.Result{}, err
+	targets := []*transportTarget{rpcTarget}
+
+	var notificationsTarget *transportTarget
+	if n := instance.Spec.Notifications; n != nil {
+		notificationsTarget = &transportTarget{
+			rabbitmqClusterName: n.RabbitmqClusterName,
+			userRef:             n.UserRef,
+			username:            n.Username,
+			vhost:               n.Vhost,
+			permissions:         n.Permissions,
+			resourcePrefix:      instance.Name + "-notifications",
+		}
+		if notificationsTarget.rabbitmqClusterName == "" {
+			notificationsTarget.rabbitmqClusterName = instance.Spec.RabbitmqClusterName
+		}
+		// A RabbitMQ user can only have one RabbitMQUser, which grants permissions on one vhost
+		if notificationsTarget.sharesUserWith(rpcTarget) && notificationsTarget.vhostOrDefault() != rpcTarget.vhostOrDefault() {
+			err := fmt.Errorf("notifications username %s is the RPC username on cluster %s, but uses another vhost",
+				notificationsTarget.username, notificationsTarget.rabbitmqClusterName)
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1.TransportURLReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				rabbitmqv1.TransportURLReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+		targets = append(targets, notificationsTarget)
 	}
 
-	// Determine credentials and vhost
-	var finalUsername, finalPassword, vhostName string
-	var userRef, vhostRef string
+	for _, target := range targets {
+		// Get RabbitMQ cluster
+		rabbit, err := getRabbitmqCluster(ctx, helper, instance.Namespace, target.rabbitmqClusterName)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
 
-	if instance.Spec.UserRef != "" {
-		userRef = instance.Spec.UserRef
-	} else if instance.Spec.Username != "" {
-		// Create RabbitMQVhost if needed
-		vhostName = instance.Spec.Vhost
-		if vhostName == "" {
-			vhostName = "/"
+		// Wait for RabbitMQ cluster to be ready
+		rabbitReady := false
+		for _, condition := range rabbit.Status.Conditions {
+			if condition.Reason == "AllPodsAreReady" && condition.Status == "True" {
+				rabbitReady = true
+				break
+			}
 		}
-		if vhostName != "/" {
-			vhostRef = fmt.Sprintf("%s-%s-vhost", instance.Name, vhostName)
-			vhost := &rabbitmqv1.RabbitMQVhost{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      vhostRef,
-					Namespace: instance.Namespace,
-				},
+		if !rabbitReady {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1.TransportURLReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				rabbitmqv1.TransportURLInProgressMessage))
+			return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+		}
+		target.rabbit = rabbit
+
+		// Get cluster admin secret for connection details
+		rabbitSecret, _, err := oko_secret.GetSecret(ctx, helper, rabbit.Status.DefaultUser.SecretReference.Name, instance.Namespace)
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					rabbitmqv1.TransportURLReadyCondition,
+					condition.ErrorReason,
+					condition.SeverityWarning,
+					rabbitmqv1.TransportURLInProgressMessage))
+				return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
 			}
-			// Note: During normal reconciliation (not deletion), we return errors rather than
-			// just logging them, as we need these operations to succeed for correct functionality.
-			if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, vhost, func() error {
-				if err := controllerutil.SetControllerReference(instance, vhost, r.Scheme); err != nil {
-					return err
-				}
-				// AddFinalizer is idempotent - safe to call even if finalizer already exists
-				controllerutil.AddFinalizer(vhost, rabbitmqv1.TransportURLFinalizer)
-				vhost.Spec.RabbitmqClusterName = instance.Spec.RabbitmqClusterName
-				vhost.Spec.Name = vhostName
-				return nil
-			}); err != nil {
-				instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.TransportURLReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.TransportURLReadyErrorMessage, err.Error()))
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1.TransportURLReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				rabbitmqv1.TransportURLReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+
+		// Validate the connection details in the secret
+		for _, key := range []string{"port", "username", "password"} {
+			if _, ok := rabbitSecret.Data[key]; !ok {
+				err := fmt.Errorf("%s does not exist in rabbitmq secret %s", key, rabbitSecret.Name)
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					rabbitmqv1.TransportURLReadyCondition,
+					condition.ErrorReason,
+					condition.SeverityWarning,
+					rabbitmqv1.TransportURLReadyErrorMessage,
+					err.Error()))
 				return ctrl.Result{}, err
 			}
 		}
+		target.rabbitSecret = rabbitSecret
 
-		// Create RabbitMQUser - use username in resource name for blue/green rotation
-		userRef = fmt.Sprintf("%s-%s-user", instance.Name, instance.Spec.Username)
-		user := &rabbitmqv1.RabbitMQUser{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      userRef,
-				Namespace: instance.Namespace,
-			},
+		// The notifications use the RabbitMQUser of the RPC target for the same user
+		if target != rpcTarget && target.sharesUserWith(rpcTarget) {
+			target.createdUserRef = rpcTarget.createdUserRef
+			target.vhostRef = rpcTarget.vhostRef
+			continue
 		}
-		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, user, func() error {
-			if err := controllerutil.SetControllerReference(instance, user, r.Scheme); err != nil {
-				return err
-			}
-			// Add TransportURL finalizer to protect user while in use
-			controllerutil.AddFinalizer(user, rabbitmqv1.TransportURLFinalizer)
-			// Add temporary blocking finalizer to prevent automatic cleanup
-			// This ensures users are not deleted automatically during credential rotation
-			// until proper safe-to-delete logic is implemented
-			controllerutil.AddFinalizer(user, rabbitmqv1.RabbitMQUserCleanupBlockedFinalizer)
-			user.Spec.RabbitmqClusterName = instance.Spec.RabbitmqClusterName
-			user.Spec.Username = instance.Spec.Username
-			user.Spec.VhostRef = vhostRef
-			user.Spec.Permissions = rabbitmqv1.RabbitMQUserPermissions{
-				Configure: ".*",
-				Read:      ".*",
-				Write:     ".*",
-			}
-			return nil
-		}); err != nil {
+
+		// Create the RabbitMQVhost and RabbitMQUser of the target if needed
+		if err := r.reconcileTransportTargetUser(ctx, instance, target); err != nil {
 			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.TransportURLReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.TransportURLReadyErrorMessage, err.Error()))
 			return ctrl.Result{}, err
 		}
+	}
 
+	// Users and vhosts used by any of the targets
+	usedUserRefs := map[string]bool{}
+	usedVhostRefs := map[string]bool{}
+	for _, target := range targets {
+		if target.createdUserRef != "" {
+			usedUserRefs[target.createdUserRef] = true
+		}
+		if target.vhostRef != "" {
+			usedVhostRefs[target.vhostRef] = true
+		}
 	}
 
 	// Remove TransportURL finalizer from previously used users that are no longer referenced
@@ -664,9 +759,9 @@ func (r *TransportURLReconciler) reconcileNormal(ctx context.Context, instance *
 			oldUser := &userList.Items[i]
 			isOwned := object.CheckOwnerRefExist(instance.GetUID(), oldUser.GetOwnerReferences())
 
-			// If owned by this TransportURL but not the current user, handle cleanup
-			// When userRef is empty (using default credentials), all owned users should be cleaned up
-			if isOwned && oldUser.Name != userRef {
+			// If owned by this TransportURL but not used by any target, handle cleanup
+			// When no user is used (default credentials), all owned users should be cleaned up
+			if isOwned && !usedUserRefs[oldUser.Name] {
 				// Clean up old user - pass NamespacedName instead of full object
 				// This ensures we always fetch fresh data inside cleanupOldUser
 				oldUserName := types.NamespacedName{Name: oldUser.Name, Namespace: oldUser.Namespace}
@@ -700,8 +795,8 @@ func (r *TransportURLReconciler) reconcileNormal(ctx context.Context, instance *
 				continue
 			}
 
-			// Skip the current vhost - only clean up orphaned vhosts from previous specs
-			if oldVhost.Name == vhostRef {
+			// Skip the current vhosts - only clean up orphaned vhosts from previous specs
+			if usedVhostRefs[oldVhost.Name] {
 				continue
 			}
 
@@ -717,77 +812,42 @@ func (r *TransportURLReconciler) reconcileNormal(ctx context.Context, instance *
 		}
 	}
 
-	if userRef != "" {
-		// Wait for RabbitMQUser to be ready
-		rabbitUser := &rabbitmqv1.RabbitMQUser{}
-		if err = r.Get(ctx, types.NamespacedName{Name: userRef, Namespace: instance.Namespace}, rabbitUser); err != nil {
-			if k8s_errors.IsNotFound(err) {
-				instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.TransportURLReadyCondition, condition.RequestedReason, condition.SeverityInfo, rabbitmqv1.TransportURLInProgressMessage))
-				Log.Info(fmt.Sprintf("RabbitMQUser %s not found, waiting for it to be created", userRef))
-				return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
-			}
-			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.TransportURLReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.TransportURLReadyErrorMessage, err.Error()))
-			return ctrl.Result{}, err
+	// Determine the credentials and vhost of each target
+	for _, target := range targets {
+		result, err := r.getTransportTargetCredentials(ctx, helper, instance, target)
+		if err != nil || !result.IsZero() {
+			return result, err
 		}
-		if rabbitUser.Status.SecretName == "" {
-			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.TransportURLReadyCondition, condition.RequestedReason, condition.SeverityInfo, rabbitmqv1.TransportURLInProgressMessage))
-			Log.Info(fmt.Sprintf("RabbitMQUser %s not ready yet (no secret created)", userRef))
-			return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
-		}
-		// Wait for vhost status to match spec when a custom vhost is specified
-		// This handles both initial creation (status is empty) and updates (status has old value)
-		if instance.Spec.Vhost != "" && instance.Spec.Vhost != rabbitUser.Status.Vhost {
-			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.TransportURLReadyCondition, condition.RequestedReason, condition.SeverityInfo, rabbitmqv1.TransportURLInProgressMessage))
-			Log.Info(fmt.Sprintf("RabbitMQUser %s vhost status (%s) doesn't match spec (%s), waiting for update", userRef, rabbitUser.Status.Vhost, instance.Spec.Vhost))
-			return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
-		}
-
-		// Get credentials from user secret
-		userSecret, _, err := oko_secret.GetSecret(ctx, helper, rabbitUser.Status.SecretName, instance.Namespace)
-		if err != nil {
-			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.TransportURLReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.TransportURLReadyErrorMessage, err.Error()))
-			return ctrl.Result{}, err
-		}
-		finalUsername = string(userSecret.Data["username"])
-		finalPassword = string(userSecret.Data["password"])
-		vhostName = rabbitUser.Status.Vhost
-	} else {
-		// Use default cluster admin credentials
-		finalUsername = string(adminUsername)
-		finalPassword = string(adminPassword)
-		vhostName = "/"
 	}
 
+	rabbit := rpcTarget.rabbit
 	tlsEnabled := rabbit.Spec.TLS.SecretName != ""
 	Log.Info(fmt.Sprintf("rabbitmq cluster %s has TLS enabled: %t", rabbit.Name, tlsEnabled))
 
 	// Get RabbitMq CR for both secret generation and status update
 	rabbitmqCR := &rabbitmqv1.RabbitMq{}
-	err = r.Get(ctx, types.NamespacedName{Name: instance.Spec.RabbitmqClusterName, Namespace: instance.Namespace}, rabbitmqCR)
+	err := r.Get(ctx, types.NamespacedName{Name: instance.Spec.RabbitmqClusterName, Namespace: instance.Namespace}, rabbitmqCR)
 
-	// Build list of hosts - use ServiceHostnames from status if available, otherwise use host from secret
-	var hosts []string
-	if err == nil && len(rabbitmqCR.Status.ServiceHostnames) > 0 {
-		hosts = rabbitmqCR.Status.ServiceHostnames
-		Log.Info(fmt.Sprintf("Using per-pod service hostnames: %v", hosts))
-	} else {
-		h, ok := rabbitSecret.Data["host"]
-		if !ok {
-			err := fmt.Errorf("host does not exist in rabbitmq secret %s", rabbitSecret.Name)
-			instance.Status.Conditions.Set(condition.FalseCondition(
-				rabbitmqv1.TransportURLReadyCondition,
-				condition.ErrorReason,
-				condition.SeverityWarning,
-				rabbitmqv1.TransportURLReadyErrorMessage,
-				err.Error()))
-			return ctrl.Result{}, err
-		}
-		hosts = []string{string(h)}
-		Log.Info(fmt.Sprintf("Using single host from secret: %s", string(h)))
+	// Build list of hosts - use ServiceHostnames from status if available and preferred, otherwise use host from secret
+	var hostsCR *rabbitmqv1.RabbitMq
+	if err == nil && instance.Spec.UsePerPodServices() {
+		hostsCR = rabbitmqCR
+	}
+	hosts, hostsErr := getRabbitMQHosts(hostsCR, rpcTarget.rabbitSecret)
+	if hostsErr != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			rabbitmqv1.TransportURLReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			rabbitmqv1.TransportURLReadyErrorMessage,
+			hostsErr.Error()))
+		return ctrl.Result{}, hostsErr
 	}
+	Log.Info(fmt.Sprintf("Using hosts: %v", hosts))
 
 	// Determine quorum setting for secret generation
 	quorum := false
+	streams := false
 	if err != nil {
 		Log.Info(fmt.Sprintf("Could not fetch RabbitMQ CR: %v", err))
 		// Default to false for quorum if we can't fetch the CR
@@ -798,7 +858,10 @@ func (r *TransportURLReconciler) reconcileNormal(ctx context.Context, instance *
 		// Spec represents the configured queue type and is set immediately when the CR is created,
 		// while Status.QueueType is updated asynchronously after cluster initialization.
 		// This prevents a race condition where TransportURL reconciles before Status.QueueType is set.
-		if rabbitmqCR.Spec.QueueType != nil {
+		// While the queues get migrated from Mirrored to Quorum, clients keep using the classic queues.
+		if rabbitmqCR.IsQueueMigrationPending() {
+			Log.Info("Setting quorum to: false until the queues are migrated to quorum queues")
+		} else if rabbitmqCR.Spec.QueueType != nil {
 			quorum = *rabbitmqCR.Spec.QueueType == rabbitmqv1.QueueTypeQuorum
 			Log.Info(fmt.Sprintf("Setting quorum to: %t based on spec QueueType", quorum))
 		} else if rabbitmqCR.Status.QueueType != "" {
@@ -812,6 +875,9 @@ func (r *TransportURLReconciler) reconcileNormal(ctx context.Context, instance *
 				"namespace", rabbitmqCR.Namespace)
 		}
 
+		// Consumers of stream vhosts use stream queues for fanout
+		streams = hasStreamsVhost(rabbitmqCR, rpcTarget.vhostName)
+
 		// Update QueueType and add annotation to signal change
 		if rabbitmqCR.Status.QueueType != instance.Status.QueueType {
 			Log.Info(fmt.Sprintf("Updating transportURL Status.QueueType from %s to %s", instance.Status.QueueType, rabbitmqCR.Status.QueueType))
@@ -823,10 +889,97 @@ func (r *TransportURLReconciler) reconcileNormal(ctx context.Context, instance *
 			}
 			instance.Annotations["rabbitmq.openstack.org/queuetype-hash"] = fmt.Sprintf("%s-%d", rabbitmqCR.Status.QueueType, time.Now().Unix())
 		}
+
+		// Publishers are blocked while a resource alarm is in effect in the cluster
+		if alarms := rabbitmqCR.GetAlarms(); len(alarms) > 0 {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1.BrokerHealthyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				rabbitmqv1.BrokerAlarmsMessage,
+				rabbitmqCR.Name,
+				strings.Join(alarms, ", ")))
+		} else {
+			instance.Status.Conditions.Remove(rabbitmqv1.BrokerHealthyCondition)
+		}
+	}
+
+	// Build the notifications transport URL
+	notificationTransportURL := ""
+	var notificationStreamURLs []string
+	if notificationsTarget != nil {
+		notificationRabbit := notificationsTarget.rabbit
+		notificationRabbitmqCR := &rabbitmqv1.RabbitMq{}
+		err := r.Get(ctx, types.NamespacedName{Name: notificationsTarget.rabbitmqClusterName, Namespace: instance.Namespace}, notificationRabbitmqCR)
+		if err != nil {
+			notificationRabbitmqCR = nil
+		}
+		notificationHostsCR := notificationRabbitmqCR
+		if !instance.Spec.UsePerPodServices() {
+			notificationHostsCR = nil
+		}
+		notificationHosts, err := getRabbitMQHosts(notificationHostsCR, notificationsTarget.rabbitSecret)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1.TransportURLReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				rabbitmqv1.TransportURLReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+		notificationTransportURL = buildTransportURL(
+			notificationsTarget.finalUsername,
+			notificationsTarget.finalPassword,
+			notificationHosts,
+			string(notificationsTarget.rabbitSecret.Data["port"]),
+			notificationsTarget.vhostName,
+			notificationRabbit.Spec.TLS.SecretName != "")
+
+		// Notification consumers of stream vhosts read the notifications from streams
+		if hasStreamsVhost(notificationRabbitmqCR, notificationsTarget.vhostName) {
+			notificationStreamURLs = buildStreamURIs(
+				notificationsTarget.finalUsername,
+				notificationsTarget.finalPassword,
+				notificationHosts,
+				transportURLVhostName(notificationsTarget.vhostName),
+				notificationRabbit.Spec.TLS.SecretName != "")
+		}
+	}
+
+	// Get the CA of the cluster for clients which use the structured secret format
+	var caCert []byte
+	if tlsEnabled && instance.Spec.HasSecretFormat(rabbitmqv1.TransportURLSecretFormatStructured) {
+		caSecretName := rabbit.Spec.TLS.CaSecretName
+		if caSecretName == "" {
+			caSecretName = rabbit.Spec.TLS.SecretName
+		}
+		caSecret, _, err := oko_secret.GetSecret(ctx, helper, caSecretName, instance.Namespace)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1.TransportURLReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				rabbitmqv1.TransportURLReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+		caCert = caSecret.Data["ca.crt"]
+	}
+
+	// The client certificate gets mounted by the service, make sure it exists before
+	// pointing the service to it
+	externalAuth := false
+	if tlsEnabled && instance.Spec.ClientCert != nil {
+		result, err := r.verifyClientCertSecret(ctx, helper, instance)
+		if err != nil || !result.IsZero() {
+			return result, err
+		}
+		externalAuth = rabbitmqCR.Spec.MTLS.UseExternalAuth()
 	}
 
 	// Create a new secret with the transport URL for this CR
-	secret := r.createTransportURLSecret(instance, finalUsername, finalPassword, hosts, string(port), vhostName, tlsEnabled, quorum)
+	secret := r.createTransportURLSecret(instance, rpcTarget.finalUsername, rpcTarget.finalPassword, hosts, string(rpcTarget.rabbitSecret.Data["port"]), rpcTarget.vhostName, tlsEnabled, quorum, streams, caCert, notificationTransportURL, notificationStreamURLs, externalAuth)
 	_, op, err := oko_secret.CreateOrPatchSecret(ctx, helper, instance, secret)
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
@@ -848,15 +1001,24 @@ func (r *TransportURLReconciler) reconcileNormal(ctx context.Context, instance *
 
 	// Update the CR status with actual values used
 	instance.Status.SecretName = secret.Name
-	instance.Status.RabbitmqUsername = finalUsername
-	instance.Status.RabbitmqVhost = vhostName
-	instance.Status.RabbitmqUserRef = userRef
+	instance.Status.RabbitmqUsername = rpcTarget.finalUsername
+	instance.Status.RabbitmqVhost = rpcTarget.vhostName
+	instance.Status.RabbitmqUserRef = rpcTarget.createdUserRef
+	instance.Status.Notifications = nil
+	if notificationsTarget != nil {
+		instance.Status.Notifications = &rabbitmqv1.TransportURLNotificationsStatus{
+			RabbitmqClusterName: notificationsTarget.rabbitmqClusterName,
+			RabbitmqUsername:    notificationsTarget.finalUsername,
+			RabbitmqVhost:       notificationsTarget.vhostName,
+			RabbitmqUserRef:     notificationsTarget.createdUserRef,
+		}
+	}
 
 	instance.Status.Conditions.MarkTrue(rabbitmqv1.TransportURLReadyCondition, rabbitmqv1.TransportURLReadyMessage)
 
 	// We reached the end of the Reconcile, update the Ready condition based on
 	// the sub conditions
-	if instance.Status.Conditions.AllSubConditionIsTrue() {
+	if allSubConditionsTrue(instance.Status.Conditions) {
 		instance.Status.Conditions.MarkTrue(
 			condition.ReadyCondition, condition.ReadyMessage)
 	}
@@ -868,20 +1030,173 @@ func (r *TransportURLReconciler) reconcileNormal(ctx context.Context, instance *
 		Log.Info("Scheduling requeue for old user cleanup", "after", requeueAfter.String())
 		return ctrl.Result{RequeueAfter: requeueAfter}, nil
 	}
+
+	// Requeue for the next scheduled credential rotation
+	if rotationRequeueAfter > 0 {
+		Log.Info("Scheduling requeue for credential rotation", "after", rotationRequeueAfter.String())
+		return ctrl.Result{RequeueAfter: rotationRequeueAfter}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
-// Create k8s secret with transport URL
-func (r *TransportURLReconciler) createTransportURLSecret(
+// reconcileTransportTargetUser creates the RabbitMQVhost and RabbitMQUser of the target if
+// a Username is specified and records the RabbitMQUser and RabbitMQVhost used by it.
+func (r *TransportURLReconciler) reconcileTransportTargetUser(ctx context.Context, instance *rabbitmqv1.TransportURL, target *transportTarget) error {
+	if target.userRef != "" {
+		target.createdUserRef = target.userRef
+		return nil
+	}
+	if target.username == "" {
+		return nil
+	}
+
+	// Create RabbitMQVhost if needed
+	vhostName := target.vhost
+	if vhostName == "" {
+		vhostName = "/"
+	}
+	if vhostName != "/" {
+		target.vhostRef = fmt.Sprintf("%s-%s-vhost", target.resourcePrefix, vhostName)
+		vhost := &rabbitmqv1.RabbitMQVhost{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      target.vhostRef,
+				Namespace: instance.Namespace,
+			},
+		}
+		// Note: During normal reconciliation (not deletion), we return errors rather than
+		// just logging them, as we need these operations to succeed for correct functionality.
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, vhost, func() error {
+			if err := controllerutil.SetControllerReference(instance, vhost, r.Scheme); err != nil {
+				return err
+			}
+			// AddFinalizer is idempotent - safe to call even if finalizer already exists
+			controllerutil.AddFinalizer(vhost, rabbitmqv1.TransportURLFinalizer)
+			vhost.Spec.RabbitmqClusterName = target.rabbitmqClusterName
+			vhost.Spec.Name = vhostName
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Create RabbitMQUser - use username in resource name for blue/green rotation
+	target.createdUserRef = fmt.Sprintf("%s-%s-user", target.resourcePrefix, target.username)
+	user := &rabbitmqv1.RabbitMQUser{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      target.createdUserRef,
+			Namespace: instance.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, user, func() error {
+		if err := controllerutil.SetControllerReference(instance, user, r.Scheme); err != nil {
+			return err
+		}
+		// Add TransportURL finalizer to protect user while in use
+		controllerutil.AddFinalizer(user, rabbitmqv1.TransportURLFinalizer)
+		// Add the blocking finalizer to prevent automatic cleanup unless disabled,
+		// then replaced users get deleted by the blue/green switchover. Rotated users
+		// are never blocked, the rotation has to revoke the previous credentials
+		if instance.Spec.BlocksUserCleanup() && !target.rotated {
+			controllerutil.AddFinalizer(user, rabbitmqv1.RabbitMQUserCleanupBlockedFinalizer)
+		} else {
+			controllerutil.RemoveFinalizer(user, rabbitmqv1.RabbitMQUserCleanupBlockedFinalizer)
+		}
+		user.Spec.RabbitmqClusterName = target.rabbitmqClusterName
+		user.Spec.Username = target.username
+		user.Spec.VhostRef = target.vhostRef
+		user.Spec.Permissions = target.permissions.UserPermissions()
+		user.Spec.TopicPermissions = nil
+		user.Spec.Tags = nil
+		if target.permissions != nil {
+			user.Spec.TopicPermissions = target.permissions.TopicPermissions
+			user.Spec.Tags = target.permissions.Tags
+		}
+		return nil
+	})
+	return err
+}
+
+// getTransportTargetCredentials waits for the RabbitMQUser of the target to be ready and
+// gets the credentials and vhost from it, or uses the cluster admin credentials if the
+// target has no user. A non zero result means the user is not ready yet.
+func (r *TransportURLReconciler) getTransportTargetCredentials(
+	ctx context.Context,
+	helper *helper.Helper,
 	instance *rabbitmqv1.TransportURL,
+	target *transportTarget,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	if target.createdUserRef == "" {
+		// Use default cluster admin credentials
+		target.finalUsername = string(target.rabbitSecret.Data["username"])
+		target.finalPassword = string(target.rabbitSecret.Data["password"])
+		target.vhostName = "/"
+		return ctrl.Result{}, nil
+	}
+
+	userRef := target.createdUserRef
+
+	// Wait for RabbitMQUser to be ready
+	rabbitUser := &rabbitmqv1.RabbitMQUser{}
+	if err := r.Get(ctx, types.NamespacedName{Name: userRef, Namespace: instance.Namespace}, rabbitUser); err != nil {
+		if k8s_errors.IsNotFound(err) {
+			instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.TransportURLReadyCondition, condition.RequestedReason, condition.SeverityInfo, rabbitmqv1.TransportURLInProgressMessage))
+			Log.Info(fmt.Sprintf("RabbitMQUser %s not found, waiting for it to be created", userRef))
+			return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+		}
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.TransportURLReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.TransportURLReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	// The transport URL needs the plaintext password, which the operator never gets for
+	// users with a password hash and has no secret of for users with an external secret
+	passwordSource := ""
+	if rabbitUser.Spec.HashedCredentials != nil {
+		passwordSource = "hashedCredentials"
+	} else if rabbitUser.Spec.ExternalSecret != nil {
+		passwordSource = "externalSecret"
+	}
+	if passwordSource != "" {
+		err := fmt.Errorf("RabbitMQUser %s uses %s, the transport URL needs a user with a password secret",
+			userRef, passwordSource)
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.TransportURLReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.TransportURLReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	if rabbitUser.Status.SecretName == "" {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.TransportURLReadyCondition, condition.RequestedReason, condition.SeverityInfo, rabbitmqv1.TransportURLInProgressMessage))
+		Log.Info(fmt.Sprintf("RabbitMQUser %s not ready yet (no secret created)", userRef))
+		return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+	}
+	// Wait for vhost status to match spec when a custom vhost is specified
+	// This handles both initial creation (status is empty) and updates (status has old value)
+	if target.vhost != "" && target.vhost != rabbitUser.Status.Vhost {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.TransportURLReadyCondition, condition.RequestedReason, condition.SeverityInfo, rabbitmqv1.TransportURLInProgressMessage))
+		Log.Info(fmt.Sprintf("RabbitMQUser %s vhost status (%s) doesn't match spec (%s), waiting for update", userRef, rabbitUser.Status.Vhost, target.vhost))
+		return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+	}
+
+	// Get credentials from user secret
+	userSecret, _, err := oko_secret.GetSecret(ctx, helper, rabbitUser.Status.SecretName, instance.Namespace)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(rabbitmqv1.TransportURLReadyCondition, condition.ErrorReason, condition.SeverityWarning, rabbitmqv1.TransportURLReadyErrorMessage, err.Error()))
+		return ctrl.Result{}, err
+	}
+	target.finalUsername = string(userSecret.Data["username"])
+	target.finalPassword = string(userSecret.Data["password"])
+	target.vhostName = rabbitUser.Status.Vhost
+
+	return ctrl.Result{}, nil
+}
+
+// buildTransportURL returns the oslo.messaging transport URL with all hosts
+func buildTransportURL(
 	username string,
 	password string,
 	hosts []string,
 	port string,
 	vhost string,
 	tlsEnabled bool,
-	quorum bool,
-) *corev1.Secret {
+) string {
 	query := "?ssl=0"
 	if tlsEnabled {
 		query = "?ssl=1"
@@ -897,7 +1212,26 @@ func (r *TransportURLReconciler) createTransportURLSecret(
 	for _, host := range hosts {
 		hostParts = append(hostParts, fmt.Sprintf("%s:%s@%s:%s", username, password, host, port))
 	}
-	transportURL := fmt.Sprintf("rabbit://%s%s%s", strings.Join(hostParts, ","), vhost, query)
+	return fmt.Sprintf("rabbit://%s%s%s", strings.Join(hostParts, ","), vhost, query)
+}
+
+// Create k8s secret with transport URL
+func (r *TransportURLReconciler) createTransportURLSecret(
+	instance *rabbitmqv1.TransportURL,
+	username string,
+	password string,
+	hosts []string,
+	port string,
+	vhost string,
+	tlsEnabled bool,
+	quorum bool,
+	streams bool,
+	caCert []byte,
+	notificationTransportURL string,
+	notificationStreamURLs []string,
+	externalAuth bool,
+) *corev1.Secret {
+	transportURL := buildTransportURL(username, password, hosts, port, vhost, tlsEnabled)
 
 	data := map[string][]byte{
 		"transport_url": []byte(transportURL),
@@ -905,6 +1239,80 @@ func (r *TransportURLReconciler) createTransportURLSecret(
 	if quorum {
 		data["quorumqueues"] = []byte("true")
 	}
+	if streams {
+		data["streams"] = []byte("true")
+	}
+	if notificationTransportURL != "" {
+		data["notification_transport_url"] = []byte(notificationTransportURL)
+	}
+	if len(notificationStreamURLs) > 0 {
+		data["notification_streams"] = []byte("true")
+	}
+
+	// Connection details as separate keys for clients which don't use oslo.messaging
+	if instance.Spec.HasSecretFormat(rabbitmqv1.TransportURLSecretFormatStructured) {
+		data["hosts"] = []byte(strings.Join(hosts, ","))
+		data["port"] = []byte(port)
+		data["username"] = []byte(username)
+		data["password"] = []byte(password)
+		vhostName := transportURLVhostName(vhost)
+		data["vhost"] = []byte(vhostName)
+		data["ssl"] = []byte(strconv.FormatBool(tlsEnabled))
+		data["amqp_urls"] = []byte(strings.Join(
+			buildAMQPURIs(username, password, hosts, port, vhostName, tlsEnabled, nil), ","))
+		if tlsEnabled && len(caCert) > 0 {
+			data["ca.crt"] = caCert
+		}
+		if streams {
+			streamPort := rabbitmqv1.StreamPort
+			if tlsEnabled {
+				streamPort = rabbitmqv1.StreamTLSPort
+			}
+			data["stream_port"] = []byte(strconv.Itoa(streamPort))
+			data["stream_urls"] = []byte(strings.Join(
+				buildStreamURIs(username, password, hosts, vhostName, tlsEnabled), ","))
+		}
+		if len(notificationStreamURLs) > 0 {
+			data["notification_stream_urls"] = []byte(strings.Join(notificationStreamURLs, ","))
+		}
+	}
+
+	// oslo.messaging configuration snippet
+	if instance.Spec.HasSecretFormat(rabbitmqv1.TransportURLSecretFormatOsloConfig) {
+		heartbeatTimeoutThreshold := transportURLDefaultHeartbeatTimeoutThreshold
+		if instance.Spec.HeartbeatTimeoutThreshold != nil {
+			heartbeatTimeoutThreshold = *instance.Spec.HeartbeatTimeoutThreshold
+		}
+		heartbeatRate := transportURLDefaultHeartbeatRate
+		if instance.Spec.HeartbeatRate != nil {
+			heartbeatRate = *instance.Spec.HeartbeatRate
+		}
+
+		var osloConfig strings.Builder
+		fmt.Fprintf(&osloConfig, "[DEFAULT]\ntransport_url = %s\n\n", transportURL)
+		osloConfig.WriteString("[oslo_messaging_rabbit]\n")
+		fmt.Fprintf(&osloConfig, "ssl = %t\n", tlsEnabled)
+		fmt.Fprintf(&osloConfig, "rabbit_quorum_queue = %t\n", quorum)
+		fmt.Fprintf(&osloConfig, "rabbit_transient_quorum_queue = %t\n", quorum)
+		if streams {
+			// Stream consumers need a bounded prefetch, an unlimited one gets refused by the broker
+			osloConfig.WriteString("rabbit_stream_fanout = true\n")
+			fmt.Fprintf(&osloConfig, "rabbit_qos_prefetch_count = %d\n", transportURLStreamPrefetchCount)
+		}
+		fmt.Fprintf(&osloConfig, "heartbeat_timeout_threshold = %d\n", heartbeatTimeoutThreshold)
+		fmt.Fprintf(&osloConfig, "heartbeat_rate = %d\n", heartbeatRate)
+		if tlsEnabled && instance.Spec.ClientCert != nil {
+			fmt.Fprintf(&osloConfig, "ssl_cert_file = %s\n", instance.Spec.ClientCert.CertFile)
+			fmt.Fprintf(&osloConfig, "ssl_key_file = %s\n", instance.Spec.ClientCert.KeyFile)
+			if externalAuth {
+				osloConfig.WriteString("rabbit_login_method = EXTERNAL\n")
+			}
+		}
+		if notificationTransportURL != "" {
+			fmt.Fprintf(&osloConfig, "\n[oslo_messaging_notifications]\ntransport_url = %s\n", notificationTransportURL)
+		}
+		data["oslo_messaging.conf"] = []byte(osloConfig.String())
+	}
 
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -915,10 +1323,60 @@ func (r *TransportURLReconciler) createTransportURLSecret(
 	}
 }
 
+// verifyClientCertSecret checks that the client certificate secret of the TransportURL exists
+// and holds a certificate and key. The reconcile gets requeued until the secret exists.
+func (r *TransportURLReconciler) verifyClientCertSecret(ctx context.Context, helper *helper.Helper, instance *rabbitmqv1.TransportURL) (ctrl.Result, error) {
+	secretName := instance.Spec.ClientCert.SecretName
+	clientCertSecret, _, err := oko_secret.GetSecret(ctx, helper, secretName, instance.Namespace)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			r.GetLogger(ctx).Info("Client certificate secret not found, waiting", "secret", secretName)
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1.TransportURLReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				rabbitmqv1.TransportURLReadyErrorMessage,
+				fmt.Sprintf("client certificate secret %s not found", secretName)))
+			return ctrl.Result{RequeueAfter: time.Duration(10) * time.Second}, nil
+		}
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			rabbitmqv1.TransportURLReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			rabbitmqv1.TransportURLReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	for _, key := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+		if len(clientCertSecret.Data[key]) == 0 {
+			err := fmt.Errorf("%s not found in client certificate secret %s", key, secretName)
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				rabbitmqv1.TransportURLReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				rabbitmqv1.TransportURLReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
 // fields to index to reconcile when change
 const (
-	rabbitmqClusterNameField = ".spec.rabbitmqClusterName"
-	transportURLFinalizer    = "transporturl.rabbitmq.openstack.org"
+	rabbitmqClusterNameField              = ".spec.rabbitmqClusterName"
+	notificationsRabbitmqClusterNameField = ".spec.notifications.rabbitmqClusterName"
+	transportURLFinalizer                 = "transporturl.rabbitmq.openstack.org"
+)
+
+// oslo.messaging defaults of the oslo-config secret format
+const (
+	transportURLDefaultHeartbeatTimeoutThreshold int32 = 60
+	transportURLDefaultHeartbeatRate             int32 = 3
+	// prefetch count of stream fanout consumers
+	transportURLStreamPrefetchCount int32 = 100
 )
 
 func (r *TransportURLReconciler) reconcileDelete(ctx context.Context, instance *rabbitmqv1.TransportURL, _ *helper.Helper) (ctrl.Result, error) {
@@ -974,6 +1432,7 @@ func (r *TransportURLReconciler) reconcileDelete(ctx context.Context, instance *
 
 var allWatchFields = []string{
 	rabbitmqClusterNameField,
+	notificationsRabbitmqClusterNameField,
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -990,6 +1449,18 @@ func (r *TransportURLReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
+	// index notifications rabbitmqClusterName
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &rabbitmqv1.TransportURL{}, notificationsRabbitmqClusterNameField, func(rawObj client.Object) []string {
+		// Extract the cluster name of the notifications target, if one is provided
+		cr := rawObj.(*rabbitmqv1.TransportURL)
+		if cr.Spec.Notifications == nil || cr.Spec.Notifications.RabbitmqClusterName == "" {
+			return nil
+		}
+		return []string{cr.Spec.Notifications.RabbitmqClusterName}
+	}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&rabbitmqv1.TransportURL{}).
 		Owns(&corev1.Secret{}).
@@ -1046,11 +1517,12 @@ func (r *TransportURLReconciler) findObjectsForSrc(ctx context.Context, src clie
 func getRabbitmqCluster(
 	ctx context.Context,
 	h *helper.Helper,
-	instance *rabbitmqv1.TransportURL,
+	namespace string,
+	name string,
 ) (*rabbitmqclusterv2.RabbitmqCluster, error) {
 	rabbitMqCluster := &rabbitmqclusterv2.RabbitmqCluster{}
 
-	err := h.GetClient().Get(ctx, types.NamespacedName{Name: instance.Spec.RabbitmqClusterName, Namespace: instance.Namespace}, rabbitMqCluster)
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, rabbitMqCluster)
 
 	return rabbitMqCluster, err
 }
@@ -5,9 +5,11 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	networkv1 "github.com/openstack-k8s-operators/infra-operator/apis/network/v1beta1"
+	rabbitmqv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
 	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/labels"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
@@ -18,6 +20,22 @@ import (
 	"k8s.io/utils/ptr"
 )
 
+// externalAuthPlugin - plugin providing the EXTERNAL authentication mechanism with client certificates
+const externalAuthPlugin = "rabbitmq_auth_mechanism_ssl"
+
+// streamPlugin - plugin providing stream queues and the stream protocol listeners
+const streamPlugin = "rabbitmq_stream"
+
+// peerCACertDir - directory the CA certificates of the peers of shovels and federation
+// links get mounted to, one subdirectory per CA secret
+const peerCACertDir = "/etc/rabbitmq-tls-peers"
+
+// PeerCACertFile returns the path of the CA certificate of the CA secret of a peer cluster
+// inside the RabbitMQ pods, as mounted by ConfigureCluster
+func PeerCACertFile(caSecretName string) string {
+	return fmt.Sprintf("%s/%s/ca.crt", peerCACertDir, caSecretName)
+}
+
 // ConfigureCluster configures a RabbitMQ cluster with the specified parameters
 func ConfigureCluster(
 	cluster *rabbitmqv2.RabbitmqCluster,
@@ -26,6 +44,12 @@ func ConfigureCluster(
 	topology *topologyv1.Topology,
 	nodeselector *map[string]string,
 	override *rabbitmqv2.OverrideTrimmed,
+	mtls *rabbitmqv1beta1.MTLSSection,
+	plugins []string,
+	prometheusDetailedMetrics bool,
+	tuning *rabbitmqv1beta1.Tuning,
+	streams *rabbitmqv1beta1.StreamsSection,
+	peerCASecrets []string,
 ) error {
 	envVars := []corev1.EnvVar{
 		{
@@ -186,6 +210,15 @@ func ConfigureCluster(
 	cluster.Spec.Rabbitmq.ErlangInetConfig = erlangInetConfig
 	cluster.Spec.Rabbitmq.AdvancedConfig = ""
 
+	// Client certificates of the AMQP listener are only verified with mTLS, the
+	// management listener keeps accepting clients without certificate
+	amqpVerify := "verify_none"
+	amqpFailIfNoPeerCert := false
+	if cluster.Spec.TLS.SecretName != "" && mtls.IsEnabled() {
+		amqpVerify = "verify_peer"
+		amqpFailIfNoPeerCert = mtls.SslVerifyMode == rabbitmqv1beta1.MTLSVerifyModeRequire
+	}
+
 	if cluster.Spec.TLS.SecretName != "" {
 		if cluster.Spec.TLS.CaSecretName == "" {
 			cluster.Spec.TLS.CaSecretName = cluster.Spec.TLS.SecretName
@@ -218,8 +251,8 @@ func ConfigureCluster(
   {reuse_sessions,true},
   {honor_cipher_order,false},
   {honor_ecc_order,false},
-  {verify,verify_none},
-  {fail_if_no_peer_cert,false},
+  {verify,%s},
+  {fail_if_no_peer_cert,%t},
   {versions, %s}
 ]}
 ]},
@@ -246,7 +279,7 @@ func ConfigureCluster(
 {versions, %s}
 ]}
 ].
-`, tlsVersions, tlsVersions, tlsVersions, tlsVersions)
+`, tlsVersions, amqpVerify, amqpFailIfNoPeerCert, tlsVersions, tlsVersions, tlsVersions)
 
 		cluster.Spec.Override.StatefulSet.Spec.Template.Spec.Volumes = append(
 			cluster.Spec.Override.StatefulSet.Spec.Template.Spec.Volumes,
@@ -279,6 +312,39 @@ func ConfigureCluster(
 		)
 	}
 
+	// Shovels and federation links verify peers with a different CA against the CA of the peer
+	if cluster.Spec.TLS.SecretName != "" {
+		for i, caSecretName := range peerCASecrets {
+			volumeName := fmt.Sprintf("peer-ca-%d", i)
+			cluster.Spec.Override.StatefulSet.Spec.Template.Spec.Volumes = append(
+				cluster.Spec.Override.StatefulSet.Spec.Template.Spec.Volumes,
+				corev1.Volume{
+					Name: volumeName,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName:  caSecretName,
+							DefaultMode: ptr.To[int32](0o440),
+							Items: []corev1.KeyToPath{
+								{
+									Key:  "ca.crt",
+									Path: "ca.crt",
+								},
+							},
+						},
+					},
+				},
+			)
+			cluster.Spec.Override.StatefulSet.Spec.Template.Spec.Containers[0].VolumeMounts = append(
+				cluster.Spec.Override.StatefulSet.Spec.Template.Spec.Containers[0].VolumeMounts,
+				corev1.VolumeMount{
+					MountPath: fmt.Sprintf("%s/%s", peerCACertDir, caSecretName),
+					ReadOnly:  true,
+					Name:      volumeName,
+				},
+			)
+		}
+	}
+
 	if cluster.Spec.Override.Service != nil &&
 		cluster.Spec.Override.Service.Spec != nil &&
 		cluster.Spec.Override.Service.Spec.Type == corev1.ServiceTypeLoadBalancer {
@@ -304,10 +370,42 @@ func ConfigureCluster(
 		"vm_memory_high_watermark.relative = 0.6",
 	}
 	if cluster.Spec.TLS.SecretName != "" {
-		settings = append(settings, "ssl_options.verify = verify_none", "prometheus.ssl.ip = ::")
+		settings = append(settings,
+			fmt.Sprintf("ssl_options.verify = %s", amqpVerify),
+			fmt.Sprintf("ssl_options.fail_if_no_peer_cert = %t", amqpFailIfNoPeerCert),
+			"prometheus.ssl.ip = ::")
 		// management ssl ip needs to be set in the AdvancedConfig
 	}
-	additionalDefaults := strings.Join(settings, "\n")
+	if prometheusDetailedMetrics {
+		settings = append(settings, "prometheus.return_per_object_metrics = true")
+	}
+	for _, plugin := range plugins {
+		if !slices.Contains(cluster.Spec.Rabbitmq.AdditionalPlugins, rabbitmqv2.Plugin(plugin)) {
+			cluster.Spec.Rabbitmq.AdditionalPlugins = append(cluster.Spec.Rabbitmq.AdditionalPlugins, rabbitmqv2.Plugin(plugin))
+		}
+	}
+	if cluster.Spec.TLS.SecretName != "" && mtls.UseExternalAuth() {
+		// EXTERNAL authenticates clients by the common name of their certificate,
+		// PLAIN and AMQPLAIN remain available for clients using passwords
+		if !slices.Contains(cluster.Spec.Rabbitmq.AdditionalPlugins, externalAuthPlugin) {
+			cluster.Spec.Rabbitmq.AdditionalPlugins = append(cluster.Spec.Rabbitmq.AdditionalPlugins, externalAuthPlugin)
+		}
+		settings = append(settings,
+			"auth_mechanisms.1 = EXTERNAL",
+			"auth_mechanisms.2 = PLAIN",
+			"auth_mechanisms.3 = AMQPLAIN",
+			"ssl_cert_login_from = common_name")
+	}
+	if streams.IsEnabled() {
+		// The cluster-operator configures the stream listeners and adds the
+		// stream ports to the service when the plugin is enabled
+		if !slices.Contains(cluster.Spec.Rabbitmq.AdditionalPlugins, streamPlugin) {
+			cluster.Spec.Rabbitmq.AdditionalPlugins = append(cluster.Spec.Rabbitmq.AdditionalPlugins, streamPlugin)
+		}
+	}
+	// The settings of the Tuning replace the defaults above
+	settings = append(settings, tuning.Settings()...)
+	additionalDefaults := strings.Join(mergeSettings(settings, cluster.Spec.Rabbitmq.AdditionalConfig), "\n")
 
 	// If additionalConfig is empty set let's our defaults, append otherwise.
 	if cluster.Spec.Rabbitmq.AdditionalConfig == "" {
@@ -318,3 +416,25 @@ func ConfigureCluster(
 
 	return nil
 }
+
+// mergeSettings returns the settings with a single line per key, a later setting replaces an
+// earlier one with the same key. Settings whose key is set in the raw additional config are
+// dropped, the additional config gets appended after the settings and takes precedence.
+func mergeSettings(settings []string, additionalConfig string) []string {
+	additionalKeys := rabbitmqv1beta1.ConfigKeys(additionalConfig)
+	merged := []string{}
+	index := map[string]int{}
+	for _, setting := range settings {
+		key := rabbitmqv1beta1.ConfigKey(setting)
+		if slices.Contains(additionalKeys, key) {
+			continue
+		}
+		if i, ok := index[key]; ok {
+			merged[i] = setting
+			continue
+		}
+		index[key] = len(merged)
+		merged = append(merged, setting)
+	}
+	return merged
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	networkv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/network/v1beta1"
+)
+
+// log is for logging in this package.
+var bgpconfigurationlog = logf.Log.WithName("bgpconfiguration-resource")
+
+// SetupBGPConfigurationWebhookWithManager registers the webhook for BGPConfiguration in the manager.
+func SetupBGPConfigurationWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&networkv1beta1.BGPConfiguration{}).
+		WithValidator(&BGPConfigurationCustomValidator{}).
+		Complete()
+}
+
+// NOTE: The 'path' attribute must follow a specific pattern and should not be modified directly here.
+// Modifying the path for an invalid path can cause API server errors; failing to locate the webhook.
+// +kubebuilder:webhook:path=/validate-network-openstack-org-v1beta1-bgpconfiguration,mutating=false,failurePolicy=fail,sideEffects=None,groups=network.openstack.org,resources=bgpconfigurations,verbs=create;update,versions=v1beta1,name=vbgpconfiguration-v1beta1.kb.io,admissionReviewVersions=v1
+
+// BGPConfigurationCustomValidator struct is responsible for validating the BGPConfiguration resource
+// when it is created, updated, or deleted.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+type BGPConfigurationCustomValidator struct{}
+
+var _ webhook.CustomValidator = &BGPConfigurationCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type BGPConfiguration.
+func (v *BGPConfigurationCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	bgpconfiguration, ok := obj.(*networkv1beta1.BGPConfiguration)
+	if !ok {
+		return nil, fmt.Errorf("expected a BGPConfiguration object but got %T", obj)
+	}
+	bgpconfigurationlog.Info("Validation for BGPConfiguration upon creation", "name", bgpconfiguration.GetName())
+
+	return bgpconfiguration.ValidateCreate()
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type BGPConfiguration.
+func (v *BGPConfigurationCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	bgpconfiguration, ok := newObj.(*networkv1beta1.BGPConfiguration)
+	if !ok {
+		return nil, fmt.Errorf("expected a BGPConfiguration object for the newObj but got %T", newObj)
+	}
+	bgpconfigurationlog.Info("Validation for BGPConfiguration upon update", "name", bgpconfiguration.GetName())
+
+	return bgpconfiguration.ValidateUpdate(oldObj)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type BGPConfiguration.
+func (v *BGPConfigurationCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	bgpconfiguration, ok := obj.(*networkv1beta1.BGPConfiguration)
+	if !ok {
+		return nil, fmt.Errorf("expected a BGPConfiguration object but got %T", obj)
+	}
+	bgpconfigurationlog.Info("Validation for BGPConfiguration upon deletion", "name", bgpconfiguration.GetName())
+
+	return bgpconfiguration.ValidateDelete()
+}
@@ -18,12 +18,15 @@ package v1beta1
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
 	. "github.com/onsi/gomega"    //revive:disable:dot-imports
 	rabbitmqv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
 	rabbitmqv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -467,4 +470,157 @@ var _ = Describe("RabbitMq webhook", func() {
 				"QueueType should be preserved from existing CR (Mirrored), not changed to webhook default (Quorum)")
 		})
 	})
+
+	Context("ValidateMTLS method", func() {
+		basePath := field.NewPath("spec")
+
+		It("should accept mTLS with TLS enabled", func() {
+			spec := rabbitmqv1beta1.RabbitMqSpecCore{
+				RabbitmqClusterSpecCore: rabbitmqv2.RabbitmqClusterSpecCore{
+					TLS: rabbitmqv2.TLSSpec{SecretName: "rabbitmq-tls"},
+				},
+				MTLS: &rabbitmqv1beta1.MTLSSection{
+					SslVerifyMode: rabbitmqv1beta1.MTLSVerifyModeRequire,
+					ExternalAuth:  true,
+				},
+			}
+
+			Expect(spec.ValidateMTLS(basePath)).To(BeEmpty())
+		})
+
+		It("should reject mTLS without TLS", func() {
+			spec := rabbitmqv1beta1.RabbitMqSpecCore{
+				MTLS: &rabbitmqv1beta1.MTLSSection{
+					SslVerifyMode: rabbitmqv1beta1.MTLSVerifyModeRequest,
+				},
+			}
+
+			errs := spec.ValidateMTLS(basePath)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.mtls.sslVerifyMode"))
+		})
+
+		It("should reject externalAuth without client certificate verification", func() {
+			spec := rabbitmqv1beta1.RabbitMqSpecCore{
+				RabbitmqClusterSpecCore: rabbitmqv2.RabbitmqClusterSpecCore{
+					TLS: rabbitmqv2.TLSSpec{SecretName: "rabbitmq-tls"},
+				},
+				MTLS: &rabbitmqv1beta1.MTLSSection{
+					SslVerifyMode: rabbitmqv1beta1.MTLSVerifyModeNone,
+					ExternalAuth:  true,
+				},
+			}
+
+			errs := spec.ValidateMTLS(basePath)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.mtls.externalAuth"))
+		})
+	})
+
+	Context("ValidatePlugins method", func() {
+		basePath := field.NewPath("spec")
+
+		It("should accept plugins of the allow-list", func() {
+			spec := rabbitmqv1beta1.RabbitMqSpecCore{
+				Plugins: []string{"rabbitmq_shovel", "rabbitmq_federation", "rabbitmq_stream"},
+			}
+
+			Expect(spec.ValidatePlugins(basePath)).To(BeEmpty())
+		})
+
+		It("should reject plugins which are not in the allow-list", func() {
+			spec := rabbitmqv1beta1.RabbitMqSpecCore{
+				Plugins: []string{"rabbitmq_shovel", "rabbitmq_auth_backend_ldap"},
+			}
+
+			errs := spec.ValidatePlugins(basePath)
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("spec.plugins[1]"))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeNotSupported))
+		})
+	})
+
+	Context("ValidateTuning method", func() {
+		basePath := field.NewPath("spec")
+
+		It("should accept valid settings", func() {
+			spec := rabbitmqv1beta1.RabbitMqSpecCore{
+				Tuning: &rabbitmqv1beta1.Tuning{
+					VMMemoryHighWatermark: ptr.To("0.4"),
+					ChannelMax:            ptr.To[int32](128),
+					Heartbeat:             ptr.To[int32](0),
+					ConsumerTimeout:       &metav1.Duration{Duration: 30 * time.Minute},
+				},
+			}
+
+			warn, errs := spec.ValidateTuning(basePath)
+			Expect(warn).To(BeEmpty())
+			Expect(errs).To(BeEmpty())
+		})
+
+		It("should reject settings out of range", func() {
+			spec := rabbitmqv1beta1.RabbitMqSpecCore{
+				Tuning: &rabbitmqv1beta1.Tuning{
+					VMMemoryHighWatermark: ptr.To("1.5"),
+					ChannelMax:            ptr.To[int32](-1),
+					Heartbeat:             ptr.To[int32](70000),
+					ConsumerTimeout:       &metav1.Duration{},
+				},
+			}
+
+			_, errs := spec.ValidateTuning(basePath)
+			Expect(errs).To(HaveLen(4))
+			Expect(errs[0].Field).To(Equal("spec.tuning.vmMemoryHighWatermark"))
+			Expect(errs[1].Field).To(Equal("spec.tuning.channelMax"))
+			Expect(errs[2].Field).To(Equal("spec.tuning.heartbeat"))
+			Expect(errs[3].Field).To(Equal("spec.tuning.consumerTimeout"))
+		})
+
+		It("should warn about settings also set in the additionalConfig", func() {
+			spec := rabbitmqv1beta1.RabbitMqSpecCore{
+				Tuning: &rabbitmqv1beta1.Tuning{
+					ChannelMax: ptr.To[int32](128),
+					Heartbeat:  ptr.To[int32](30),
+				},
+			}
+			spec.Rabbitmq.AdditionalConfig = "channel_max = 64"
+
+			warn, errs := spec.ValidateTuning(basePath)
+			Expect(errs).To(BeEmpty())
+			Expect(warn).To(HaveLen(1))
+			Expect(warn[0]).To(ContainSubstring("channel_max"))
+		})
+	})
+
+	Context("ValidateStreams method", func() {
+		basePath := field.NewPath("spec")
+
+		It("should accept valid vhosts and retention", func() {
+			maxLengthBytes := resource.MustParse("20Gi")
+			spec := rabbitmqv1beta1.RabbitMqSpecCore{
+				Streams: &rabbitmqv1beta1.StreamsSection{
+					Vhosts:         []string{"/", "ceilometer"},
+					MaxLengthBytes: &maxLengthBytes,
+					MaxAge:         "7D",
+				},
+			}
+
+			Expect(spec.ValidateStreams(basePath)).To(BeEmpty())
+		})
+
+		It("should reject invalid vhosts and retention", func() {
+			maxLengthBytes := resource.MustParse("0")
+			spec := rabbitmqv1beta1.RabbitMqSpecCore{
+				Streams: &rabbitmqv1beta1.StreamsSection{
+					Vhosts:         []string{"telemetry/notifications"},
+					MaxLengthBytes: &maxLengthBytes,
+				},
+			}
+
+			errs := spec.ValidateStreams(basePath)
+			Expect(errs).To(HaveLen(2))
+			Expect(errs[0].Field).To(Equal("spec.streams.vhosts[0]"))
+			Expect(errs[1].Field).To(Equal("spec.streams.maxLengthBytes"))
+		})
+	})
 })
@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rabbitmqv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+)
+
+var backuplog = logf.Log.WithName("rabbitmqbackup-resource")
+
+// SetupRabbitMQBackupWebhookWithManager registers the webhook for RabbitMQBackup in the manager.
+func SetupRabbitMQBackupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&rabbitmqv1beta1.RabbitMQBackup{}).
+		WithDefaulter(&RabbitMQBackupCustomDefaulter{
+			Client: mgr.GetClient(),
+		}).
+		WithValidator(&RabbitMQBackupCustomValidator{
+			Client: mgr.GetClient(),
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-rabbitmq-openstack-org-v1beta1-rabbitmqbackup,mutating=true,failurePolicy=fail,sideEffects=None,groups=rabbitmq.openstack.org,resources=rabbitmqbackups,verbs=create;update,versions=v1beta1,name=mrabbitmqbackup-v1beta1.kb.io,admissionReviewVersions=v1
+
+// RabbitMQBackupCustomDefaulter struct is responsible for setting default values on the RabbitMQBackup resource
+// when it is created or updated.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+// +kubebuilder:object:generate=false
+type RabbitMQBackupCustomDefaulter struct {
+	Client client.Client
+}
+
+var _ webhook.CustomDefaulter = &RabbitMQBackupCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type RabbitMQBackup.
+func (d *RabbitMQBackupCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	rabbitmqbackup, ok := obj.(*rabbitmqv1beta1.RabbitMQBackup)
+	if !ok {
+		return fmt.Errorf("expected a RabbitMQBackup object but got %T", obj)
+	}
+	backuplog.Info("Defaulting for RabbitMQBackup", "name", rabbitmqbackup.GetName())
+
+	rabbitmqbackup.Default(d.Client)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-rabbitmq-openstack-org-v1beta1-rabbitmqbackup,mutating=false,failurePolicy=fail,sideEffects=None,groups=rabbitmq.openstack.org,resources=rabbitmqbackups,verbs=create;update,versions=v1beta1,name=vrabbitmqbackup-v1beta1.kb.io,admissionReviewVersions=v1
+
+// RabbitMQBackupCustomValidator struct is responsible for validating the RabbitMQBackup resource
+// when it is created, updated, or deleted.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+// +kubebuilder:object:generate=false
+type RabbitMQBackupCustomValidator struct {
+	Client client.Client
+}
+
+var _ webhook.CustomValidator = &RabbitMQBackupCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type RabbitMQBackup.
+func (v *RabbitMQBackupCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	rabbitmqbackup, ok := obj.(*rabbitmqv1beta1.RabbitMQBackup)
+	if !ok {
+		return nil, fmt.Errorf("expected a RabbitMQBackup object but got %T", obj)
+	}
+	backuplog.Info("Validation for RabbitMQBackup upon creation", "name", rabbitmqbackup.GetName())
+
+	return rabbitmqbackup.ValidateCreate(v.Client)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type RabbitMQBackup.
+func (v *RabbitMQBackupCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	rabbitmqbackup, ok := newObj.(*rabbitmqv1beta1.RabbitMQBackup)
+	if !ok {
+		return nil, fmt.Errorf("expected a RabbitMQBackup object for the newObj but got %T", newObj)
+	}
+	backuplog.Info("Validation for RabbitMQBackup upon update", "name", rabbitmqbackup.GetName())
+
+	return rabbitmqbackup.ValidateUpdate(v.Client, oldObj)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type RabbitMQBackup.
+func (v *RabbitMQBackupCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	rabbitmqbackup, ok := obj.(*rabbitmqv1beta1.RabbitMQBackup)
+	if !ok {
+		return nil, fmt.Errorf("expected a RabbitMQBackup object but got %T", obj)
+	}
+	backuplog.Info("Validation for RabbitMQBackup upon deletion", "name", rabbitmqbackup.GetName())
+
+	return rabbitmqbackup.ValidateDelete(v.Client)
+}
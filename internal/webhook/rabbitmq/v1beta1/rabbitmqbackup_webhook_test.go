@@ -0,0 +1,161 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+	. "github.com/onsi/gomega"    //revive:disable:dot-imports
+	rabbitmqv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("RabbitMQBackup webhook", func() {
+	var backup *rabbitmqv1beta1.RabbitMQBackup
+
+	BeforeEach(func() {
+		backup = &rabbitmqv1beta1.RabbitMQBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-backup",
+				Namespace: "default",
+			},
+			Spec: rabbitmqv1beta1.RabbitMQBackupSpec{
+				RabbitmqClusterName: "rabbitmq",
+				Schedule:            "0 2 * * *",
+				Retention:           7,
+			},
+		}
+	})
+
+	Context("Default method", func() {
+		It("should default the storage type to Secret", func() {
+			backup.Default(k8sClient)
+
+			Expect(backup.Spec.Storage.Type).To(Equal(rabbitmqv1beta1.RabbitMQBackupStorageSecret))
+		})
+	})
+
+	Context("ValidateCreate method", func() {
+		BeforeEach(func() {
+			backup.Default(k8sClient)
+		})
+
+		It("should accept a valid backup", func() {
+			_, err := backup.ValidateCreate(k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject an invalid schedule", func() {
+			backup.Spec.Schedule = "0 25 * * *"
+
+			_, err := backup.ValidateCreate(k8sClient)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.schedule"))
+		})
+
+		It("should require a claim name with storage type PVC", func() {
+			backup.Spec.Storage.Type = rabbitmqv1beta1.RabbitMQBackupStoragePVC
+
+			_, err := backup.ValidateCreate(k8sClient)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("claimName is required with storage type PVC"))
+		})
+
+		It("should reject a claim name with storage type Secret", func() {
+			backup.Spec.Storage.ClaimName = "backups"
+
+			_, err := backup.ValidateCreate(k8sClient)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("claimName is only supported with storage type PVC"))
+		})
+	})
+
+	Context("ValidateUpdate method", func() {
+		BeforeEach(func() {
+			backup.Default(k8sClient)
+		})
+
+		It("should allow changing the schedule and the retention", func() {
+			newBackup := backup.DeepCopy()
+			newBackup.Spec.Schedule = "@weekly"
+			newBackup.Spec.Retention = 4
+
+			_, err := newBackup.ValidateUpdate(k8sClient, backup)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject changing the cluster", func() {
+			newBackup := backup.DeepCopy()
+			newBackup.Spec.RabbitmqClusterName = "cell1"
+
+			_, err := newBackup.ValidateUpdate(k8sClient, backup)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cluster cannot be changed after creation"))
+		})
+	})
+})
+
+var _ = Describe("RabbitMQRestore webhook", func() {
+	var restore *rabbitmqv1beta1.RabbitMQRestore
+
+	BeforeEach(func() {
+		restore = &rabbitmqv1beta1.RabbitMQRestore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-restore",
+				Namespace: "default",
+			},
+			Spec: rabbitmqv1beta1.RabbitMQRestoreSpec{
+				RabbitmqClusterName: "rabbitmq",
+				BackupRef:           "test-backup",
+				Vhosts:              []string{"/", "nova"},
+			},
+		}
+	})
+
+	Context("ValidateCreate method", func() {
+		It("should accept a valid restore", func() {
+			_, err := restore.ValidateCreate(k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject an invalid vhost name", func() {
+			restore.Spec.Vhosts = []string{"nova/cell1"}
+
+			_, err := restore.ValidateCreate(k8sClient)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.vhosts[0]"))
+		})
+	})
+
+	Context("ValidateUpdate method", func() {
+		It("should reject changing the spec", func() {
+			newRestore := restore.DeepCopy()
+			newRestore.Spec.Vhosts = []string{"neutron"}
+
+			_, err := newRestore.ValidateUpdate(k8sClient, restore)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec cannot be changed after creation"))
+		})
+
+		It("should allow metadata changes", func() {
+			newRestore := restore.DeepCopy()
+			newRestore.Labels = map[string]string{"service": "nova"}
+
+			_, err := newRestore.ValidateUpdate(k8sClient, restore)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rabbitmqv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+)
+
+var bindinglog = logf.Log.WithName("rabbitmqbinding-resource")
+
+// SetupRabbitMQBindingWebhookWithManager registers the webhook for RabbitMQBinding in the manager.
+func SetupRabbitMQBindingWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&rabbitmqv1beta1.RabbitMQBinding{}).
+		WithValidator(&RabbitMQBindingCustomValidator{
+			Client: mgr.GetClient(),
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-rabbitmq-openstack-org-v1beta1-rabbitmqbinding,mutating=false,failurePolicy=fail,sideEffects=None,groups=rabbitmq.openstack.org,resources=rabbitmqbindings,verbs=create;update,versions=v1beta1,name=vrabbitmqbinding-v1beta1.kb.io,admissionReviewVersions=v1
+
+// RabbitMQBindingCustomValidator struct is responsible for validating the RabbitMQBinding resource
+// when it is created, updated, or deleted.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+// +kubebuilder:object:generate=false
+type RabbitMQBindingCustomValidator struct {
+	Client client.Client
+}
+
+var _ webhook.CustomValidator = &RabbitMQBindingCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type RabbitMQBinding.
+func (v *RabbitMQBindingCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	rabbitmqbinding, ok := obj.(*rabbitmqv1beta1.RabbitMQBinding)
+	if !ok {
+		return nil, fmt.Errorf("expected a RabbitMQBinding object but got %T", obj)
+	}
+	bindinglog.Info("Validation for RabbitMQBinding upon creation", "name", rabbitmqbinding.GetName())
+
+	return rabbitmqbinding.ValidateCreate(v.Client)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type RabbitMQBinding.
+func (v *RabbitMQBindingCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	rabbitmqbinding, ok := newObj.(*rabbitmqv1beta1.RabbitMQBinding)
+	if !ok {
+		return nil, fmt.Errorf("expected a RabbitMQBinding object for the newObj but got %T", newObj)
+	}
+	bindinglog.Info("Validation for RabbitMQBinding upon update", "name", rabbitmqbinding.GetName())
+
+	return rabbitmqbinding.ValidateUpdate(v.Client, oldObj)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type RabbitMQBinding.
+func (v *RabbitMQBindingCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	rabbitmqbinding, ok := obj.(*rabbitmqv1beta1.RabbitMQBinding)
+	if !ok {
+		return nil, fmt.Errorf("expected a RabbitMQBinding object but got %T", obj)
+	}
+	bindinglog.Info("Validation for RabbitMQBinding upon deletion", "name", rabbitmqbinding.GetName())
+
+	return rabbitmqbinding.ValidateDelete(v.Client)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rabbitmqv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+)
+
+var exchangelog = logf.Log.WithName("rabbitmqexchange-resource")
+
+// SetupRabbitMQExchangeWebhookWithManager registers the webhook for RabbitMQExchange in the manager.
+func SetupRabbitMQExchangeWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&rabbitmqv1beta1.RabbitMQExchange{}).
+		WithDefaulter(&RabbitMQExchangeCustomDefaulter{
+			Client: mgr.GetClient(),
+		}).
+		WithValidator(&RabbitMQExchangeCustomValidator{
+			Client: mgr.GetClient(),
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-rabbitmq-openstack-org-v1beta1-rabbitmqexchange,mutating=true,failurePolicy=fail,sideEffects=None,groups=rabbitmq.openstack.org,resources=rabbitmqexchanges,verbs=create;update,versions=v1beta1,name=mrabbitmqexchange-v1beta1.kb.io,admissionReviewVersions=v1
+
+// RabbitMQExchangeCustomDefaulter struct is responsible for setting default values on the RabbitMQExchange resource
+// when it is created or updated.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+// +kubebuilder:object:generate=false
+type RabbitMQExchangeCustomDefaulter struct {
+	Client client.Client
+}
+
+var _ webhook.CustomDefaulter = &RabbitMQExchangeCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type RabbitMQExchange.
+func (d *RabbitMQExchangeCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	rabbitmqexchange, ok := obj.(*rabbitmqv1beta1.RabbitMQExchange)
+	if !ok {
+		return fmt.Errorf("expected a RabbitMQExchange object but got %T", obj)
+	}
+	exchangelog.Info("Defaulting for RabbitMQExchange", "name", rabbitmqexchange.GetName())
+
+	rabbitmqexchange.Default(d.Client)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-rabbitmq-openstack-org-v1beta1-rabbitmqexchange,mutating=false,failurePolicy=fail,sideEffects=None,groups=rabbitmq.openstack.org,resources=rabbitmqexchanges,verbs=create;update,versions=v1beta1,name=vrabbitmqexchange-v1beta1.kb.io,admissionReviewVersions=v1
+
+// RabbitMQExchangeCustomValidator struct is responsible for validating the RabbitMQExchange resource
+// when it is created, updated, or deleted.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+// +kubebuilder:object:generate=false
+type RabbitMQExchangeCustomValidator struct {
+	Client client.Client
+}
+
+var _ webhook.CustomValidator = &RabbitMQExchangeCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type RabbitMQExchange.
+func (v *RabbitMQExchangeCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	rabbitmqexchange, ok := obj.(*rabbitmqv1beta1.RabbitMQExchange)
+	if !ok {
+		return nil, fmt.Errorf("expected a RabbitMQExchange object but got %T", obj)
+	}
+	exchangelog.Info("Validation for RabbitMQExchange upon creation", "name", rabbitmqexchange.GetName())
+
+	return rabbitmqexchange.ValidateCreate(v.Client)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type RabbitMQExchange.
+func (v *RabbitMQExchangeCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	rabbitmqexchange, ok := newObj.(*rabbitmqv1beta1.RabbitMQExchange)
+	if !ok {
+		return nil, fmt.Errorf("expected a RabbitMQExchange object for the newObj but got %T", newObj)
+	}
+	exchangelog.Info("Validation for RabbitMQExchange upon update", "name", rabbitmqexchange.GetName())
+
+	return rabbitmqexchange.ValidateUpdate(v.Client, oldObj)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type RabbitMQExchange.
+func (v *RabbitMQExchangeCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	rabbitmqexchange, ok := obj.(*rabbitmqv1beta1.RabbitMQExchange)
+	if !ok {
+		return nil, fmt.Errorf("expected a RabbitMQExchange object but got %T", obj)
+	}
+	exchangelog.Info("Validation for RabbitMQExchange upon deletion", "name", rabbitmqexchange.GetName())
+
+	return rabbitmqexchange.ValidateDelete(v.Client)
+}
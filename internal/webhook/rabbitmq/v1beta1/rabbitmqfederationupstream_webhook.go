@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rabbitmqv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+)
+
+var federationupstreamlog = logf.Log.WithName("rabbitmqfederationupstream-resource")
+
+// SetupRabbitMQFederationUpstreamWebhookWithManager registers the webhook for RabbitMQFederationUpstream in the manager.
+func SetupRabbitMQFederationUpstreamWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&rabbitmqv1beta1.RabbitMQFederationUpstream{}).
+		WithDefaulter(&RabbitMQFederationUpstreamCustomDefaulter{
+			Client: mgr.GetClient(),
+		}).
+		WithValidator(&RabbitMQFederationUpstreamCustomValidator{
+			Client: mgr.GetClient(),
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-rabbitmq-openstack-org-v1beta1-rabbitmqfederationupstream,mutating=true,failurePolicy=fail,sideEffects=None,groups=rabbitmq.openstack.org,resources=rabbitmqfederationupstreams,verbs=create;update,versions=v1beta1,name=mrabbitmqfederationupstream-v1beta1.kb.io,admissionReviewVersions=v1
+
+// RabbitMQFederationUpstreamCustomDefaulter struct is responsible for setting default values on the RabbitMQFederationUpstream resource
+// when it is created or updated.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+// +kubebuilder:object:generate=false
+type RabbitMQFederationUpstreamCustomDefaulter struct {
+	Client client.Client
+}
+
+var _ webhook.CustomDefaulter = &RabbitMQFederationUpstreamCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type RabbitMQFederationUpstream.
+func (d *RabbitMQFederationUpstreamCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	rabbitmqfederationupstream, ok := obj.(*rabbitmqv1beta1.RabbitMQFederationUpstream)
+	if !ok {
+		return fmt.Errorf("expected a RabbitMQFederationUpstream object but got %T", obj)
+	}
+	federationupstreamlog.Info("Defaulting for RabbitMQFederationUpstream", "name", rabbitmqfederationupstream.GetName())
+
+	rabbitmqfederationupstream.Default(d.Client)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-rabbitmq-openstack-org-v1beta1-rabbitmqfederationupstream,mutating=false,failurePolicy=fail,sideEffects=None,groups=rabbitmq.openstack.org,resources=rabbitmqfederationupstreams,verbs=create;update,versions=v1beta1,name=vrabbitmqfederationupstream-v1beta1.kb.io,admissionReviewVersions=v1
+
+// RabbitMQFederationUpstreamCustomValidator struct is responsible for validating the RabbitMQFederationUpstream resource
+// when it is created, updated, or deleted.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+// +kubebuilder:object:generate=false
+type RabbitMQFederationUpstreamCustomValidator struct {
+	Client client.Client
+}
+
+var _ webhook.CustomValidator = &RabbitMQFederationUpstreamCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type RabbitMQFederationUpstream.
+func (v *RabbitMQFederationUpstreamCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	rabbitmqfederationupstream, ok := obj.(*rabbitmqv1beta1.RabbitMQFederationUpstream)
+	if !ok {
+		return nil, fmt.Errorf("expected a RabbitMQFederationUpstream object but got %T", obj)
+	}
+	federationupstreamlog.Info("Validation for RabbitMQFederationUpstream upon creation", "name", rabbitmqfederationupstream.GetName())
+
+	return rabbitmqfederationupstream.ValidateCreate(v.Client)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type RabbitMQFederationUpstream.
+func (v *RabbitMQFederationUpstreamCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	rabbitmqfederationupstream, ok := newObj.(*rabbitmqv1beta1.RabbitMQFederationUpstream)
+	if !ok {
+		return nil, fmt.Errorf("expected a RabbitMQFederationUpstream object for the newObj but got %T", newObj)
+	}
+	federationupstreamlog.Info("Validation for RabbitMQFederationUpstream upon update", "name", rabbitmqfederationupstream.GetName())
+
+	return rabbitmqfederationupstream.ValidateUpdate(v.Client, oldObj)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type RabbitMQFederationUpstream.
+func (v *RabbitMQFederationUpstreamCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	rabbitmqfederationupstream, ok := obj.(*rabbitmqv1beta1.RabbitMQFederationUpstream)
+	if !ok {
+		return nil, fmt.Errorf("expected a RabbitMQFederationUpstream object but got %T", obj)
+	}
+	federationupstreamlog.Info("Validation for RabbitMQFederationUpstream upon deletion", "name", rabbitmqfederationupstream.GetName())
+
+	return rabbitmqfederationupstream.ValidateDelete(v.Client)
+}
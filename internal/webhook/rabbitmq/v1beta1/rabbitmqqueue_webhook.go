@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rabbitmqv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+)
+
+var queuelog = logf.Log.WithName("rabbitmqqueue-resource")
+
+// SetupRabbitMQQueueWebhookWithManager registers the webhook for RabbitMQQueue in the manager.
+func SetupRabbitMQQueueWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&rabbitmqv1beta1.RabbitMQQueue{}).
+		WithDefaulter(&RabbitMQQueueCustomDefaulter{
+			Client: mgr.GetClient(),
+		}).
+		WithValidator(&RabbitMQQueueCustomValidator{
+			Client: mgr.GetClient(),
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-rabbitmq-openstack-org-v1beta1-rabbitmqqueue,mutating=true,failurePolicy=fail,sideEffects=None,groups=rabbitmq.openstack.org,resources=rabbitmqqueues,verbs=create;update,versions=v1beta1,name=mrabbitmqqueue-v1beta1.kb.io,admissionReviewVersions=v1
+
+// RabbitMQQueueCustomDefaulter struct is responsible for setting default values on the RabbitMQQueue resource
+// when it is created or updated.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+// +kubebuilder:object:generate=false
+type RabbitMQQueueCustomDefaulter struct {
+	Client client.Client
+}
+
+var _ webhook.CustomDefaulter = &RabbitMQQueueCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type RabbitMQQueue.
+func (d *RabbitMQQueueCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	rabbitmqqueue, ok := obj.(*rabbitmqv1beta1.RabbitMQQueue)
+	if !ok {
+		return fmt.Errorf("expected a RabbitMQQueue object but got %T", obj)
+	}
+	queuelog.Info("Defaulting for RabbitMQQueue", "name", rabbitmqqueue.GetName())
+
+	rabbitmqqueue.Default(d.Client)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-rabbitmq-openstack-org-v1beta1-rabbitmqqueue,mutating=false,failurePolicy=fail,sideEffects=None,groups=rabbitmq.openstack.org,resources=rabbitmqqueues,verbs=create;update,versions=v1beta1,name=vrabbitmqqueue-v1beta1.kb.io,admissionReviewVersions=v1
+
+// RabbitMQQueueCustomValidator struct is responsible for validating the RabbitMQQueue resource
+// when it is created, updated, or deleted.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+// +kubebuilder:object:generate=false
+type RabbitMQQueueCustomValidator struct {
+	Client client.Client
+}
+
+var _ webhook.CustomValidator = &RabbitMQQueueCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type RabbitMQQueue.
+func (v *RabbitMQQueueCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	rabbitmqqueue, ok := obj.(*rabbitmqv1beta1.RabbitMQQueue)
+	if !ok {
+		return nil, fmt.Errorf("expected a RabbitMQQueue object but got %T", obj)
+	}
+	queuelog.Info("Validation for RabbitMQQueue upon creation", "name", rabbitmqqueue.GetName())
+
+	return rabbitmqqueue.ValidateCreate(v.Client)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type RabbitMQQueue.
+func (v *RabbitMQQueueCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	rabbitmqqueue, ok := newObj.(*rabbitmqv1beta1.RabbitMQQueue)
+	if !ok {
+		return nil, fmt.Errorf("expected a RabbitMQQueue object for the newObj but got %T", newObj)
+	}
+	queuelog.Info("Validation for RabbitMQQueue upon update", "name", rabbitmqqueue.GetName())
+
+	return rabbitmqqueue.ValidateUpdate(v.Client, oldObj)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type RabbitMQQueue.
+func (v *RabbitMQQueueCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	rabbitmqqueue, ok := obj.(*rabbitmqv1beta1.RabbitMQQueue)
+	if !ok {
+		return nil, fmt.Errorf("expected a RabbitMQQueue object but got %T", obj)
+	}
+	queuelog.Info("Validation for RabbitMQQueue upon deletion", "name", rabbitmqqueue.GetName())
+
+	return rabbitmqqueue.ValidateDelete(v.Client)
+}
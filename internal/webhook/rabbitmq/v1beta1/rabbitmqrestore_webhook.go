@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rabbitmqv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+)
+
+var restorelog = logf.Log.WithName("rabbitmqrestore-resource")
+
+// SetupRabbitMQRestoreWebhookWithManager registers the webhook for RabbitMQRestore in the manager.
+func SetupRabbitMQRestoreWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&rabbitmqv1beta1.RabbitMQRestore{}).
+		WithDefaulter(&RabbitMQRestoreCustomDefaulter{
+			Client: mgr.GetClient(),
+		}).
+		WithValidator(&RabbitMQRestoreCustomValidator{
+			Client: mgr.GetClient(),
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-rabbitmq-openstack-org-v1beta1-rabbitmqrestore,mutating=true,failurePolicy=fail,sideEffects=None,groups=rabbitmq.openstack.org,resources=rabbitmqrestores,verbs=create;update,versions=v1beta1,name=mrabbitmqrestore-v1beta1.kb.io,admissionReviewVersions=v1
+
+// RabbitMQRestoreCustomDefaulter struct is responsible for setting default values on the RabbitMQRestore resource
+// when it is created or updated.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+// +kubebuilder:object:generate=false
+type RabbitMQRestoreCustomDefaulter struct {
+	Client client.Client
+}
+
+var _ webhook.CustomDefaulter = &RabbitMQRestoreCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type RabbitMQRestore.
+func (d *RabbitMQRestoreCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	rabbitmqrestore, ok := obj.(*rabbitmqv1beta1.RabbitMQRestore)
+	if !ok {
+		return fmt.Errorf("expected a RabbitMQRestore object but got %T", obj)
+	}
+	restorelog.Info("Defaulting for RabbitMQRestore", "name", rabbitmqrestore.GetName())
+
+	rabbitmqrestore.Default(d.Client)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-rabbitmq-openstack-org-v1beta1-rabbitmqrestore,mutating=false,failurePolicy=fail,sideEffects=None,groups=rabbitmq.openstack.org,resources=rabbitmqrestores,verbs=create;update,versions=v1beta1,name=vrabbitmqrestore-v1beta1.kb.io,admissionReviewVersions=v1
+
+// RabbitMQRestoreCustomValidator struct is responsible for validating the RabbitMQRestore resource
+// when it is created, updated, or deleted.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+// +kubebuilder:object:generate=false
+type RabbitMQRestoreCustomValidator struct {
+	Client client.Client
+}
+
+var _ webhook.CustomValidator = &RabbitMQRestoreCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type RabbitMQRestore.
+func (v *RabbitMQRestoreCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	rabbitmqrestore, ok := obj.(*rabbitmqv1beta1.RabbitMQRestore)
+	if !ok {
+		return nil, fmt.Errorf("expected a RabbitMQRestore object but got %T", obj)
+	}
+	restorelog.Info("Validation for RabbitMQRestore upon creation", "name", rabbitmqrestore.GetName())
+
+	return rabbitmqrestore.ValidateCreate(v.Client)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type RabbitMQRestore.
+func (v *RabbitMQRestoreCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	rabbitmqrestore, ok := newObj.(*rabbitmqv1beta1.RabbitMQRestore)
+	if !ok {
+		return nil, fmt.Errorf("expected a RabbitMQRestore object for the newObj but got %T", newObj)
+	}
+	restorelog.Info("Validation for RabbitMQRestore upon update", "name", rabbitmqrestore.GetName())
+
+	return rabbitmqrestore.ValidateUpdate(v.Client, oldObj)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type RabbitMQRestore.
+func (v *RabbitMQRestoreCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	rabbitmqrestore, ok := obj.(*rabbitmqv1beta1.RabbitMQRestore)
+	if !ok {
+		return nil, fmt.Errorf("expected a RabbitMQRestore object but got %T", obj)
+	}
+	restorelog.Info("Validation for RabbitMQRestore upon deletion", "name", rabbitmqrestore.GetName())
+
+	return rabbitmqrestore.ValidateDelete(v.Client)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	rabbitmqv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+)
+
+var shovellog = logf.Log.WithName("rabbitmqshovel-resource")
+
+// SetupRabbitMQShovelWebhookWithManager registers the webhook for RabbitMQShovel in the manager.
+func SetupRabbitMQShovelWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&rabbitmqv1beta1.RabbitMQShovel{}).
+		WithDefaulter(&RabbitMQShovelCustomDefaulter{
+			Client: mgr.GetClient(),
+		}).
+		WithValidator(&RabbitMQShovelCustomValidator{
+			Client: mgr.GetClient(),
+		}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-rabbitmq-openstack-org-v1beta1-rabbitmqshovel,mutating=true,failurePolicy=fail,sideEffects=None,groups=rabbitmq.openstack.org,resources=rabbitmqshovels,verbs=create;update,versions=v1beta1,name=mrabbitmqshovel-v1beta1.kb.io,admissionReviewVersions=v1
+
+// RabbitMQShovelCustomDefaulter struct is responsible for setting default values on the RabbitMQShovel resource
+// when it is created or updated.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+// +kubebuilder:object:generate=false
+type RabbitMQShovelCustomDefaulter struct {
+	Client client.Client
+}
+
+var _ webhook.CustomDefaulter = &RabbitMQShovelCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type RabbitMQShovel.
+func (d *RabbitMQShovelCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	rabbitmqshovel, ok := obj.(*rabbitmqv1beta1.RabbitMQShovel)
+	if !ok {
+		return fmt.Errorf("expected a RabbitMQShovel object but got %T", obj)
+	}
+	shovellog.Info("Defaulting for RabbitMQShovel", "name", rabbitmqshovel.GetName())
+
+	rabbitmqshovel.Default(d.Client)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-rabbitmq-openstack-org-v1beta1-rabbitmqshovel,mutating=false,failurePolicy=fail,sideEffects=None,groups=rabbitmq.openstack.org,resources=rabbitmqshovels,verbs=create;update,versions=v1beta1,name=vrabbitmqshovel-v1beta1.kb.io,admissionReviewVersions=v1
+
+// RabbitMQShovelCustomValidator struct is responsible for validating the RabbitMQShovel resource
+// when it is created, updated, or deleted.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+// +kubebuilder:object:generate=false
+type RabbitMQShovelCustomValidator struct {
+	Client client.Client
+}
+
+var _ webhook.CustomValidator = &RabbitMQShovelCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type RabbitMQShovel.
+func (v *RabbitMQShovelCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	rabbitmqshovel, ok := obj.(*rabbitmqv1beta1.RabbitMQShovel)
+	if !ok {
+		return nil, fmt.Errorf("expected a RabbitMQShovel object but got %T", obj)
+	}
+	shovellog.Info("Validation for RabbitMQShovel upon creation", "name", rabbitmqshovel.GetName())
+
+	return rabbitmqshovel.ValidateCreate(v.Client)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type RabbitMQShovel.
+func (v *RabbitMQShovelCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	rabbitmqshovel, ok := newObj.(*rabbitmqv1beta1.RabbitMQShovel)
+	if !ok {
+		return nil, fmt.Errorf("expected a RabbitMQShovel object for the newObj but got %T", newObj)
+	}
+	shovellog.Info("Validation for RabbitMQShovel upon update", "name", rabbitmqshovel.GetName())
+
+	return rabbitmqshovel.ValidateUpdate(v.Client, oldObj)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type RabbitMQShovel.
+func (v *RabbitMQShovelCustomValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	rabbitmqshovel, ok := obj.(*rabbitmqv1beta1.RabbitMQShovel)
+	if !ok {
+		return nil, fmt.Errorf("expected a RabbitMQShovel object but got %T", obj)
+	}
+	shovellog.Info("Validation for RabbitMQShovel upon deletion", "name", rabbitmqshovel.GetName())
+
+	return rabbitmqshovel.ValidateDelete(v.Client)
+}
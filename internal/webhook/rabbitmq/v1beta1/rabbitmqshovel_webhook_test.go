@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+	. "github.com/onsi/gomega"    //revive:disable:dot-imports
+	rabbitmqv1beta1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("RabbitMQShovel webhook", func() {
+	var shovel *rabbitmqv1beta1.RabbitMQShovel
+
+	BeforeEach(func() {
+		shovel = &rabbitmqv1beta1.RabbitMQShovel{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-shovel",
+				Namespace: "default",
+			},
+			Spec: rabbitmqv1beta1.RabbitMQShovelSpec{
+				Source: rabbitmqv1beta1.RabbitMQShovelEndpoint{
+					RabbitmqClusterName: "cell1",
+					Queue:               "notifications.info",
+				},
+				Destination: rabbitmqv1beta1.RabbitMQShovelEndpoint{
+					RabbitmqClusterName: "rabbitmq",
+					Exchange:            "notifications",
+					ExchangeKey:         "info",
+				},
+				AckMode:     "on-confirm",
+				DeleteAfter: "never",
+			},
+		}
+	})
+
+	Context("Default method", func() {
+		It("should default Name to CR name when not specified", func() {
+			shovel.Default(k8sClient)
+
+			Expect(shovel.Spec.Name).To(Equal("test-shovel"))
+		})
+	})
+
+	Context("ValidateCreate method", func() {
+		BeforeEach(func() {
+			shovel.Default(k8sClient)
+		})
+
+		It("should accept a valid shovel", func() {
+			_, err := shovel.ValidateCreate(k8sClient)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a source without queue or exchange", func() {
+			shovel.Spec.Source.Queue = ""
+
+			_, err := shovel.ValidateCreate(k8sClient)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("either queue or exchange must be set"))
+		})
+
+		It("should reject setting both queue and exchange", func() {
+			shovel.Spec.Destination.Queue = "notifications.info"
+
+			_, err := shovel.ValidateCreate(k8sClient)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("queue and exchange are mutually exclusive"))
+		})
+
+		It("should reject deleteAfter queue-length without source queue", func() {
+			shovel.Spec.Source.Queue = ""
+			shovel.Spec.Source.Exchange = "notifications"
+			shovel.Spec.DeleteAfter = "queue-length"
+
+			_, err := shovel.ValidateCreate(k8sClient)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("queue-length requires a source queue"))
+		})
+
+		It("should reject a destination equal to the source", func() {
+			shovel.Spec.Destination = shovel.Spec.Source
+
+			_, err := shovel.ValidateCreate(k8sClient)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("destination must be different from the source"))
+		})
+	})
+
+	Context("ValidateUpdate method", func() {
+		BeforeEach(func() {
+			shovel.Default(k8sClient)
+		})
+
+		It("should allow changing the destination", func() {
+			newShovel := shovel.DeepCopy()
+			newShovel.Spec.Destination.RabbitmqClusterName = "cell2"
+
+			_, err := newShovel.ValidateUpdate(k8sClient, shovel)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject changing the source cluster", func() {
+			newShovel := shovel.DeepCopy()
+			newShovel.Spec.Source.RabbitmqClusterName = "cell2"
+
+			_, err := newShovel.ValidateUpdate(k8sClient, shovel)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("source cluster cannot be changed after creation"))
+		})
+	})
+})
@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//nolint:revive
+package api
+
+import (
+	"crypto/sha256"
+	"strconv"
+	"sync"
+)
+
+// cachedClient is a client together with the fingerprint of its credentials
+type cachedClient struct {
+	fingerprint [sha256.Size]byte
+	client      *Client
+}
+
+var (
+	clientCacheMu sync.Mutex
+	clientCache   = map[string]*cachedClient{}
+)
+
+// GetClient returns a RabbitMQ Management API client for the given cluster URL, reusing
+// the client and its connections as long as the credentials and the TLS settings don't change.
+func GetClient(baseURL, username, password string, tlsCfg TLSConfig) (*Client, error) {
+	fingerprint := clientFingerprint(username, password, tlsCfg)
+
+	clientCacheMu.Lock()
+	defer clientCacheMu.Unlock()
+
+	if cached, ok := clientCache[baseURL]; ok {
+		if cached.fingerprint == fingerprint {
+			return cached.client, nil
+		}
+		// Credentials or CA changed, drop the connections of the old client
+		cached.client.httpClient.CloseIdleConnections()
+	}
+
+	client, err := NewClient(baseURL, username, password, tlsCfg)
+	if err != nil {
+		return nil, err
+	}
+	clientCache[baseURL] = &cachedClient{fingerprint: fingerprint, client: client}
+	return client, nil
+}
+
+// clientFingerprint returns a hash of the settings of a client, so that the
+// credentials don't need to be kept in the cache key
+func clientFingerprint(username, password string, tlsCfg TLSConfig) [sha256.Size]byte {
+	h := sha256.New()
+	for _, field := range [][]byte{
+		[]byte(username),
+		[]byte(password),
+		[]byte(strconv.FormatBool(tlsCfg.Enabled)),
+		tlsCfg.CACert,
+		[]byte(strconv.FormatBool(tlsCfg.UseSystemCAs)),
+		[]byte(tlsCfg.ServerName),
+		[]byte(strconv.FormatBool(tlsCfg.InsecureSkipVerify)),
+	} {
+		h.Write([]byte(strconv.Itoa(len(field))))
+		h.Write([]byte{0})
+		h.Write(field)
+	}
+	var fingerprint [sha256.Size]byte
+	copy(fingerprint[:], h.Sum(nil))
+	return fingerprint
+}
@@ -21,13 +21,17 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"syscall"
 	"time"
 )
 
@@ -49,16 +53,55 @@ const (
 	DeleteTimeout = 60 * time.Second
 )
 
-// User represents a RabbitMQ user
+// Retry settings for idempotent RabbitMQ Management API requests
+const (
+	// retryMaxAttempts is the number of attempts made for an idempotent request
+	retryMaxAttempts = 3
+
+	// retryInitialBackoff is the delay before the first retry, doubled for every further retry
+	retryInitialBackoff = 500 * time.Millisecond
+
+	// maxDrainSize limits how much of an unread response body is drained before closing it
+	maxDrainSize = 64 * 1024
+)
+
+// User represents a RabbitMQ user. The password is only sent to RabbitMQ,
+// it returns the salted password hash instead.
 type User struct {
-	Name     string   `json:"name"`
-	Password string   `json:"password"`
-	Tags     []string `json:"tags"`
+	Name             string   `json:"name"`
+	Password         string   `json:"password"`
+	PasswordHash     string   `json:"password_hash,omitempty"`
+	HashingAlgorithm string   `json:"hashing_algorithm,omitempty"`
+	Tags             []string `json:"tags"`
 }
 
 // Vhost represents a RabbitMQ virtual host
 type Vhost struct {
-	Name string `json:"name"`
+	Name             string   `json:"name"`
+	Description      string   `json:"description"`
+	Tags             []string `json:"tags"`
+	DefaultQueueType string   `json:"default_queue_type,omitempty"`
+}
+
+// VhostLimits represents the limits of a RabbitMQ virtual host
+type VhostLimits struct {
+	Vhost string           `json:"vhost"`
+	Value map[string]int64 `json:"value"`
+}
+
+// Vhost limit names supported by the RabbitMQ Management API
+const (
+	// VhostLimitMaxConnections limits the number of connections to a vhost
+	VhostLimitMaxConnections = "max-connections"
+	// VhostLimitMaxQueues limits the number of queues in a vhost
+	VhostLimitMaxQueues = "max-queues"
+)
+
+// Connection represents a client connection to RabbitMQ
+type Connection struct {
+	Name  string `json:"name"`
+	Vhost string `json:"vhost"`
+	User  string `json:"user"`
 }
 
 // Permission represents RabbitMQ permissions
@@ -70,87 +113,306 @@ type Permission struct {
 	Read      string `json:"read"`
 }
 
-// Policy represents a RabbitMQ policy
+// TopicPermission represents RabbitMQ topic permissions on a topic exchange
+type TopicPermission struct {
+	User     string `json:"user,omitempty"`
+	Vhost    string `json:"vhost,omitempty"`
+	Exchange string `json:"exchange"`
+	Write    string `json:"write"`
+	Read     string `json:"read"`
+}
+
+// UserLimits represents the limits of a RabbitMQ user
+type UserLimits struct {
+	User  string           `json:"user"`
+	Value map[string]int64 `json:"value"`
+}
+
+// User limit names supported by the RabbitMQ Management API
+const (
+	// UserLimitMaxConnections limits the number of connections a user can open
+	UserLimitMaxConnections = "max-connections"
+	// UserLimitMaxChannels limits the number of channels a user can open
+	UserLimitMaxChannels = "max-channels"
+)
+
+// Policy represents a RabbitMQ policy. Vhost and Name are only returned by RabbitMQ.
 type Policy struct {
+	Vhost      string                 `json:"vhost,omitempty"`
+	Name       string                 `json:"name,omitempty"`
 	Pattern    string                 `json:"pattern"`
 	Definition map[string]interface{} `json:"definition"`
 	Priority   int                    `json:"priority"`
 	ApplyTo    string                 `json:"apply-to"`
 }
 
-// NewClient creates a new RabbitMQ Management API client
-func NewClient(baseURL, username, password string, tlsEnabled bool, caCert []byte) *Client {
-	httpClient := &http.Client{
-		Timeout: DefaultAPITimeout,
+// Queue represents a RabbitMQ queue
+type Queue struct {
+	Name       string                 `json:"name"`
+	Vhost      string                 `json:"vhost"`
+	Type       string                 `json:"type"`
+	Durable    bool                   `json:"durable"`
+	AutoDelete bool                   `json:"auto_delete"`
+	Arguments  map[string]interface{} `json:"arguments"`
+	Messages   int64                  `json:"messages"`
+	Consumers  int64                  `json:"consumers"`
+	Exclusive  bool                   `json:"exclusive"`
+	// Members and Online are only set for quorum queues
+	Members []string `json:"members,omitempty"`
+	Online  []string `json:"online,omitempty"`
+}
+
+// ShrinkResult is the result of removing the member on a node from a quorum queue
+type ShrinkResult struct {
+	Vhost  string `json:"vhost"`
+	Name   string `json:"name"`
+	Size   int    `json:"size"`
+	Result string `json:"result"`
+}
+
+// Exchange represents a RabbitMQ exchange
+type Exchange struct {
+	Type       string                 `json:"type"`
+	Durable    bool                   `json:"durable"`
+	AutoDelete bool                   `json:"auto_delete"`
+	Internal   bool                   `json:"internal"`
+	Arguments  map[string]interface{} `json:"arguments"`
+}
+
+// Binding represents a RabbitMQ binding between an exchange and a queue or exchange
+type Binding struct {
+	Source          string                 `json:"source"`
+	Vhost           string                 `json:"vhost"`
+	Destination     string                 `json:"destination"`
+	DestinationType string                 `json:"destination_type"`
+	RoutingKey      string                 `json:"routing_key"`
+	Arguments       map[string]interface{} `json:"arguments"`
+	PropertiesKey   string                 `json:"properties_key"`
+}
+
+// Binding destination types
+const (
+	// BindingDestinationQueue binds an exchange to a queue
+	BindingDestinationQueue = "queue"
+	// BindingDestinationExchange binds an exchange to another exchange
+	BindingDestinationExchange = "exchange"
+)
+
+// Runtime parameter components managed through the RabbitMQ Management API
+const (
+	// ParameterComponentShovel is the component of dynamic shovels
+	ParameterComponentShovel = "shovel"
+	// ParameterComponentFederationUpstream is the component of federation upstreams
+	ParameterComponentFederationUpstream = "federation-upstream"
+)
+
+// Shovel represents the definition of a RabbitMQ dynamic shovel
+type Shovel struct {
+	SrcProtocol      string   `json:"src-protocol"`
+	SrcURI           []string `json:"src-uri"`
+	SrcQueue         string   `json:"src-queue,omitempty"`
+	SrcExchange      string   `json:"src-exchange,omitempty"`
+	SrcExchangeKey   string   `json:"src-exchange-key,omitempty"`
+	SrcPrefetchCount *int32   `json:"src-prefetch-count,omitempty"`
+	SrcDeleteAfter   string   `json:"src-delete-after,omitempty"`
+	DestProtocol     string   `json:"dest-protocol"`
+	DestURI          []string `json:"dest-uri"`
+	DestQueue        string   `json:"dest-queue,omitempty"`
+	DestExchange     string   `json:"dest-exchange,omitempty"`
+	DestExchangeKey  string   `json:"dest-exchange-key,omitempty"`
+	AckMode          string   `json:"ack-mode,omitempty"`
+	ReconnectDelay   *int32   `json:"reconnect-delay,omitempty"`
+}
+
+// FederationUpstream represents the definition of a RabbitMQ federation upstream
+type FederationUpstream struct {
+	URI            []string `json:"uri"`
+	Exchange       string   `json:"exchange,omitempty"`
+	Queue          string   `json:"queue,omitempty"`
+	PrefetchCount  *int32   `json:"prefetch-count,omitempty"`
+	ReconnectDelay *int32   `json:"reconnect-delay,omitempty"`
+	AckMode        string   `json:"ack-mode,omitempty"`
+	MaxHops        *int32   `json:"max-hops,omitempty"`
+}
+
+// TLSConfig configures TLS for the RabbitMQ Management API. The certificate of the
+// server is verified against CACert, and the system trust store if UseSystemCAs is set.
+type TLSConfig struct {
+	// Enabled - use TLS to connect to the management API
+	Enabled bool
+	// CACert - PEM encoded CA certificates which sign the certificate of the server
+	CACert []byte
+	// UseSystemCAs - also trust the CAs of the system trust store
+	UseSystemCAs bool
+	// ServerName - hostname the certificate of the server is verified against,
+	// defaults to the host of the base URL
+	ServerName string
+	// InsecureSkipVerify - do not verify the certificate of the server
+	InsecureSkipVerify bool
+}
+
+// ErrNoCACert is returned when TLS is enabled without any CA to verify the server with
+var ErrNoCACert = errors.New("no CA certificate to verify the RabbitMQ management API")
+
+// newTLSClientConfig returns the TLS client configuration for the management API
+func newTLSClientConfig(cfg TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.ServerName,
 	}
 
-	if tlsEnabled {
-		tlsConfig := &tls.Config{
-			MinVersion: tls.VersionTLS12,
+	if cfg.InsecureSkipVerify {
+		tlsConfig.InsecureSkipVerify = true //nolint:gosec // explicit opt-out of the RabbitMq CR
+		return tlsConfig, nil
+	}
+
+	caCertPool := x509.NewCertPool()
+	if cfg.UseSystemCAs {
+		systemPool, err := x509.SystemCertPool()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the system trust store: %w", err)
 		}
+		caCertPool = systemPool
+	}
 
-		if len(caCert) > 0 {
-			caCertPool := x509.NewCertPool()
-			caCertPool.AppendCertsFromPEM(caCert)
-			tlsConfig.RootCAs = caCertPool
-		} else {
-			tlsConfig.InsecureSkipVerify = true
+	if len(cfg.CACert) > 0 {
+		if !caCertPool.AppendCertsFromPEM(cfg.CACert) {
+			return nil, errors.New("failed to parse the CA certificate of the RabbitMQ management API")
 		}
+	} else if !cfg.UseSystemCAs {
+		return nil, ErrNoCACert
+	}
+	tlsConfig.RootCAs = caCertPool
+
+	return tlsConfig, nil
+}
+
+// NewClient creates a new RabbitMQ Management API client. Clients keep their
+// connections alive between requests, use GetClient to share them between reconciles.
+func NewClient(baseURL, username, password string, tlsCfg TLSConfig) (*Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
 
-		httpClient.Transport = &http.Transport{
-			TLSClientConfig: tlsConfig,
+	if tlsCfg.Enabled {
+		tlsConfig, err := newTLSClientConfig(tlsCfg)
+		if err != nil {
+			return nil, err
 		}
+		transport.TLSClientConfig = tlsConfig
 	}
 
 	return &Client{
-		baseURL:    baseURL,
-		username:   username,
-		password:   password,
-		httpClient: httpClient,
-	}
+		baseURL:  baseURL,
+		username: username,
+		password: password,
+		httpClient: &http.Client{
+			Transport: transport,
+		},
+	}, nil
 }
 
 // doRequest performs an HTTP request with authentication using the default timeout
-func (c *Client) doRequest(method, path string, body interface{}) (*http.Response, error) {
-	return c.doRequestWithTimeout(method, path, body, DefaultAPITimeout)
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	return c.doRequestWithTimeout(ctx, method, path, body, DefaultAPITimeout)
 }
 
-// doRequestWithTimeout performs an HTTP request with authentication using a custom timeout
-func (c *Client) doRequestWithTimeout(method, path string, body interface{}, timeout time.Duration) (*http.Response, error) {
-	var reqBody io.Reader
+// doRequestWithTimeout performs an HTTP request with authentication using a custom timeout.
+// Idempotent requests are retried with backoff on server errors and connection resets.
+// The timeout applies to each attempt, the context to the whole request.
+func (c *Client) doRequestWithTimeout(ctx context.Context, method, path string, body interface{}, timeout time.Duration) (*http.Response, error) {
+	var jsonData []byte
 	if body != nil {
-		jsonData, err := json.Marshal(body)
+		var err error
+		jsonData, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonData)
 	}
 
+	backoff := retryInitialBackoff
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, method, path, jsonData, timeout)
+		if attempt >= retryMaxAttempts || !isIdempotent(method) || !shouldRetry(resp, err) {
+			if err != nil {
+				return nil, fmt.Errorf("request failed: %w", err)
+			}
+			return resp, nil
+		}
+		if resp != nil {
+			closeBody(resp)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("request failed: %w", ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// send performs a single attempt of an HTTP request. The attempt's timeout is
+// released when the response body is closed.
+func (c *Client) send(ctx context.Context, method, path string, jsonData []byte, timeout time.Duration) (*http.Response, error) {
+	var reqBody io.Reader
+	if jsonData != nil {
+		reqBody = bytes.NewReader(jsonData)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	url := fmt.Sprintf("%s%s", c.baseURL, path)
-	req, err := http.NewRequest(method, url, reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
+		cancel()
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.SetBasicAuth(c.username, c.password)
 	req.Header.Set("Content-Type", "application/json")
 
-	// Create a client with custom timeout for this request
-	client := &http.Client{
-		Timeout:   timeout,
-		Transport: c.httpClient.Transport,
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
 	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
 
-	resp, err := client.Do(req)
+	return resp, nil
+}
+
+// cancelOnClose releases the context of a request when its response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the response body and releases the context of the request
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// isIdempotent returns true if a request with the given method can be safely retried
+func isIdempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodPut || method == http.MethodDelete
+}
+
+// shouldRetry returns true if the request failed with a server error or a connection reset
+func shouldRetry(resp *http.Response, err error) bool {
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
 	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
 
-	return resp, nil
+// closeBody drains and closes a response body, so that the connection can be reused
+func closeBody(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainSize))
+	_ = resp.Body.Close()
 }
 
 // CreateOrUpdateUser creates or updates a RabbitMQ user
-func (c *Client) CreateOrUpdateUser(name, password string, tags []string) error {
+func (c *Client) CreateOrUpdateUser(ctx context.Context, name, password string, tags []string) error {
 	if tags == nil {
 		tags = []string{}
 	}
@@ -162,85 +424,306 @@ func (c *Client) CreateOrUpdateUser(name, password string, tags []string) error
 	}
 
 	encodedName := url.PathEscape(name)
-	resp, err := c.doRequest("PUT", fmt.Sprintf("/api/users/%s", encodedName), user)
+	resp, err := c.doRequest(ctx, "PUT", fmt.Sprintf("/api/users/%s", encodedName), user)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
+	defer closeBody(resp)
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to create/update user %s: status %d, body: %s", name, resp.StatusCode, string(body))
+		return fmt.Errorf("failed to create/update user %s: %w", name, newAPIError(resp))
 	}
 
 	return nil
 }
 
+// CreateOrUpdateUserWithHash creates or updates a RabbitMQ user with a precomputed password hash,
+// so that the plaintext password is never sent to RabbitMQ
+func (c *Client) CreateOrUpdateUserWithHash(ctx context.Context, name, passwordHash, hashingAlgorithm string, tags []string) error {
+	if tags == nil {
+		tags = []string{}
+	}
+
+	// The password must not be sent, RabbitMQ would hash it instead of using the password hash
+	user := struct {
+		PasswordHash     string   `json:"password_hash"`
+		HashingAlgorithm string   `json:"hashing_algorithm,omitempty"`
+		Tags             []string `json:"tags"`
+	}{
+		PasswordHash:     passwordHash,
+		HashingAlgorithm: hashingAlgorithm,
+		Tags:             tags,
+	}
+
+	encodedName := url.PathEscape(name)
+	resp, err := c.doRequest(ctx, "PUT", fmt.Sprintf("/api/users/%s", encodedName), user)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to create/update user %s: %w", name, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// GetUser returns a RabbitMQ user, or nil if the user does not exist
+func (c *Client) GetUser(ctx context.Context, name string) (*User, error) {
+	encodedName := url.PathEscape(name)
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/api/users/%s", encodedName), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get user %s: %w", name, newAPIError(resp))
+	}
+
+	user := &User{}
+	if err := json.NewDecoder(resp.Body).Decode(user); err != nil {
+		return nil, fmt.Errorf("failed to decode user %s: %w", name, err)
+	}
+
+	return user, nil
+}
+
+// ListUsers returns all RabbitMQ users
+func (c *Client) ListUsers(ctx context.Context) ([]User, error) {
+	resp, err := c.doRequest(ctx, "GET", "/api/users", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list users: %w", newAPIError(resp))
+	}
+
+	users := []User{}
+	if err := json.NewDecoder(resp.Body).Decode(&users); err != nil {
+		return nil, fmt.Errorf("failed to decode users: %w", err)
+	}
+
+	return users, nil
+}
+
 // DeleteUser deletes a RabbitMQ user
-func (c *Client) DeleteUser(name string) error {
+func (c *Client) DeleteUser(ctx context.Context, name string) error {
 	encodedName := url.PathEscape(name)
-	resp, err := c.doRequestWithTimeout("DELETE", fmt.Sprintf("/api/users/%s", encodedName), nil, DeleteTimeout)
+	resp, err := c.doRequestWithTimeout(ctx, "DELETE", fmt.Sprintf("/api/users/%s", encodedName), nil, DeleteTimeout)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
+	defer closeBody(resp)
 
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete user %s: status %d, body: %s", name, resp.StatusCode, string(body))
+		return fmt.Errorf("failed to delete user %s: %w", name, newAPIError(resp))
 	}
 
 	return nil
 }
 
-// CreateOrUpdateVhost creates or updates a RabbitMQ vhost
-func (c *Client) CreateOrUpdateVhost(name string) error {
+// CreateOrUpdateVhost creates or updates a RabbitMQ vhost. An empty defaultQueueType
+// leaves the default queue type of the vhost unchanged.
+func (c *Client) CreateOrUpdateVhost(ctx context.Context, name, description string, tags []string, defaultQueueType string) error {
+	if tags == nil {
+		tags = []string{}
+	}
+
 	vhost := Vhost{
-		Name: name,
+		Name:             name,
+		Description:      description,
+		Tags:             tags,
+		DefaultQueueType: defaultQueueType,
+	}
+
+	encodedName := url.PathEscape(name)
+	resp, err := c.doRequest(ctx, "PUT", fmt.Sprintf("/api/vhosts/%s", encodedName), vhost)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to create/update vhost %s: %w", name, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// GetVhost returns a RabbitMQ vhost, or nil if the vhost does not exist
+func (c *Client) GetVhost(ctx context.Context, name string) (*Vhost, error) {
+	encodedName := url.PathEscape(name)
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/api/vhosts/%s", encodedName), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get vhost %s: %w", name, newAPIError(resp))
+	}
+
+	vhost := &Vhost{}
+	if err := json.NewDecoder(resp.Body).Decode(vhost); err != nil {
+		return nil, fmt.Errorf("failed to decode vhost %s: %w", name, err)
+	}
+
+	return vhost, nil
+}
+
+// ListVhosts returns all RabbitMQ vhosts
+func (c *Client) ListVhosts(ctx context.Context) ([]Vhost, error) {
+	resp, err := c.doRequest(ctx, "GET", "/api/vhosts", nil)
+	if err != nil {
+		return nil, err
 	}
+	defer closeBody(resp)
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list vhosts: %w", newAPIError(resp))
+	}
+
+	vhosts := []Vhost{}
+	if err := json.NewDecoder(resp.Body).Decode(&vhosts); err != nil {
+		return nil, fmt.Errorf("failed to decode vhosts: %w", err)
+	}
+
+	return vhosts, nil
+}
+
+// GetVhostLimits returns the limits set on a RabbitMQ vhost, keyed by limit name
+func (c *Client) GetVhostLimits(ctx context.Context, name string) (map[string]int64, error) {
 	encodedName := url.PathEscape(name)
-	resp, err := c.doRequest("PUT", fmt.Sprintf("/api/vhosts/%s", encodedName), vhost)
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/api/vhost-limits/%s", encodedName), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get limits of vhost %s: %w", name, newAPIError(resp))
+	}
+
+	limits := []VhostLimits{}
+	if err := json.NewDecoder(resp.Body).Decode(&limits); err != nil {
+		return nil, fmt.Errorf("failed to decode limits of vhost %s: %w", name, err)
+	}
+
+	result := map[string]int64{}
+	for _, l := range limits {
+		for limit, value := range l.Value {
+			result[limit] = value
+		}
+	}
+
+	return result, nil
+}
+
+// SetVhostLimit sets a limit (e.g. max-queues) on a RabbitMQ vhost
+func (c *Client) SetVhostLimit(ctx context.Context, vhost, limit string, value int64) error {
+	body := map[string]int64{
+		"value": value,
+	}
+
+	encodedVhost := url.PathEscape(vhost)
+	encodedLimit := url.PathEscape(limit)
+	resp, err := c.doRequest(ctx, "PUT", fmt.Sprintf("/api/vhost-limits/%s/%s", encodedVhost, encodedLimit), body)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
+	defer closeBody(resp)
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to create/update vhost %s: status %d, body: %s", name, resp.StatusCode, string(body))
+		return fmt.Errorf("failed to set limit %s on vhost %s: %w", limit, vhost, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// DeleteVhostLimit removes a limit from a RabbitMQ vhost
+func (c *Client) DeleteVhostLimit(ctx context.Context, vhost, limit string) error {
+	encodedVhost := url.PathEscape(vhost)
+	encodedLimit := url.PathEscape(limit)
+	resp, err := c.doRequestWithTimeout(ctx, "DELETE", fmt.Sprintf("/api/vhost-limits/%s/%s", encodedVhost, encodedLimit), nil, DeleteTimeout)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete limit %s from vhost %s: %w", limit, vhost, newAPIError(resp))
 	}
 
 	return nil
 }
 
 // DeleteVhost deletes a RabbitMQ vhost
-func (c *Client) DeleteVhost(name string) error {
+func (c *Client) DeleteVhost(ctx context.Context, name string) error {
 	encodedName := url.PathEscape(name)
-	resp, err := c.doRequestWithTimeout("DELETE", fmt.Sprintf("/api/vhosts/%s", encodedName), nil, DeleteTimeout)
+	resp, err := c.doRequestWithTimeout(ctx, "DELETE", fmt.Sprintf("/api/vhosts/%s", encodedName), nil, DeleteTimeout)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
+	defer closeBody(resp)
 
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete vhost %s: status %d, body: %s", name, resp.StatusCode, string(body))
+		return fmt.Errorf("failed to delete vhost %s: %w", name, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// ListVhostConnections returns the client connections to a RabbitMQ vhost
+func (c *Client) ListVhostConnections(ctx context.Context, vhost string) ([]Connection, error) {
+	encodedVhost := url.PathEscape(vhost)
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/api/vhosts/%s/connections", encodedVhost), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list connections of vhost %s: %w", vhost, newAPIError(resp))
+	}
+
+	connections := []Connection{}
+	if err := json.NewDecoder(resp.Body).Decode(&connections); err != nil {
+		return nil, fmt.Errorf("failed to decode connections of vhost %s: %w", vhost, err)
+	}
+
+	return connections, nil
+}
+
+// CloseConnection closes a client connection to RabbitMQ
+func (c *Client) CloseConnection(ctx context.Context, name string) error {
+	encodedName := url.PathEscape(name)
+	resp, err := c.doRequestWithTimeout(ctx, "DELETE", fmt.Sprintf("/api/connections/%s", encodedName), nil, DeleteTimeout)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to close connection %s: %w", name, newAPIError(resp))
 	}
 
 	return nil
 }
 
 // SetPermissions sets permissions for a user on a vhost
-func (c *Client) SetPermissions(vhost, user, configure, write, read string) error {
+func (c *Client) SetPermissions(ctx context.Context, vhost, user, configure, write, read string) error {
 	// The request body should only contain the permission fields, not user/vhost
 	perm := map[string]string{
 		"configure": configure,
@@ -252,44 +735,202 @@ func (c *Client) SetPermissions(vhost, user, configure, write, read string) erro
 	encodedVhost := url.PathEscape(vhost)
 	encodedUser := url.PathEscape(user)
 
-	resp, err := c.doRequest("PUT", fmt.Sprintf("/api/permissions/%s/%s", encodedVhost, encodedUser), perm)
+	resp, err := c.doRequest(ctx, "PUT", fmt.Sprintf("/api/permissions/%s/%s", encodedVhost, encodedUser), perm)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
+	defer closeBody(resp)
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to set permissions for user %s on vhost %s: status %d, body: %s", user, vhost, resp.StatusCode, string(body))
+		return fmt.Errorf("failed to set permissions for user %s on vhost %s: %w", user, vhost, newAPIError(resp))
 	}
 
 	return nil
 }
 
+// GetPermissions returns the permissions of a user on a vhost, or nil if none are set
+func (c *Client) GetPermissions(ctx context.Context, vhost, user string) (*Permission, error) {
+	encodedVhost := url.PathEscape(vhost)
+	encodedUser := url.PathEscape(user)
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/api/permissions/%s/%s", encodedVhost, encodedUser), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get permissions for user %s on vhost %s: %w", user, vhost, newAPIError(resp))
+	}
+
+	perm := &Permission{}
+	if err := json.NewDecoder(resp.Body).Decode(perm); err != nil {
+		return nil, fmt.Errorf("failed to decode permissions for user %s on vhost %s: %w", user, vhost, err)
+	}
+
+	return perm, nil
+}
+
 // DeletePermissions deletes permissions for a user on a vhost
-func (c *Client) DeletePermissions(vhost, user string) error {
+func (c *Client) DeletePermissions(ctx context.Context, vhost, user string) error {
+	encodedVhost := url.PathEscape(vhost)
+	encodedUser := url.PathEscape(user)
+	resp, err := c.doRequestWithTimeout(ctx, "DELETE", fmt.Sprintf("/api/permissions/%s/%s", encodedVhost, encodedUser), nil, DeleteTimeout)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete permissions for user %s on vhost %s: %w", user, vhost, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// SetTopicPermissions sets topic permissions for a user on a topic exchange of a vhost
+func (c *Client) SetTopicPermissions(ctx context.Context, vhost, user, exchange, write, read string) error {
+	perm := TopicPermission{
+		Exchange: exchange,
+		Write:    write,
+		Read:     read,
+	}
+
 	encodedVhost := url.PathEscape(vhost)
 	encodedUser := url.PathEscape(user)
-	resp, err := c.doRequestWithTimeout("DELETE", fmt.Sprintf("/api/permissions/%s/%s", encodedVhost, encodedUser), nil, DeleteTimeout)
+	resp, err := c.doRequest(ctx, "PUT", fmt.Sprintf("/api/topic-permissions/%s/%s", encodedVhost, encodedUser), perm)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to set topic permissions for user %s on exchange %s in vhost %s: %w", user, exchange, vhost, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// ListTopicPermissions returns the topic permissions of a user on a vhost
+func (c *Client) ListTopicPermissions(ctx context.Context, vhost, user string) ([]TopicPermission, error) {
+	encodedVhost := url.PathEscape(vhost)
+	encodedUser := url.PathEscape(user)
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/api/topic-permissions/%s/%s", encodedVhost, encodedUser), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+
+	// No topic permissions set for the user on this vhost
+	if resp.StatusCode == http.StatusNotFound {
+		return []TopicPermission{}, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list topic permissions for user %s on vhost %s: %w", user, vhost, newAPIError(resp))
+	}
+
+	perms := []TopicPermission{}
+	if err := json.NewDecoder(resp.Body).Decode(&perms); err != nil {
+		return nil, fmt.Errorf("failed to decode topic permissions for user %s on vhost %s: %w", user, vhost, err)
+	}
+
+	return perms, nil
+}
+
+// DeleteTopicPermissions deletes all topic permissions of a user on a vhost
+func (c *Client) DeleteTopicPermissions(ctx context.Context, vhost, user string) error {
+	encodedVhost := url.PathEscape(vhost)
+	encodedUser := url.PathEscape(user)
+	resp, err := c.doRequestWithTimeout(ctx, "DELETE", fmt.Sprintf("/api/topic-permissions/%s/%s", encodedVhost, encodedUser), nil, DeleteTimeout)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
 
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete permissions for user %s on vhost %s: status %d, body: %s", user, vhost, resp.StatusCode, string(body))
+		return fmt.Errorf("failed to delete topic permissions for user %s on vhost %s: %w", user, vhost, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// GetUserLimits returns the limits set on a RabbitMQ user, keyed by limit name
+func (c *Client) GetUserLimits(ctx context.Context, user string) (map[string]int64, error) {
+	encodedUser := url.PathEscape(user)
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/api/user-limits/%s", encodedUser), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+
+	// The user does not exist yet
+	if resp.StatusCode == http.StatusNotFound {
+		return map[string]int64{}, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get limits of user %s: %w", user, newAPIError(resp))
+	}
+
+	limits := []UserLimits{}
+	if err := json.NewDecoder(resp.Body).Decode(&limits); err != nil {
+		return nil, fmt.Errorf("failed to decode limits of user %s: %w", user, err)
+	}
+
+	result := map[string]int64{}
+	for _, l := range limits {
+		for limit, value := range l.Value {
+			result[limit] = value
+		}
+	}
+
+	return result, nil
+}
+
+// SetUserLimit sets a limit (e.g. max-connections) for a user
+func (c *Client) SetUserLimit(ctx context.Context, user, limit string, value int64) error {
+	body := map[string]int64{
+		"value": value,
+	}
+
+	encodedUser := url.PathEscape(user)
+	encodedLimit := url.PathEscape(limit)
+	resp, err := c.doRequest(ctx, "PUT", fmt.Sprintf("/api/user-limits/%s/%s", encodedUser, encodedLimit), body)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to set limit %s for user %s: %w", limit, user, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// DeleteUserLimit removes a limit from a user
+func (c *Client) DeleteUserLimit(ctx context.Context, user, limit string) error {
+	encodedUser := url.PathEscape(user)
+	encodedLimit := url.PathEscape(limit)
+	resp, err := c.doRequestWithTimeout(ctx, "DELETE", fmt.Sprintf("/api/user-limits/%s/%s", encodedUser, encodedLimit), nil, DeleteTimeout)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete limit %s for user %s: %w", limit, user, newAPIError(resp))
 	}
 
 	return nil
 }
 
 // CreateOrUpdatePolicy creates or updates a RabbitMQ policy
-func (c *Client) CreateOrUpdatePolicy(vhost, name, pattern string, definition map[string]interface{}, priority int, applyTo string) error {
+func (c *Client) CreateOrUpdatePolicy(ctx context.Context, vhost, name, pattern string, definition map[string]interface{}, priority int, applyTo string) error {
 	if applyTo == "" {
 		applyTo = "all"
 	}
@@ -303,38 +944,441 @@ func (c *Client) CreateOrUpdatePolicy(vhost, name, pattern string, definition ma
 
 	encodedVhost := url.PathEscape(vhost)
 	encodedName := url.PathEscape(name)
-	resp, err := c.doRequest("PUT", fmt.Sprintf("/api/policies/%s/%s", encodedVhost, encodedName), policy)
+	resp, err := c.doRequest(ctx, "PUT", fmt.Sprintf("/api/policies/%s/%s", encodedVhost, encodedName), policy)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
+	defer closeBody(resp)
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to create/update policy %s on vhost %s: status %d, body: %s", name, vhost, resp.StatusCode, string(body))
+		return fmt.Errorf("failed to create/update policy %s on vhost %s: %w", name, vhost, newAPIError(resp))
 	}
 
 	return nil
 }
 
+// GetPolicy returns a RabbitMQ policy, or nil if the policy does not exist
+func (c *Client) GetPolicy(ctx context.Context, vhost, name string) (*Policy, error) {
+	encodedVhost := url.PathEscape(vhost)
+	encodedName := url.PathEscape(name)
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/api/policies/%s/%s", encodedVhost, encodedName), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get policy %s on vhost %s: %w", name, vhost, newAPIError(resp))
+	}
+
+	policy := &Policy{}
+	if err := json.NewDecoder(resp.Body).Decode(policy); err != nil {
+		return nil, fmt.Errorf("failed to decode policy %s on vhost %s: %w", name, vhost, err)
+	}
+
+	return policy, nil
+}
+
+// ListPolicies returns all RabbitMQ policies
+func (c *Client) ListPolicies(ctx context.Context) ([]Policy, error) {
+	resp, err := c.doRequest(ctx, "GET", "/api/policies", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list policies: %w", newAPIError(resp))
+	}
+
+	policies := []Policy{}
+	if err := json.NewDecoder(resp.Body).Decode(&policies); err != nil {
+		return nil, fmt.Errorf("failed to decode policies: %w", err)
+	}
+
+	return policies, nil
+}
+
 // DeletePolicy deletes a RabbitMQ policy
-func (c *Client) DeletePolicy(vhost, name string) error {
+func (c *Client) DeletePolicy(ctx context.Context, vhost, name string) error {
 	encodedVhost := url.PathEscape(vhost)
 	encodedName := url.PathEscape(name)
-	resp, err := c.doRequestWithTimeout("DELETE", fmt.Sprintf("/api/policies/%s/%s", encodedVhost, encodedName), nil, DeleteTimeout)
+	resp, err := c.doRequestWithTimeout(ctx, "DELETE", fmt.Sprintf("/api/policies/%s/%s", encodedVhost, encodedName), nil, DeleteTimeout)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		_ = resp.Body.Close()
-	}()
+	defer closeBody(resp)
 
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete policy %s on vhost %s: status %d, body: %s", name, vhost, resp.StatusCode, string(body))
+		return fmt.Errorf("failed to delete policy %s on vhost %s: %w", name, vhost, newAPIError(resp))
 	}
 
 	return nil
 }
+
+// CreateOrUpdateQueue declares a RabbitMQ queue. The properties of an existing
+// queue can't be changed, RabbitMQ rejects the request if they differ.
+func (c *Client) CreateOrUpdateQueue(ctx context.Context, vhost, name string, durable, autoDelete bool, arguments map[string]interface{}) error {
+	if arguments == nil {
+		arguments = map[string]interface{}{}
+	}
+
+	queue := map[string]interface{}{
+		"durable":     durable,
+		"auto_delete": autoDelete,
+		"arguments":   arguments,
+	}
+
+	encodedVhost := url.PathEscape(vhost)
+	encodedName := url.PathEscape(name)
+	resp, err := c.doRequest(ctx, "PUT", fmt.Sprintf("/api/queues/%s/%s", encodedVhost, encodedName), queue)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to create/update queue %s on vhost %s: %w", name, vhost, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// GetQueue returns a RabbitMQ queue, or nil if the queue does not exist
+func (c *Client) GetQueue(ctx context.Context, vhost, name string) (*Queue, error) {
+	encodedVhost := url.PathEscape(vhost)
+	encodedName := url.PathEscape(name)
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/api/queues/%s/%s", encodedVhost, encodedName), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get queue %s on vhost %s: %w", name, vhost, newAPIError(resp))
+	}
+
+	queue := &Queue{}
+	if err := json.NewDecoder(resp.Body).Decode(queue); err != nil {
+		return nil, fmt.Errorf("failed to decode queue %s on vhost %s: %w", name, vhost, err)
+	}
+
+	return queue, nil
+}
+
+// ListQueues returns all queues of a RabbitMQ vhost
+func (c *Client) ListQueues(ctx context.Context, vhost string) ([]Queue, error) {
+	encodedVhost := url.PathEscape(vhost)
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/api/queues/%s", encodedVhost), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list queues on vhost %s: %w", vhost, newAPIError(resp))
+	}
+
+	queues := []Queue{}
+	if err := json.NewDecoder(resp.Body).Decode(&queues); err != nil {
+		return nil, fmt.Errorf("failed to decode queues on vhost %s: %w", vhost, err)
+	}
+
+	return queues, nil
+}
+
+// ListAllQueues returns the queues of all vhosts
+func (c *Client) ListAllQueues(ctx context.Context) ([]Queue, error) {
+	resp, err := c.doRequest(ctx, "GET", "/api/queues", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list queues: %w", newAPIError(resp))
+	}
+
+	queues := []Queue{}
+	if err := json.NewDecoder(resp.Body).Decode(&queues); err != nil {
+		return nil, fmt.Errorf("failed to decode queues: %w", err)
+	}
+
+	return queues, nil
+}
+
+// ShrinkQuorumQueues removes the members on a node from all quorum queues, the
+// equivalent of rabbitmq-queues shrink. It fails if the member could not be removed
+// from any of the queues.
+func (c *Client) ShrinkQuorumQueues(ctx context.Context, node string) error {
+	encodedNode := url.PathEscape(node)
+	resp, err := c.doRequestWithTimeout(ctx, "DELETE", fmt.Sprintf("/api/queues/quorum/replicas/on/%s/shrink", encodedNode), nil, DeleteTimeout)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to shrink quorum queues on node %s: %w", node, newAPIError(resp))
+	}
+
+	results := []ShrinkResult{}
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return fmt.Errorf("failed to decode shrink results of node %s: %w", node, err)
+	}
+	failed := []string{}
+	for _, result := range results {
+		if result.Result != "ok" {
+			failed = append(failed, fmt.Sprintf("%s/%s: %s", result.Vhost, result.Name, result.Result))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to shrink quorum queues on node %s: %s", node, strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// DeleteQueue deletes a RabbitMQ queue. With ifEmpty set RabbitMQ refuses
+// to delete the queue if it still holds messages.
+func (c *Client) DeleteQueue(ctx context.Context, vhost, name string, ifEmpty bool) error {
+	encodedVhost := url.PathEscape(vhost)
+	encodedName := url.PathEscape(name)
+	path := fmt.Sprintf("/api/queues/%s/%s", encodedVhost, encodedName)
+	if ifEmpty {
+		path += "?if-empty=true"
+	}
+
+	resp, err := c.doRequestWithTimeout(ctx, "DELETE", path, nil, DeleteTimeout)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete queue %s on vhost %s: %w", name, vhost, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// CreateOrUpdateExchange declares a RabbitMQ exchange. The properties of an existing
+// exchange can't be changed, RabbitMQ rejects the request if they differ.
+func (c *Client) CreateOrUpdateExchange(ctx context.Context, vhost, name, exchangeType string, durable, autoDelete, internal bool, arguments map[string]interface{}) error {
+	if arguments == nil {
+		arguments = map[string]interface{}{}
+	}
+
+	exchange := Exchange{
+		Type:       exchangeType,
+		Durable:    durable,
+		AutoDelete: autoDelete,
+		Internal:   internal,
+		Arguments:  arguments,
+	}
+
+	encodedVhost := url.PathEscape(vhost)
+	encodedName := url.PathEscape(name)
+	resp, err := c.doRequest(ctx, "PUT", fmt.Sprintf("/api/exchanges/%s/%s", encodedVhost, encodedName), exchange)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to create/update exchange %s on vhost %s: %w", name, vhost, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// DeleteExchange deletes a RabbitMQ exchange
+func (c *Client) DeleteExchange(ctx context.Context, vhost, name string) error {
+	encodedVhost := url.PathEscape(vhost)
+	encodedName := url.PathEscape(name)
+	resp, err := c.doRequestWithTimeout(ctx, "DELETE", fmt.Sprintf("/api/exchanges/%s/%s", encodedVhost, encodedName), nil, DeleteTimeout)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete exchange %s on vhost %s: %w", name, vhost, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// bindingPath returns the management API path of the bindings between
+// the source exchange and the destination queue or exchange
+func bindingPath(vhost, source, destinationType, destination string) (string, error) {
+	var destinationPrefix string
+	switch destinationType {
+	case BindingDestinationQueue:
+		destinationPrefix = "q"
+	case BindingDestinationExchange:
+		destinationPrefix = "e"
+	default:
+		return "", fmt.Errorf("invalid binding destination type %q", destinationType)
+	}
+
+	return fmt.Sprintf("/api/bindings/%s/e/%s/%s/%s",
+		url.PathEscape(vhost), url.PathEscape(source), destinationPrefix, url.PathEscape(destination)), nil
+}
+
+// CreateBinding binds the source exchange to the destination queue or exchange
+func (c *Client) CreateBinding(ctx context.Context, vhost, source, destinationType, destination, routingKey string, arguments map[string]interface{}) error {
+	if arguments == nil {
+		arguments = map[string]interface{}{}
+	}
+
+	path, err := bindingPath(vhost, source, destinationType, destination)
+	if err != nil {
+		return err
+	}
+
+	binding := map[string]interface{}{
+		"routing_key": routingKey,
+		"arguments":   arguments,
+	}
+
+	resp, err := c.doRequest(ctx, "POST", path, binding)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to create binding from %s to %s %s on vhost %s: %w", source, destinationType, destination, vhost, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// ListBindings returns the bindings between the source exchange and the destination queue or exchange
+func (c *Client) ListBindings(ctx context.Context, vhost, source, destinationType, destination string) ([]Binding, error) {
+	path, err := bindingPath(vhost, source, destinationType, destination)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+
+	// Source or destination does not exist (yet)
+	if resp.StatusCode == http.StatusNotFound {
+		return []Binding{}, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list bindings from %s to %s %s on vhost %s: %w", source, destinationType, destination, vhost, newAPIError(resp))
+	}
+
+	bindings := []Binding{}
+	if err := json.NewDecoder(resp.Body).Decode(&bindings); err != nil {
+		return nil, fmt.Errorf("failed to decode bindings from %s to %s %s on vhost %s: %w", source, destinationType, destination, vhost, err)
+	}
+
+	return bindings, nil
+}
+
+// DeleteBinding deletes the binding identified by its properties key
+func (c *Client) DeleteBinding(ctx context.Context, vhost, source, destinationType, destination, propertiesKey string) error {
+	path, err := bindingPath(vhost, source, destinationType, destination)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doRequestWithTimeout(ctx, "DELETE", fmt.Sprintf("%s/%s", path, url.PathEscape(propertiesKey)), nil, DeleteTimeout)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete binding from %s to %s %s on vhost %s: %w", source, destinationType, destination, vhost, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// setParameter creates or updates a runtime parameter of the given component
+func (c *Client) setParameter(ctx context.Context, component, vhost, name string, value interface{}) error {
+	encodedVhost := url.PathEscape(vhost)
+	encodedName := url.PathEscape(name)
+	parameter := map[string]interface{}{
+		"value": value,
+	}
+
+	resp, err := c.doRequest(ctx, "PUT", fmt.Sprintf("/api/parameters/%s/%s/%s", component, encodedVhost, encodedName), parameter)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to set %s parameter %s on vhost %s: %w", component, name, vhost, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// deleteParameter deletes a runtime parameter of the given component
+func (c *Client) deleteParameter(ctx context.Context, component, vhost, name string) error {
+	encodedVhost := url.PathEscape(vhost)
+	encodedName := url.PathEscape(name)
+	resp, err := c.doRequestWithTimeout(ctx, "DELETE", fmt.Sprintf("/api/parameters/%s/%s/%s", component, encodedVhost, encodedName), nil, DeleteTimeout)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete %s parameter %s on vhost %s: %w", component, name, vhost, newAPIError(resp))
+	}
+
+	return nil
+}
+
+// CreateOrUpdateShovel creates or updates a dynamic shovel. Requires the
+// rabbitmq_shovel plugin to be enabled.
+func (c *Client) CreateOrUpdateShovel(ctx context.Context, vhost, name string, shovel Shovel) error {
+	if shovel.SrcProtocol == "" {
+		shovel.SrcProtocol = "amqp091"
+	}
+	if shovel.DestProtocol == "" {
+		shovel.DestProtocol = "amqp091"
+	}
+
+	return c.setParameter(ctx, ParameterComponentShovel, vhost, name, shovel)
+}
+
+// DeleteShovel deletes a dynamic shovel
+func (c *Client) DeleteShovel(ctx context.Context, vhost, name string) error {
+	return c.deleteParameter(ctx, ParameterComponentShovel, vhost, name)
+}
+
+// CreateOrUpdateFederationUpstream creates or updates a federation upstream.
+// Requires the rabbitmq_federation plugin to be enabled.
+func (c *Client) CreateOrUpdateFederationUpstream(ctx context.Context, vhost, name string, upstream FederationUpstream) error {
+	return c.setParameter(ctx, ParameterComponentFederationUpstream, vhost, name, upstream)
+}
+
+// DeleteFederationUpstream deletes a federation upstream
+func (c *Client) DeleteFederationUpstream(ctx context.Context, vhost, name string) error {
+	return c.deleteParameter(ctx, ParameterComponentFederationUpstream, vhost, name)
+}
@@ -18,16 +18,32 @@ limitations under the License.
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 )
 
+// newTestClient returns a client without TLS for the test server
+func newTestClient(t *testing.T, baseURL string) *Client {
+	t.Helper()
+	client, err := NewClient(baseURL, "admin", "admin", TLSConfig{})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	return client
+}
+
 func TestNewClient(t *testing.T) {
-	client := NewClient("http://localhost:15672", "user", "pass", false, nil)
-	if client == nil {
-		t.Fatal("Expected client to be created")
+	client, err := NewClient("http://localhost:15672", "user", "pass", TLSConfig{})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
 	}
 	if client.baseURL != "http://localhost:15672" {
 		t.Errorf("Expected baseURL http://localhost:15672, got %s", client.baseURL)
@@ -55,13 +71,67 @@ func TestCreateOrUpdateUser(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "admin", "admin", false, nil)
-	err := client.CreateOrUpdateUser("testuser", "testpass", []string{"monitoring"})
+	client := newTestClient(t, server.URL)
+	err := client.CreateOrUpdateUser(context.Background(), "testuser", "testpass", []string{"monitoring"})
 	if err != nil {
 		t.Errorf("CreateOrUpdateUser failed: %v", err)
 	}
 }
 
+func TestCreateOrUpdateUserWithHash(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected PUT request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/users/testuser" {
+			t.Errorf("Expected /api/users/testuser, got %s", r.URL.Path)
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := body["password"]; ok {
+			t.Errorf("Expected no password to be sent, got %+v", body)
+		}
+		if body["password_hash"] != "c2FsdGhhc2g=" || body["hashing_algorithm"] != HashingAlgorithmSHA512 {
+			t.Errorf("Unexpected user data: %+v", body)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	err := client.CreateOrUpdateUserWithHash(context.Background(), "testuser", "c2FsdGhhc2g=", HashingAlgorithmSHA512, nil)
+	if err != nil {
+		t.Errorf("CreateOrUpdateUserWithHash failed: %v", err)
+	}
+}
+
+func TestListUsers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/users" {
+			t.Errorf("Expected /api/users, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[{"name":"admin","tags":["administrator"]},{"name":"nova","tags":[]}]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	result, err := client.ListUsers(context.Background())
+	if err != nil {
+		t.Fatalf("ListUsers failed: %v", err)
+	}
+	if len(result) != 2 || result[1].Name != "nova" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
 func TestDeleteUser(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "DELETE" {
@@ -74,8 +144,8 @@ func TestDeleteUser(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "admin", "admin", false, nil)
-	err := client.DeleteUser("testuser")
+	client := newTestClient(t, server.URL)
+	err := client.DeleteUser(context.Background(), "testuser")
 	if err != nil {
 		t.Errorf("DeleteUser failed: %v", err)
 	}
@@ -89,17 +159,152 @@ func TestCreateOrUpdateVhost(t *testing.T) {
 		if r.URL.Path != "/api/vhosts/testvhost" {
 			t.Errorf("Expected /api/vhosts/testvhost, got %s", r.URL.Path)
 		}
+
+		var vhost Vhost
+		if err := json.NewDecoder(r.Body).Decode(&vhost); err != nil {
+			t.Fatal(err)
+		}
+		if vhost.Description != "nova" || len(vhost.Tags) != 1 || vhost.Tags[0] != "openstack" || vhost.DefaultQueueType != "quorum" {
+			t.Errorf("Unexpected vhost: %+v", vhost)
+		}
+
 		w.WriteHeader(http.StatusCreated)
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "admin", "admin", false, nil)
-	err := client.CreateOrUpdateVhost("testvhost")
+	client := newTestClient(t, server.URL)
+	err := client.CreateOrUpdateVhost(context.Background(), "testvhost", "nova", []string{"openstack"}, "quorum")
 	if err != nil {
 		t.Errorf("CreateOrUpdateVhost failed: %v", err)
 	}
 }
 
+func TestGetVhost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		switch r.URL.Path {
+		case "/api/vhosts/testvhost":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"name":"testvhost","description":"nova","tags":["openstack"],"default_queue_type":"quorum"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	vhost, err := client.GetVhost(context.Background(), "testvhost")
+	if err != nil {
+		t.Fatalf("GetVhost failed: %v", err)
+	}
+	if vhost == nil || vhost.Description != "nova" || vhost.DefaultQueueType != "quorum" {
+		t.Errorf("Unexpected vhost: %+v", vhost)
+	}
+
+	vhost, err = client.GetVhost(context.Background(), "missing")
+	if err != nil {
+		t.Fatalf("GetVhost failed: %v", err)
+	}
+	if vhost != nil {
+		t.Errorf("Expected nil vhost, got %+v", vhost)
+	}
+}
+
+func TestListVhosts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/vhosts" {
+			t.Errorf("Expected /api/vhosts, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[{"name":"/"},{"name":"nova","description":"nova"}]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	result, err := client.ListVhosts(context.Background())
+	if err != nil {
+		t.Fatalf("ListVhosts failed: %v", err)
+	}
+	if len(result) != 2 || result[1].Description != "nova" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
+func TestGetVhostLimits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/vhost-limits/testvhost" {
+			t.Errorf("Expected /api/vhost-limits/testvhost, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[{"vhost":"testvhost","value":{"max-connections":10,"max-queues":20}}]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	limits, err := client.GetVhostLimits(context.Background(), "testvhost")
+	if err != nil {
+		t.Fatalf("GetVhostLimits failed: %v", err)
+	}
+	if limits[VhostLimitMaxConnections] != 10 || limits[VhostLimitMaxQueues] != 20 {
+		t.Errorf("Unexpected limits: %+v", limits)
+	}
+}
+
+func TestSetVhostLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected PUT request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/vhost-limits/testvhost/max-queues" {
+			t.Errorf("Expected /api/vhost-limits/testvhost/max-queues, got %s", r.URL.Path)
+		}
+
+		var limit map[string]int64
+		if err := json.NewDecoder(r.Body).Decode(&limit); err != nil {
+			t.Fatal(err)
+		}
+		if limit["value"] != 500 {
+			t.Errorf("Unexpected limit: %+v", limit)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	err := client.SetVhostLimit(context.Background(), "testvhost", VhostLimitMaxQueues, 500)
+	if err != nil {
+		t.Errorf("SetVhostLimit failed: %v", err)
+	}
+}
+
+func TestDeleteVhostLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected DELETE request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/vhost-limits/testvhost/max-connections" {
+			t.Errorf("Expected /api/vhost-limits/testvhost/max-connections, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	err := client.DeleteVhostLimit(context.Background(), "testvhost", VhostLimitMaxConnections)
+	if err != nil {
+		t.Errorf("DeleteVhostLimit failed: %v", err)
+	}
+}
+
 func TestDeleteVhost(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "DELETE" {
@@ -112,13 +317,54 @@ func TestDeleteVhost(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "admin", "admin", false, nil)
-	err := client.DeleteVhost("testvhost")
+	client := newTestClient(t, server.URL)
+	err := client.DeleteVhost(context.Background(), "testvhost")
 	if err != nil {
 		t.Errorf("DeleteVhost failed: %v", err)
 	}
 }
 
+func TestListVhostConnections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.RawPath != "/api/vhosts/%2F/connections" {
+			t.Errorf("Expected /api/vhosts/%%2F/connections, got %s", r.URL.RawPath)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[{"name":"10.0.0.1:40000 -> 10.0.0.2:5672","vhost":"/","user":"nova"}]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	result, err := client.ListVhostConnections(context.Background(), "/")
+	if err != nil {
+		t.Fatalf("ListVhostConnections failed: %v", err)
+	}
+	if len(result) != 1 || result[0].Name != "10.0.0.1:40000 -> 10.0.0.2:5672" || result[0].User != "nova" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
+func TestCloseConnection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected DELETE request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/connections/10.0.0.1:40000 -> 10.0.0.2:5672" {
+			t.Errorf("Expected /api/connections/10.0.0.1:40000 -> 10.0.0.2:5672, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if err := client.CloseConnection(context.Background(), "10.0.0.1:40000 -> 10.0.0.2:5672"); err != nil {
+		t.Errorf("CloseConnection failed: %v", err)
+	}
+}
+
 func TestSetPermissions(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "PUT" {
@@ -140,8 +386,8 @@ func TestSetPermissions(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "admin", "admin", false, nil)
-	err := client.SetPermissions("/", "testuser", ".*", ".*", ".*")
+	client := newTestClient(t, server.URL)
+	err := client.SetPermissions(context.Background(), "/", "testuser", ".*", ".*", ".*")
 	if err != nil {
 		t.Errorf("SetPermissions failed: %v", err)
 	}
@@ -159,8 +405,8 @@ func TestDeletePermissions(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "admin", "admin", false, nil)
-	err := client.DeletePermissions("/", "testuser")
+	client := newTestClient(t, server.URL)
+	err := client.DeletePermissions(context.Background(), "/", "testuser")
 	if err != nil {
 		t.Errorf("DeletePermissions failed: %v", err)
 	}
@@ -187,14 +433,37 @@ func TestCreateOrUpdatePolicy(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "admin", "admin", false, nil)
+	client := newTestClient(t, server.URL)
 	definition := map[string]interface{}{"max-length": 10000}
-	err := client.CreateOrUpdatePolicy("/", "testpolicy", ".*", definition, 1, "all")
+	err := client.CreateOrUpdatePolicy(context.Background(), "/", "testpolicy", ".*", definition, 1, "all")
 	if err != nil {
 		t.Errorf("CreateOrUpdatePolicy failed: %v", err)
 	}
 }
 
+func TestListPolicies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/policies" {
+			t.Errorf("Expected /api/policies, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[{"vhost":"nova","name":"ha-all","pattern":".*","apply-to":"all","definition":{"ha-mode":"all"},"priority":0}]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	result, err := client.ListPolicies(context.Background())
+	if err != nil {
+		t.Fatalf("ListPolicies failed: %v", err)
+	}
+	if len(result) != 1 || result[0].Vhost != "nova" || result[0].Name != "ha-all" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
 func TestDeletePolicy(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != "DELETE" {
@@ -207,9 +476,774 @@ func TestDeletePolicy(t *testing.T) {
 	}))
 	defer server.Close()
 
-	client := NewClient(server.URL, "admin", "admin", false, nil)
-	err := client.DeletePolicy("/", "testpolicy")
+	client := newTestClient(t, server.URL)
+	err := client.DeletePolicy(context.Background(), "/", "testpolicy")
 	if err != nil {
 		t.Errorf("DeletePolicy failed: %v", err)
 	}
 }
+
+func TestSetTopicPermissions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected PUT request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/topic-permissions///testuser" {
+			t.Errorf("Expected /api/topic-permissions///testuser, got %s", r.URL.Path)
+		}
+
+		var perm TopicPermission
+		if err := json.NewDecoder(r.Body).Decode(&perm); err != nil {
+			t.Fatal(err)
+		}
+		if perm.Exchange != "amq.topic" || perm.Write != "^notifications\\." || perm.Read != ".*" {
+			t.Errorf("Unexpected topic permissions: %+v", perm)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	err := client.SetTopicPermissions(context.Background(), "/", "testuser", "amq.topic", "^notifications\\.", ".*")
+	if err != nil {
+		t.Errorf("SetTopicPermissions failed: %v", err)
+	}
+}
+
+func TestListTopicPermissions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		switch r.URL.Path {
+		case "/api/topic-permissions///testuser":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`[{"user":"testuser","vhost":"/","exchange":"amq.topic","write":".*","read":".*"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	perms, err := client.ListTopicPermissions(context.Background(), "/", "testuser")
+	if err != nil {
+		t.Fatalf("ListTopicPermissions failed: %v", err)
+	}
+	if len(perms) != 1 || perms[0].Exchange != "amq.topic" {
+		t.Errorf("Unexpected topic permissions: %+v", perms)
+	}
+
+	perms, err = client.ListTopicPermissions(context.Background(), "/", "otheruser")
+	if err != nil {
+		t.Fatalf("ListTopicPermissions failed: %v", err)
+	}
+	if len(perms) != 0 {
+		t.Errorf("Expected no topic permissions, got %+v", perms)
+	}
+}
+
+func TestDeleteTopicPermissions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected DELETE request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/topic-permissions///testuser" {
+			t.Errorf("Expected /api/topic-permissions///testuser, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	err := client.DeleteTopicPermissions(context.Background(), "/", "testuser")
+	if err != nil {
+		t.Errorf("DeleteTopicPermissions failed: %v", err)
+	}
+}
+
+func TestGetUserLimits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		switch r.URL.Path {
+		case "/api/user-limits/testuser":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`[{"user":"testuser","value":{"max-connections":100,"max-channels":200}}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	limits, err := client.GetUserLimits(context.Background(), "testuser")
+	if err != nil {
+		t.Fatalf("GetUserLimits failed: %v", err)
+	}
+	if limits[UserLimitMaxConnections] != 100 || limits[UserLimitMaxChannels] != 200 {
+		t.Errorf("Unexpected limits: %+v", limits)
+	}
+
+	limits, err = client.GetUserLimits(context.Background(), "otheruser")
+	if err != nil {
+		t.Fatalf("GetUserLimits failed: %v", err)
+	}
+	if len(limits) != 0 {
+		t.Errorf("Expected no limits, got %+v", limits)
+	}
+}
+
+func TestSetUserLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected PUT request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/user-limits/testuser/max-connections" {
+			t.Errorf("Expected /api/user-limits/testuser/max-connections, got %s", r.URL.Path)
+		}
+
+		var limit map[string]int64
+		if err := json.NewDecoder(r.Body).Decode(&limit); err != nil {
+			t.Fatal(err)
+		}
+		if limit["value"] != 100 {
+			t.Errorf("Unexpected limit: %+v", limit)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	err := client.SetUserLimit(context.Background(), "testuser", UserLimitMaxConnections, 100)
+	if err != nil {
+		t.Errorf("SetUserLimit failed: %v", err)
+	}
+}
+
+func TestDeleteUserLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected DELETE request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/user-limits/testuser/max-channels" {
+			t.Errorf("Expected /api/user-limits/testuser/max-channels, got %s", r.URL.Path)
+		}
+		// limit was never set
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	err := client.DeleteUserLimit(context.Background(), "testuser", UserLimitMaxChannels)
+	if err != nil {
+		t.Errorf("DeleteUserLimit failed: %v", err)
+	}
+}
+
+func TestCreateOrUpdateQueue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected PUT request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/queues/testvhost/testqueue" {
+			t.Errorf("Expected /api/queues/testvhost/testqueue, got %s", r.URL.Path)
+		}
+
+		var queue map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&queue); err != nil {
+			t.Fatal(err)
+		}
+		arguments, _ := queue["arguments"].(map[string]interface{})
+		if queue["durable"] != true || queue["auto_delete"] != false || arguments["x-queue-type"] != "quorum" {
+			t.Errorf("Unexpected queue: %+v", queue)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	err := client.CreateOrUpdateQueue(context.Background(), "testvhost", "testqueue", true, false, map[string]interface{}{"x-queue-type": "quorum"})
+	if err != nil {
+		t.Errorf("CreateOrUpdateQueue failed: %v", err)
+	}
+}
+
+func TestGetQueue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		switch r.URL.Path {
+		case "/api/queues/testvhost/testqueue":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"name":"testqueue","vhost":"testvhost","type":"quorum","durable":true,"messages":42}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	queue, err := client.GetQueue(context.Background(), "testvhost", "testqueue")
+	if err != nil {
+		t.Fatalf("GetQueue failed: %v", err)
+	}
+	if queue == nil || queue.Type != "quorum" || queue.Messages != 42 {
+		t.Errorf("Unexpected queue: %+v", queue)
+	}
+
+	queue, err = client.GetQueue(context.Background(), "testvhost", "missing")
+	if err != nil {
+		t.Fatalf("GetQueue failed: %v", err)
+	}
+	if queue != nil {
+		t.Errorf("Expected nil queue, got %+v", queue)
+	}
+}
+
+func TestListQueues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.RawPath != "/api/queues/%2F" {
+			t.Errorf("Expected /api/queues/%%2F, got %s", r.URL.RawPath)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[{"name":"nova","vhost":"/","type":"classic","durable":true,"consumers":2,"messages":5}]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	result, err := client.ListQueues(context.Background(), "/")
+	if err != nil {
+		t.Fatalf("ListQueues failed: %v", err)
+	}
+	if len(result) != 1 || result[0].Type != "classic" || result[0].Consumers != 2 || result[0].Messages != 5 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
+func TestListAllQueues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/queues" {
+			t.Errorf("Expected /api/queues, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[{"name":"nova","vhost":"/","type":"quorum","members":["rabbit@a","rabbit@b"],"online":["rabbit@a"]}]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	result, err := client.ListAllQueues(context.Background())
+	if err != nil {
+		t.Fatalf("ListAllQueues failed: %v", err)
+	}
+	if len(result) != 1 || len(result[0].Members) != 2 || len(result[0].Online) != 1 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
+func TestShrinkQuorumQueues(t *testing.T) {
+	result := "ok"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected DELETE request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/queues/quorum/replicas/on/rabbit@rabbitmq-server-2/shrink" {
+			t.Errorf("Expected /api/queues/quorum/replicas/on/rabbit@rabbitmq-server-2/shrink, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(w, `[{"vhost":"/","name":"nova","size":2,"result":"%s"}]`, result)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if err := client.ShrinkQuorumQueues(context.Background(), "rabbit@rabbitmq-server-2"); err != nil {
+		t.Fatalf("ShrinkQuorumQueues failed: %v", err)
+	}
+
+	result = "error: last_member"
+	err := client.ShrinkQuorumQueues(context.Background(), "rabbit@rabbitmq-server-2")
+	if err == nil || !strings.Contains(err.Error(), "/nova: error: last_member") {
+		t.Errorf("Expected shrink failure of queue nova, got %v", err)
+	}
+}
+
+func TestDeleteQueue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected DELETE request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/queues/testvhost/testqueue" {
+			t.Errorf("Expected /api/queues/testvhost/testqueue, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("if-empty") != "true" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		// queue still holds messages
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if err := client.DeleteQueue(context.Background(), "testvhost", "testqueue", false); err != nil {
+		t.Errorf("DeleteQueue failed: %v", err)
+	}
+	if err := client.DeleteQueue(context.Background(), "testvhost", "testqueue", true); err == nil {
+		t.Error("Expected DeleteQueue with ifEmpty to fail for a non-empty queue")
+	}
+}
+
+func TestCreateOrUpdateExchange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected PUT request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/exchanges///nova" {
+			t.Errorf("Expected /api/exchanges///nova, got %s", r.URL.Path)
+		}
+
+		var exchange Exchange
+		if err := json.NewDecoder(r.Body).Decode(&exchange); err != nil {
+			t.Fatal(err)
+		}
+		if exchange.Type != "topic" || !exchange.Durable || exchange.AutoDelete || exchange.Internal {
+			t.Errorf("Unexpected exchange: %+v", exchange)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	err := client.CreateOrUpdateExchange(context.Background(), "/", "nova", "topic", true, false, false, nil)
+	if err != nil {
+		t.Errorf("CreateOrUpdateExchange failed: %v", err)
+	}
+}
+
+func TestDeleteExchange(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected DELETE request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/exchanges///nova" {
+			t.Errorf("Expected /api/exchanges///nova, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	err := client.DeleteExchange(context.Background(), "/", "nova")
+	if err != nil {
+		t.Errorf("DeleteExchange failed: %v", err)
+	}
+}
+
+func TestCreateBinding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/bindings/testvhost/e/nova/q/notifications.info" {
+			t.Errorf("Expected /api/bindings/testvhost/e/nova/q/notifications.info, got %s", r.URL.Path)
+		}
+
+		var binding map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&binding); err != nil {
+			t.Fatal(err)
+		}
+		if binding["routing_key"] != "notifications.info" {
+			t.Errorf("Unexpected binding: %+v", binding)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	err := client.CreateBinding(context.Background(), "testvhost", "nova", BindingDestinationQueue, "notifications.info", "notifications.info", nil)
+	if err != nil {
+		t.Errorf("CreateBinding failed: %v", err)
+	}
+
+	err = client.CreateBinding(context.Background(), "testvhost", "nova", "invalid", "notifications.info", "notifications.info", nil)
+	if err == nil {
+		t.Error("Expected CreateBinding to fail for an invalid destination type")
+	}
+}
+
+func TestListBindings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/bindings/testvhost/e/nova/e/nova-fanout" {
+			t.Errorf("Expected /api/bindings/testvhost/e/nova/e/nova-fanout, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[{"source":"nova","vhost":"testvhost","destination":"nova-fanout","destination_type":"exchange","routing_key":"compute","arguments":{},"properties_key":"compute"}]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	bindings, err := client.ListBindings(context.Background(), "testvhost", "nova", BindingDestinationExchange, "nova-fanout")
+	if err != nil {
+		t.Fatalf("ListBindings failed: %v", err)
+	}
+	if len(bindings) != 1 || bindings[0].RoutingKey != "compute" || bindings[0].PropertiesKey != "compute" {
+		t.Errorf("Unexpected bindings: %+v", bindings)
+	}
+}
+
+func TestDeleteBinding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected DELETE request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/bindings/testvhost/e/nova/q/compute/compute" {
+			t.Errorf("Expected /api/bindings/testvhost/e/nova/q/compute/compute, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	err := client.DeleteBinding(context.Background(), "testvhost", "nova", BindingDestinationQueue, "compute", "compute")
+	if err != nil {
+		t.Errorf("DeleteBinding failed: %v", err)
+	}
+}
+
+func TestCreateOrUpdateShovel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected PUT request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/parameters/shovel/cell1/testshovel" {
+			t.Errorf("Expected /api/parameters/shovel/cell1/testshovel, got %s", r.URL.Path)
+		}
+
+		var parameter struct {
+			Value Shovel `json:"value"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&parameter); err != nil {
+			t.Fatal(err)
+		}
+		shovel := parameter.Value
+		if shovel.SrcProtocol != "amqp091" || shovel.DestProtocol != "amqp091" {
+			t.Errorf("Expected amqp091 protocols, got %s and %s", shovel.SrcProtocol, shovel.DestProtocol)
+		}
+		if len(shovel.SrcURI) != 1 || shovel.SrcURI[0] != "amqp://cell1" || shovel.SrcQueue != "notifications" {
+			t.Errorf("Unexpected shovel source: %+v", shovel)
+		}
+		if len(shovel.DestURI) != 1 || shovel.DestURI[0] != "amqp://central" || shovel.DestExchange != "notifications" {
+			t.Errorf("Unexpected shovel destination: %+v", shovel)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	err := client.CreateOrUpdateShovel(context.Background(), "cell1", "testshovel", Shovel{
+		SrcURI:       []string{"amqp://cell1"},
+		SrcQueue:     "notifications",
+		DestURI:      []string{"amqp://central"},
+		DestExchange: "notifications",
+	})
+	if err != nil {
+		t.Errorf("CreateOrUpdateShovel failed: %v", err)
+	}
+}
+
+func TestDeleteShovel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected DELETE request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/parameters/shovel///testshovel" {
+			t.Errorf("Expected /api/parameters/shovel///testshovel, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if err := client.DeleteShovel(context.Background(), "/", "testshovel"); err != nil {
+		t.Errorf("DeleteShovel failed: %v", err)
+	}
+}
+
+func TestCreateOrUpdateFederationUpstream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected PUT request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/parameters/federation-upstream///cell1" {
+			t.Errorf("Expected /api/parameters/federation-upstream///cell1, got %s", r.URL.Path)
+		}
+
+		var parameter struct {
+			Value FederationUpstream `json:"value"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&parameter); err != nil {
+			t.Fatal(err)
+		}
+		upstream := parameter.Value
+		if len(upstream.URI) != 2 || upstream.Exchange != "nova" || upstream.MaxHops == nil || *upstream.MaxHops != 1 {
+			t.Errorf("Unexpected federation upstream: %+v", upstream)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	maxHops := int32(1)
+	client := newTestClient(t, server.URL)
+	err := client.CreateOrUpdateFederationUpstream(context.Background(), "/", "cell1", FederationUpstream{
+		URI:      []string{"amqp://cell1-0", "amqp://cell1-1"},
+		Exchange: "nova",
+		MaxHops:  &maxHops,
+	})
+	if err != nil {
+		t.Errorf("CreateOrUpdateFederationUpstream failed: %v", err)
+	}
+}
+
+func TestCreateOrUpdateFederationUpstreamError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"bad_request","reason":"Validation failed\n\ncomponent federation-upstream not found\n"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	err := client.CreateOrUpdateFederationUpstream(context.Background(), "/", "cell1", FederationUpstream{URI: []string{"amqp://cell1"}})
+	if err == nil {
+		t.Fatal("Expected error when the federation plugin is not enabled")
+	}
+	if !strings.Contains(err.Error(), "component federation-upstream not found") {
+		t.Errorf("Expected error to contain the API reason, got %v", err)
+	}
+}
+
+func TestDeleteFederationUpstream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" {
+			t.Errorf("Expected DELETE request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/parameters/federation-upstream///cell1" {
+			t.Errorf("Expected /api/parameters/federation-upstream///cell1, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if err := client.DeleteFederationUpstream(context.Background(), "/", "cell1"); err != nil {
+		t.Errorf("DeleteFederationUpstream failed: %v", err)
+	}
+}
+
+func TestRetryOnServerError(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"name":"testvhost"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	vhost, err := client.GetVhost(context.Background(), "testvhost")
+	if err != nil {
+		t.Fatalf("GetVhost failed: %v", err)
+	}
+	if vhost == nil || vhost.Name != "testvhost" {
+		t.Errorf("Unexpected vhost: %+v", vhost)
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts.Load())
+	}
+}
+
+func TestRetryOnConnectionReset(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if attempts.Add(1) == 1 {
+			// Close the connection without a response
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if err := client.DeleteUser(context.Background(), "testuser"); err != nil {
+		t.Fatalf("DeleteUser failed: %v", err)
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts.Load())
+	}
+}
+
+func TestNoRetryForNonIdempotentRequests(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	err := client.CreateBinding(context.Background(), "/", "source", BindingDestinationQueue, "dest", "key", nil)
+	if err == nil {
+		t.Fatal("Expected error for a server error")
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts.Load())
+	}
+}
+
+func TestTypedErrors(t *testing.T) {
+	status := http.StatusUnauthorized
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"error":"failed"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	_, err := client.ListUsers(context.Background())
+	if !IsUnauthorized(err) || IsNotFound(err) || IsConflict(err) {
+		t.Errorf("Expected unauthorized error, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized || apiErr.Body != `{"error":"failed"}` {
+		t.Errorf("Expected APIError with status and body, got %v", err)
+	}
+
+	status = http.StatusConflict
+	err = client.CreateOrUpdateQueue(context.Background(), "/", "testqueue", true, false, nil)
+	if !IsConflict(err) {
+		t.Errorf("Expected conflict error, got %v", err)
+	}
+
+	status = http.StatusNotFound
+	err = client.SetPermissions(context.Background(), "missing", "testuser", ".*", ".*", ".*")
+	if !IsNotFound(err) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestRequestCancellation(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := newTestClient(t, server.URL)
+	_, err := client.GetUser(ctx, "testuser")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected canceled error, got %v", err)
+	}
+	if attempts.Load() != 0 {
+		t.Errorf("Expected no attempts, got %d", attempts.Load())
+	}
+}
+
+func TestGetClient(t *testing.T) {
+	getClient := func(baseURL, password string) *Client {
+		client, err := GetClient(baseURL, "user", password, TLSConfig{})
+		if err != nil {
+			t.Fatalf("GetClient failed: %v", err)
+		}
+		return client
+	}
+
+	client := getClient("http://rabbitmq-cache:15672", "pass")
+	if getClient("http://rabbitmq-cache:15672", "pass") != client {
+		t.Error("Expected the client to be reused")
+	}
+	if getClient("http://rabbitmq-cache:15672", "newpass") == client {
+		t.Error("Expected a new client after a password change")
+	}
+	if getClient("http://rabbitmq-other:15672", "newpass") == client {
+		t.Error("Expected a separate client for another cluster")
+	}
+}
+
+func TestNewClientTLS(t *testing.T) {
+	if _, err := NewClient("https://localhost:15671", "user", "pass", TLSConfig{Enabled: true}); !errors.Is(err, ErrNoCACert) {
+		t.Errorf("Expected ErrNoCACert without a CA, got %v", err)
+	}
+	if _, err := NewClient("https://localhost:15671", "user", "pass", TLSConfig{Enabled: true, CACert: []byte("invalid")}); err == nil {
+		t.Error("Expected error for an invalid CA certificate")
+	}
+	if _, err := NewClient("https://localhost:15671", "user", "pass", TLSConfig{Enabled: true, UseSystemCAs: true}); err != nil {
+		t.Errorf("Expected the system trust store to be used, got %v", err)
+	}
+	if _, err := NewClient("https://localhost:15671", "user", "pass", TLSConfig{Enabled: true, InsecureSkipVerify: true}); err != nil {
+		t.Errorf("Expected insecure client to be created, got %v", err)
+	}
+}
+
+func TestTLSVerification(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	for _, tc := range []struct {
+		name      string
+		tlsConfig TLSConfig
+		wantErr   bool
+	}{
+		{"trusted CA", TLSConfig{Enabled: true, CACert: caCert}, false},
+		{"server name mismatch", TLSConfig{Enabled: true, CACert: caCert, ServerName: "rabbitmq.openstack.svc"}, true},
+		{"server name in certificate", TLSConfig{Enabled: true, CACert: caCert, ServerName: "example.com"}, false},
+		{"untrusted CA", TLSConfig{Enabled: true, UseSystemCAs: true}, true},
+		{"insecure", TLSConfig{Enabled: true, InsecureSkipVerify: true}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := NewClient(server.URL, "admin", "admin", tc.tlsConfig)
+			if err != nil {
+				t.Fatalf("NewClient failed: %v", err)
+			}
+			_, err = client.ListUsers(context.Background())
+			if tc.wantErr && err == nil {
+				t.Error("Expected certificate verification to fail")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("ListUsers failed: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//nolint:revive
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// DefinitionsTimeout is the timeout for exporting and importing definitions,
+// which may take longer on clusters with many queues
+const DefinitionsTimeout = 120 * time.Second
+
+// definitionsVhostScoped are the sections of the definitions whose items
+// belong to the vhost in their vhost field
+var definitionsVhostScoped = []string{
+	"permissions",
+	"topic_permissions",
+	"parameters",
+	"policies",
+	"queues",
+	"exchanges",
+	"bindings",
+}
+
+// GetDefinitions exports the definitions of the cluster, i.e. users, vhosts,
+// permissions, policies, parameters, queues, exchanges and bindings, as JSON
+func (c *Client) GetDefinitions(ctx context.Context) ([]byte, error) {
+	resp, err := c.doRequestWithTimeout(ctx, "GET", "/api/definitions", nil, DefinitionsTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get definitions: %w", newAPIError(resp))
+	}
+
+	definitions, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read definitions: %w", err)
+	}
+	if !json.Valid(definitions) {
+		return nil, fmt.Errorf("failed to read definitions: invalid JSON")
+	}
+
+	return definitions, nil
+}
+
+// ImportDefinitions imports definitions previously exported with GetDefinitions.
+// Existing objects are updated, objects which are not part of the definitions
+// are left untouched.
+func (c *Client) ImportDefinitions(ctx context.Context, definitions []byte) error {
+	if !json.Valid(definitions) {
+		return fmt.Errorf("failed to import definitions: invalid JSON")
+	}
+
+	resp, err := c.doRequestWithTimeout(ctx, "POST", "/api/definitions", json.RawMessage(definitions), DefinitionsTimeout)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to import definitions: %w", newAPIError(resp))
+	}
+
+	return nil
+}
+
+// FilterDefinitions returns the definitions reduced to the given vhosts. Users
+// are kept if they have permissions on one of the vhosts, global parameters are
+// dropped. Sections unknown to the filter are kept as they are.
+func FilterDefinitions(definitions []byte, vhosts []string) ([]byte, error) {
+	defs := map[string]json.RawMessage{}
+	if err := json.Unmarshal(definitions, &defs); err != nil {
+		return nil, fmt.Errorf("failed to decode definitions: %w", err)
+	}
+
+	var err error
+	users := []string{}
+	for _, section := range definitionsVhostScoped {
+		defs[section], err = filterDefinitionsSection(defs[section], section, func(item map[string]interface{}) bool {
+			vhost, _ := item["vhost"].(string)
+			if !slices.Contains(vhosts, vhost) {
+				return false
+			}
+			if user, ok := item["user"].(string); ok {
+				users = append(users, user)
+			}
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	defs["vhosts"], err = filterDefinitionsSection(defs["vhosts"], "vhosts", func(item map[string]interface{}) bool {
+		name, _ := item["name"].(string)
+		return slices.Contains(vhosts, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defs["users"], err = filterDefinitionsSection(defs["users"], "users", func(item map[string]interface{}) bool {
+		name, _ := item["name"].(string)
+		return slices.Contains(users, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	delete(defs, "global_parameters")
+
+	for section, items := range defs {
+		if items == nil {
+			delete(defs, section)
+		}
+	}
+
+	return json.Marshal(defs)
+}
+
+// RemoveUserDefinitions returns the definitions without the user and its permissions and
+// topic permissions, e.g. to keep the credentials of the default user of a cluster when
+// importing definitions exported from another cluster
+func RemoveUserDefinitions(definitions []byte, user string) ([]byte, error) {
+	defs := map[string]json.RawMessage{}
+	if err := json.Unmarshal(definitions, &defs); err != nil {
+		return nil, fmt.Errorf("failed to decode definitions: %w", err)
+	}
+
+	var err error
+	for _, section := range []string{"permissions", "topic_permissions"} {
+		defs[section], err = filterDefinitionsSection(defs[section], section, func(item map[string]interface{}) bool {
+			return item["user"] != user
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	defs["users"], err = filterDefinitionsSection(defs["users"], "users", func(item map[string]interface{}) bool {
+		return item["name"] != user
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for section, items := range defs {
+		if items == nil {
+			delete(defs, section)
+		}
+	}
+
+	return json.Marshal(defs)
+}
+
+// filterDefinitionsSection returns the items of a definitions section for which keep returns true
+func filterDefinitionsSection(section json.RawMessage, name string, keep func(map[string]interface{}) bool) (json.RawMessage, error) {
+	if section == nil {
+		return nil, nil
+	}
+
+	items := []map[string]interface{}{}
+	if err := json.Unmarshal(section, &items); err != nil {
+		return nil, fmt.Errorf("failed to decode definitions section %s: %w", name, err)
+	}
+
+	kept := []map[string]interface{}{}
+	for _, item := range items {
+		if keep(item) {
+			kept = append(kept, item)
+		}
+	}
+
+	return json.Marshal(kept)
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//nolint:revive
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testDefinitions = `{
+	"rabbit_version": "4.1.0",
+	"users": [{"name": "nova", "tags": []}, {"name": "neutron", "tags": []}, {"name": "admin", "tags": ["administrator"]}],
+	"vhosts": [{"name": "/"}, {"name": "nova"}, {"name": "neutron"}],
+	"permissions": [
+		{"user": "nova", "vhost": "nova", "configure": ".*", "write": ".*", "read": ".*"},
+		{"user": "neutron", "vhost": "neutron", "configure": ".*", "write": ".*", "read": ".*"}
+	],
+	"global_parameters": [{"name": "cluster_name", "value": "rabbitmq"}],
+	"policies": [{"vhost": "nova", "name": "ha", "pattern": ".*", "definition": {}}],
+	"queues": [{"vhost": "nova", "name": "scheduler"}, {"vhost": "neutron", "name": "q-agent"}],
+	"exchanges": [],
+	"bindings": [{"vhost": "neutron", "source": "neutron", "destination": "q-agent"}]
+}`
+
+func TestGetDefinitions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/definitions" {
+			t.Errorf("Expected /api/definitions, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(testDefinitions))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	result, err := client.GetDefinitions(context.Background())
+	if err != nil {
+		t.Fatalf("GetDefinitions failed: %v", err)
+	}
+	if string(result) != testDefinitions {
+		t.Errorf("Unexpected definitions: %s", result)
+	}
+}
+
+func TestImportDefinitions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/definitions" {
+			t.Errorf("Expected /api/definitions, got %s", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if !json.Valid(body) {
+			t.Errorf("Expected JSON body, got %s", body)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if err := client.ImportDefinitions(context.Background(), []byte(testDefinitions)); err != nil {
+		t.Fatalf("ImportDefinitions failed: %v", err)
+	}
+	if err := client.ImportDefinitions(context.Background(), []byte("{")); err == nil {
+		t.Error("Expected an error for invalid definitions")
+	}
+}
+
+func TestFilterDefinitions(t *testing.T) {
+	result, err := FilterDefinitions([]byte(testDefinitions), []string{"nova"})
+	if err != nil {
+		t.Fatalf("FilterDefinitions failed: %v", err)
+	}
+
+	defs := map[string]interface{}{}
+	if err := json.Unmarshal(result, &defs); err != nil {
+		t.Fatalf("Failed to decode filtered definitions: %v", err)
+	}
+	for section, expected := range map[string]int{
+		"users":       1,
+		"vhosts":      1,
+		"permissions": 1,
+		"policies":    1,
+		"queues":      1,
+		"exchanges":   0,
+		"bindings":    0,
+	} {
+		items, _ := defs[section].([]interface{})
+		if len(items) != expected {
+			t.Errorf("Expected %d items in %s, got %v", expected, section, defs[section])
+		}
+	}
+	if user := defs["users"].([]interface{})[0].(map[string]interface{})["name"]; user != "nova" {
+		t.Errorf("Expected user nova, got %v", user)
+	}
+	if _, ok := defs["global_parameters"]; ok {
+		t.Error("Expected global parameters to be dropped")
+	}
+	if defs["rabbit_version"] != "4.1.0" {
+		t.Errorf("Expected rabbit_version to be kept, got %v", defs["rabbit_version"])
+	}
+
+	if _, err := FilterDefinitions([]byte(`{"queues": {}}`), []string{"nova"}); err == nil {
+		t.Error("Expected an error for a malformed section")
+	}
+}
+
+func TestRemoveUserDefinitions(t *testing.T) {
+	result, err := RemoveUserDefinitions([]byte(testDefinitions), "nova")
+	if err != nil {
+		t.Fatalf("RemoveUserDefinitions failed: %v", err)
+	}
+
+	defs := map[string]interface{}{}
+	if err := json.Unmarshal(result, &defs); err != nil {
+		t.Fatalf("Failed to decode definitions: %v", err)
+	}
+	for section, expected := range map[string]int{
+		"users":       2,
+		"vhosts":      3,
+		"permissions": 1,
+		"queues":      2,
+	} {
+		items, _ := defs[section].([]interface{})
+		if len(items) != expected {
+			t.Errorf("Expected %d items in %s, got %v", expected, section, defs[section])
+		}
+	}
+	for _, user := range defs["users"].([]interface{}) {
+		if name := user.(map[string]interface{})["name"]; name == "nova" {
+			t.Error("Expected user nova to be removed")
+		}
+	}
+	if user := defs["permissions"].([]interface{})[0].(map[string]interface{})["user"]; user != "neutron" {
+		t.Errorf("Expected the permissions of neutron to be kept, got %v", user)
+	}
+	if _, ok := defs["topic_permissions"]; ok {
+		t.Error("Expected no topic_permissions section to be added")
+	}
+
+	if _, err := RemoveUserDefinitions([]byte(`{"users": {}}`), "nova"); err == nil {
+		t.Error("Expected an error for a malformed section")
+	}
+}
@@ -0,0 +1,360 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//nolint:revive
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" //nolint:gosec // only used to verify hashes of users created with the md5 algorithm
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"reflect"
+	"slices"
+	"sort"
+)
+
+// Password hashing algorithms of RabbitMQ users
+const (
+	// HashingAlgorithmSHA256 is the default password hashing algorithm of RabbitMQ
+	HashingAlgorithmSHA256 = "rabbit_password_hashing_sha256"
+	// HashingAlgorithmSHA512 hashes passwords with SHA-512
+	HashingAlgorithmSHA512 = "rabbit_password_hashing_sha512"
+	// HashingAlgorithmMD5 is the legacy password hashing algorithm
+	HashingAlgorithmMD5 = "rabbit_password_hashing_md5"
+)
+
+// passwordSaltLength is the length of the salt RabbitMQ prepends to the password hash
+const passwordSaltLength = 4
+
+// PasswordMatches returns true if the password matches the salted password hash of the user.
+// RabbitMQ stores base64(salt + hash(salt + password)). Users with an unknown hashing
+// algorithm are assumed to match, since their password can't be verified.
+func (u *User) PasswordMatches(password string) bool {
+	var h hash.Hash
+	switch u.HashingAlgorithm {
+	case HashingAlgorithmSHA256, "":
+		h = sha256.New()
+	case HashingAlgorithmSHA512:
+		h = sha512.New()
+	case HashingAlgorithmMD5:
+		h = md5.New() //nolint:gosec
+	default:
+		return true
+	}
+
+	// Users without a password can only authenticate with other mechanisms
+	if u.PasswordHash == "" {
+		return password == ""
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(u.PasswordHash)
+	if err != nil || len(decoded) <= passwordSaltLength {
+		return false
+	}
+
+	salt := decoded[:passwordSaltLength]
+	h.Write(salt)
+	h.Write([]byte(password))
+	return bytes.Equal(h.Sum(nil), decoded[passwordSaltLength:])
+}
+
+// DiffUser returns the differences between the user in RabbitMQ and the desired password and tags
+func DiffUser(current *User, password string, tags []string) []string {
+	if current == nil {
+		return []string{"user does not exist"}
+	}
+
+	diff := []string{}
+	if !current.PasswordMatches(password) {
+		diff = append(diff, "password")
+	}
+	if !equalUnordered(current.Tags, tags) {
+		diff = append(diff, "tags")
+	}
+	return diff
+}
+
+// DiffUserHash returns the differences between the user in RabbitMQ and the desired password hash and tags
+func DiffUserHash(current *User, passwordHash, hashingAlgorithm string, tags []string) []string {
+	if current == nil {
+		return []string{"user does not exist"}
+	}
+
+	diff := []string{}
+	currentAlgorithm := current.HashingAlgorithm
+	if currentAlgorithm == "" {
+		currentAlgorithm = HashingAlgorithmSHA256
+	}
+	if hashingAlgorithm == "" {
+		hashingAlgorithm = HashingAlgorithmSHA256
+	}
+	if current.PasswordHash != passwordHash || currentAlgorithm != hashingAlgorithm {
+		diff = append(diff, "password")
+	}
+	if !equalUnordered(current.Tags, tags) {
+		diff = append(diff, "tags")
+	}
+	return diff
+}
+
+// DiffPermissions returns the differences between the permissions in RabbitMQ and the desired ones
+func DiffPermissions(current *Permission, configure, write, read string) []string {
+	if current == nil {
+		return []string{"permissions do not exist"}
+	}
+
+	diff := []string{}
+	if current.Configure != configure {
+		diff = append(diff, "configure permission")
+	}
+	if current.Write != write {
+		diff = append(diff, "write permission")
+	}
+	if current.Read != read {
+		diff = append(diff, "read permission")
+	}
+	return diff
+}
+
+// DiffVhost returns the differences between the vhost in RabbitMQ and the desired metadata.
+// The default queue type is only compared when set, since RabbitMQ can't unset it again.
+func DiffVhost(current *Vhost, description string, tags []string, defaultQueueType string) []string {
+	if current == nil {
+		return []string{"vhost does not exist"}
+	}
+
+	diff := []string{}
+	if current.Description != description {
+		diff = append(diff, "description")
+	}
+	if !equalUnordered(current.Tags, tags) {
+		diff = append(diff, "tags")
+	}
+	if defaultQueueType != "" && current.DefaultQueueType != defaultQueueType {
+		diff = append(diff, "default queue type")
+	}
+	return diff
+}
+
+// DiffVhostLimits returns the differences between the vhost limits in RabbitMQ and the desired ones.
+// Limits which are not desired must not be set.
+func DiffVhostLimits(current map[string]int64, limits map[string]int64) []string {
+	return diffLimits(current, limits)
+}
+
+// DiffUserLimits returns the differences between the user limits in RabbitMQ and the desired ones.
+// Limits which are not desired must not be set.
+func DiffUserLimits(current map[string]int64, limits map[string]int64) []string {
+	return diffLimits(current, limits)
+}
+
+// DiffTopicPermissions returns the differences between the topic permissions in RabbitMQ and the
+// desired ones. Topic permissions on exchanges which are not desired must not be set.
+func DiffTopicPermissions(current []TopicPermission, desired []TopicPermission) []string {
+	currentByExchange := map[string]TopicPermission{}
+	for _, perm := range current {
+		currentByExchange[perm.Exchange] = perm
+	}
+	desiredByExchange := map[string]TopicPermission{}
+	for _, perm := range desired {
+		desiredByExchange[perm.Exchange] = perm
+	}
+
+	exchanges := []string{}
+	for exchange := range currentByExchange {
+		exchanges = append(exchanges, exchange)
+	}
+	for exchange := range desiredByExchange {
+		if _, ok := currentByExchange[exchange]; !ok {
+			exchanges = append(exchanges, exchange)
+		}
+	}
+	sort.Strings(exchanges)
+
+	diff := []string{}
+	for _, exchange := range exchanges {
+		currentPerm, isSet := currentByExchange[exchange]
+		desiredPerm, isDesired := desiredByExchange[exchange]
+		if isSet != isDesired || currentPerm.Write != desiredPerm.Write || currentPerm.Read != desiredPerm.Read {
+			diff = append(diff, "topic permissions "+exchange)
+		}
+	}
+	return diff
+}
+
+// diffLimits returns the names of the limits which differ between RabbitMQ and the desired ones
+func diffLimits(current map[string]int64, limits map[string]int64) []string {
+	names := []string{}
+	for name := range current {
+		names = append(names, name)
+	}
+	for name := range limits {
+		if _, ok := current[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	diff := []string{}
+	for _, name := range names {
+		currentValue, isSet := current[name]
+		value, isDesired := limits[name]
+		if isSet != isDesired || currentValue != value {
+			diff = append(diff, "limit "+name)
+		}
+	}
+	return diff
+}
+
+// DiffPolicy returns the differences between the policy in RabbitMQ and the desired one
+func DiffPolicy(current *Policy, pattern string, definition map[string]interface{}, priority int, applyTo string) []string {
+	if current == nil {
+		return []string{"policy does not exist"}
+	}
+	if applyTo == "" {
+		applyTo = "all"
+	}
+
+	diff := []string{}
+	if current.Pattern != pattern {
+		diff = append(diff, "pattern")
+	}
+	if !equalJSON(current.Definition, definition) {
+		diff = append(diff, "definition")
+	}
+	if current.Priority != priority {
+		diff = append(diff, "priority")
+	}
+	if current.ApplyTo != applyTo {
+		diff = append(diff, "apply-to")
+	}
+	return diff
+}
+
+// CompareUser returns the differences between the user in RabbitMQ and the desired password and tags
+func (c *Client) CompareUser(ctx context.Context, name, password string, tags []string) ([]string, error) {
+	current, err := c.GetUser(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return DiffUser(current, password, tags), nil
+}
+
+// CompareUserHash returns the differences between the user in RabbitMQ and the desired password hash and tags
+func (c *Client) CompareUserHash(ctx context.Context, name, passwordHash, hashingAlgorithm string, tags []string) ([]string, error) {
+	current, err := c.GetUser(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return DiffUserHash(current, passwordHash, hashingAlgorithm, tags), nil
+}
+
+// ComparePermissions returns the differences between the permissions of a user on a vhost
+// in RabbitMQ and the desired ones
+func (c *Client) ComparePermissions(ctx context.Context, vhost, user, configure, write, read string) ([]string, error) {
+	current, err := c.GetPermissions(ctx, vhost, user)
+	if err != nil {
+		return nil, err
+	}
+	return DiffPermissions(current, configure, write, read), nil
+}
+
+// CompareTopicPermissions returns the differences between the topic permissions of a user
+// on a vhost in RabbitMQ and the desired ones
+func (c *Client) CompareTopicPermissions(ctx context.Context, vhost, user string, desired []TopicPermission) ([]string, error) {
+	current, err := c.ListTopicPermissions(ctx, vhost, user)
+	if err != nil {
+		return nil, err
+	}
+	return DiffTopicPermissions(current, desired), nil
+}
+
+// CompareUserLimits returns the differences between the user limits in RabbitMQ and the desired ones
+func (c *Client) CompareUserLimits(ctx context.Context, user string, limits map[string]int64) ([]string, error) {
+	current, err := c.GetUserLimits(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	return DiffUserLimits(current, limits), nil
+}
+
+// CompareVhost returns the differences between the vhost in RabbitMQ and the desired metadata
+func (c *Client) CompareVhost(ctx context.Context, name, description string, tags []string, defaultQueueType string) ([]string, error) {
+	current, err := c.GetVhost(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return DiffVhost(current, description, tags, defaultQueueType), nil
+}
+
+// CompareVhostLimits returns the differences between the vhost limits in RabbitMQ and the desired ones
+func (c *Client) CompareVhostLimits(ctx context.Context, name string, limits map[string]int64) ([]string, error) {
+	current, err := c.GetVhostLimits(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return DiffVhostLimits(current, limits), nil
+}
+
+// ComparePolicy returns the differences between the policy in RabbitMQ and the desired one
+func (c *Client) ComparePolicy(ctx context.Context, vhost, name, pattern string, definition map[string]interface{}, priority int, applyTo string) ([]string, error) {
+	current, err := c.GetPolicy(ctx, vhost, name)
+	if err != nil {
+		return nil, err
+	}
+	return DiffPolicy(current, pattern, definition, priority, applyTo), nil
+}
+
+// equalUnordered returns true if both lists contain the same strings, in any order
+func equalUnordered(a, b []string) bool {
+	a = slices.Clone(a)
+	b = slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+// equalJSON returns true if both values have the same JSON representation,
+// e.g. an int in the desired definition and a float64 decoded from RabbitMQ
+func equalJSON(a, b interface{}) bool {
+	normalize := func(v interface{}) (interface{}, error) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %v: %w", v, err)
+		}
+		var out interface{}
+		if err := json.Unmarshal(data, &out); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+
+	na, err := normalize(a)
+	if err != nil {
+		return false
+	}
+	nb, err := normalize(b)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(na, nb)
+}
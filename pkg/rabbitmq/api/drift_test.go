@@ -0,0 +1,259 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//nolint:revive
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// hashPassword returns the password hash the way RabbitMQ stores it
+func hashPassword(salt []byte, password string) string {
+	sum := sha256.Sum256(append(slices.Clone(salt), []byte(password)...))
+	return base64.StdEncoding.EncodeToString(append(slices.Clone(salt), sum[:]...))
+}
+
+func TestPasswordMatches(t *testing.T) {
+	user := &User{
+		Name:             "testuser",
+		PasswordHash:     hashPassword([]byte{1, 2, 3, 4}, "testpass"),
+		HashingAlgorithm: HashingAlgorithmSHA256,
+	}
+	if !user.PasswordMatches("testpass") {
+		t.Error("Expected password to match")
+	}
+	if user.PasswordMatches("otherpass") {
+		t.Error("Expected password not to match")
+	}
+
+	user.HashingAlgorithm = "rabbit_password_hashing_custom"
+	if !user.PasswordMatches("otherpass") {
+		t.Error("Expected passwords with an unknown hashing algorithm to match")
+	}
+
+	user = &User{Name: "testuser", HashingAlgorithm: HashingAlgorithmSHA256}
+	if user.PasswordMatches("testpass") {
+		t.Error("Expected password not to match a user without password")
+	}
+}
+
+func TestDiffUser(t *testing.T) {
+	current := &User{
+		Name:             "testuser",
+		PasswordHash:     hashPassword([]byte{1, 2, 3, 4}, "testpass"),
+		HashingAlgorithm: HashingAlgorithmSHA256,
+		Tags:             []string{"monitoring", "management"},
+	}
+	if diff := DiffUser(current, "testpass", []string{"management", "monitoring"}); len(diff) != 0 {
+		t.Errorf("Expected no diff, got %v", diff)
+	}
+	if diff := DiffUser(current, "otherpass", []string{"administrator"}); !slices.Equal(diff, []string{"password", "tags"}) {
+		t.Errorf("Unexpected diff: %v", diff)
+	}
+	if diff := DiffUser(nil, "testpass", nil); !slices.Equal(diff, []string{"user does not exist"}) {
+		t.Errorf("Unexpected diff: %v", diff)
+	}
+}
+
+func TestDiffUserHash(t *testing.T) {
+	passwordHash := hashPassword([]byte{1, 2, 3, 4}, "testpass")
+	current := &User{
+		Name:         "testuser",
+		PasswordHash: passwordHash,
+		Tags:         []string{"monitoring"},
+	}
+	if diff := DiffUserHash(current, passwordHash, HashingAlgorithmSHA256, []string{"monitoring"}); len(diff) != 0 {
+		t.Errorf("Expected no diff, got %v", diff)
+	}
+	if diff := DiffUserHash(current, passwordHash, HashingAlgorithmSHA512, []string{"monitoring"}); !slices.Equal(diff, []string{"password"}) {
+		t.Errorf("Unexpected diff: %v", diff)
+	}
+	if diff := DiffUserHash(current, hashPassword([]byte{1, 2, 3, 4}, "otherpass"), "", nil); !slices.Equal(diff, []string{"password", "tags"}) {
+		t.Errorf("Unexpected diff: %v", diff)
+	}
+	if diff := DiffUserHash(nil, passwordHash, "", nil); !slices.Equal(diff, []string{"user does not exist"}) {
+		t.Errorf("Unexpected diff: %v", diff)
+	}
+}
+
+func TestDiffPermissions(t *testing.T) {
+	current := &Permission{Configure: ".*", Write: ".*", Read: ".*"}
+	if diff := DiffPermissions(current, ".*", ".*", ".*"); len(diff) != 0 {
+		t.Errorf("Expected no diff, got %v", diff)
+	}
+	if diff := DiffPermissions(current, "", ".*", "^$"); !slices.Equal(diff, []string{"configure permission", "read permission"}) {
+		t.Errorf("Unexpected diff: %v", diff)
+	}
+	if diff := DiffPermissions(nil, ".*", ".*", ".*"); !slices.Equal(diff, []string{"permissions do not exist"}) {
+		t.Errorf("Unexpected diff: %v", diff)
+	}
+}
+
+func TestDiffVhost(t *testing.T) {
+	current := &Vhost{Name: "testvhost", Description: "nova", Tags: []string{"b", "a"}, DefaultQueueType: "quorum"}
+	if diff := DiffVhost(current, "nova", []string{"a", "b"}, ""); len(diff) != 0 {
+		t.Errorf("Expected no diff, got %v", diff)
+	}
+	if diff := DiffVhost(current, "cinder", nil, "classic"); !slices.Equal(diff, []string{"description", "tags", "default queue type"}) {
+		t.Errorf("Unexpected diff: %v", diff)
+	}
+	if diff := DiffVhost(nil, "", nil, ""); !slices.Equal(diff, []string{"vhost does not exist"}) {
+		t.Errorf("Unexpected diff: %v", diff)
+	}
+}
+
+func TestDiffVhostLimits(t *testing.T) {
+	current := map[string]int64{VhostLimitMaxConnections: 10, VhostLimitMaxQueues: 20}
+	if diff := DiffVhostLimits(current, map[string]int64{VhostLimitMaxConnections: 10, VhostLimitMaxQueues: 20}); len(diff) != 0 {
+		t.Errorf("Expected no diff, got %v", diff)
+	}
+	diff := DiffVhostLimits(current, map[string]int64{VhostLimitMaxConnections: 5})
+	if !slices.Equal(diff, []string{"limit " + VhostLimitMaxConnections, "limit " + VhostLimitMaxQueues}) {
+		t.Errorf("Unexpected diff: %v", diff)
+	}
+}
+
+func TestDiffUserLimits(t *testing.T) {
+	current := map[string]int64{UserLimitMaxConnections: 100}
+	if diff := DiffUserLimits(current, map[string]int64{UserLimitMaxConnections: 100}); len(diff) != 0 {
+		t.Errorf("Expected no diff, got %v", diff)
+	}
+	diff := DiffUserLimits(current, map[string]int64{UserLimitMaxChannels: 200})
+	if !slices.Equal(diff, []string{"limit " + UserLimitMaxChannels, "limit " + UserLimitMaxConnections}) {
+		t.Errorf("Unexpected diff: %v", diff)
+	}
+}
+
+func TestDiffTopicPermissions(t *testing.T) {
+	current := []TopicPermission{
+		{Exchange: "amq.topic", Write: "^notifications\\.", Read: ".*"},
+		{Exchange: "nova", Write: ".*", Read: ".*"},
+	}
+	desired := []TopicPermission{
+		{Exchange: "nova", Write: ".*", Read: ".*"},
+		{Exchange: "amq.topic", Write: "^notifications\\.", Read: ".*"},
+	}
+	if diff := DiffTopicPermissions(current, desired); len(diff) != 0 {
+		t.Errorf("Expected no diff, got %v", diff)
+	}
+	desired = []TopicPermission{
+		{Exchange: "amq.topic", Write: ".*", Read: ".*"},
+		{Exchange: "cinder", Write: ".*", Read: ".*"},
+	}
+	if diff := DiffTopicPermissions(current, desired); !slices.Equal(diff, []string{"topic permissions amq.topic", "topic permissions cinder", "topic permissions nova"}) {
+		t.Errorf("Unexpected diff: %v", diff)
+	}
+}
+
+func TestDiffPolicy(t *testing.T) {
+	current := &Policy{
+		Pattern:    "^notifications\\.",
+		Definition: map[string]interface{}{"max-length": float64(1000)},
+		Priority:   1,
+		ApplyTo:    "all",
+	}
+	if diff := DiffPolicy(current, "^notifications\\.", map[string]interface{}{"max-length": 1000}, 1, ""); len(diff) != 0 {
+		t.Errorf("Expected no diff, got %v", diff)
+	}
+	diff := DiffPolicy(current, ".*", map[string]interface{}{"max-length": 10}, 0, "queues")
+	if !slices.Equal(diff, []string{"pattern", "definition", "priority", "apply-to"}) {
+		t.Errorf("Unexpected diff: %v", diff)
+	}
+	if diff := DiffPolicy(nil, ".*", nil, 0, ""); !slices.Equal(diff, []string{"policy does not exist"}) {
+		t.Errorf("Unexpected diff: %v", diff)
+	}
+}
+
+func TestCompareUser(t *testing.T) {
+	passwordHash := hashPassword([]byte{1, 2, 3, 4}, "testpass")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		switch r.URL.Path {
+		case "/api/users/testuser":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"name":"testuser","password_hash":"` + passwordHash + `","hashing_algorithm":"rabbit_password_hashing_sha256","tags":["monitoring"]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	diff, err := client.CompareUser(context.Background(), "testuser", "testpass", []string{"monitoring"})
+	if err != nil {
+		t.Fatalf("CompareUser failed: %v", err)
+	}
+	if len(diff) != 0 {
+		t.Errorf("Expected no diff, got %v", diff)
+	}
+
+	diff, err = client.CompareUser(context.Background(), "missing", "testpass", nil)
+	if err != nil {
+		t.Fatalf("CompareUser failed: %v", err)
+	}
+	if !slices.Equal(diff, []string{"user does not exist"}) {
+		t.Errorf("Unexpected diff: %v", diff)
+	}
+}
+
+func TestComparePermissions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/permissions/testvhost/testuser" {
+			t.Errorf("Expected /api/permissions/testvhost/testuser, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"user":"testuser","vhost":"testvhost","configure":".*","write":".*","read":"^$"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	diff, err := client.ComparePermissions(context.Background(), "testvhost", "testuser", ".*", ".*", ".*")
+	if err != nil {
+		t.Fatalf("ComparePermissions failed: %v", err)
+	}
+	if !slices.Equal(diff, []string{"read permission"}) {
+		t.Errorf("Unexpected diff: %v", diff)
+	}
+}
+
+func TestComparePolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/policies/testvhost/testpolicy" {
+			t.Errorf("Expected /api/policies/testvhost/testpolicy, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"vhost":"testvhost","name":"testpolicy","pattern":".*","apply-to":"queues","definition":{"max-length":1000},"priority":0}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	diff, err := client.ComparePolicy(context.Background(), "testvhost", "testpolicy", ".*", map[string]interface{}{"max-length": 1000}, 0, "queues")
+	if err != nil {
+		t.Fatalf("ComparePolicy failed: %v", err)
+	}
+	if len(diff) != 0 {
+		t.Errorf("Expected no diff, got %v", diff)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//nolint:revive
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Errors returned by the RabbitMQ Management API, use errors.Is to check for them
+var (
+	// ErrNotFound is returned when the requested object does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrUnauthorized is returned when the credentials of the client are rejected
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrConflict is returned when the request conflicts with the state of RabbitMQ
+	ErrConflict = errors.New("conflict")
+)
+
+// maxErrorBodySize limits how much of the response body is kept in an APIError
+const maxErrorBodySize = 4 * 1024
+
+// APIError is returned when the RabbitMQ Management API responds with an unexpected status
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+// Error returns the status code and the body of the response
+func (e *APIError) Error() string {
+	return fmt.Sprintf("status %d, body: %s", e.StatusCode, e.Body)
+}
+
+// Is matches the APIError against ErrNotFound, ErrUnauthorized and ErrConflict
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	}
+	return false
+}
+
+// newAPIError returns an APIError for the response, reading the start of its body
+func newAPIError(resp *http.Response) *APIError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+	}
+}
+
+// IsNotFound returns true if the error is caused by a missing object
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsUnauthorized returns true if the error is caused by rejected credentials
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// IsConflict returns true if the error is caused by a conflict with the state of RabbitMQ
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
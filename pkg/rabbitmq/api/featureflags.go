@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//nolint:revive
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	// FeatureFlagStateEnabled - the feature flag is enabled on all nodes
+	FeatureFlagStateEnabled = "enabled"
+	// FeatureFlagStateDisabled - the feature flag is supported by all nodes and can be enabled
+	FeatureFlagStateDisabled = "disabled"
+	// FeatureFlagStateUnavailable - the feature flag is not supported by all nodes
+	FeatureFlagStateUnavailable = "unavailable"
+
+	// FeatureFlagStabilityStable - the feature flag is stable and safe to enable
+	FeatureFlagStabilityStable = "stable"
+)
+
+// Node represents a node of the RabbitMQ cluster
+type Node struct {
+	Name           string            `json:"name"`
+	Running        bool              `json:"running"`
+	EnabledPlugins []string          `json:"enabled_plugins"`
+	Applications   []NodeApplication `json:"applications"`
+	Partitions     []string          `json:"partitions"`
+}
+
+// NodeApplication is an Erlang application running on a node
+type NodeApplication struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// RabbitMQVersion returns the version of RabbitMQ running on the node, empty if unknown
+func (n *Node) RabbitMQVersion() string {
+	for _, app := range n.Applications {
+		if app.Name == "rabbit" {
+			return app.Version
+		}
+	}
+	return ""
+}
+
+// FeatureFlag represents a RabbitMQ feature flag
+type FeatureFlag struct {
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	Stability string `json:"stability"`
+}
+
+// ListNodes returns the nodes of the cluster
+func (c *Client) ListNodes(ctx context.Context) ([]Node, error) {
+	resp, err := c.doRequest(ctx, "GET", "/api/nodes", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list nodes: %w", newAPIError(resp))
+	}
+
+	nodes := []Node{}
+	if err := json.NewDecoder(resp.Body).Decode(&nodes); err != nil {
+		return nil, fmt.Errorf("failed to decode nodes: %w", err)
+	}
+
+	return nodes, nil
+}
+
+// ListFeatureFlags returns the feature flags of the cluster
+func (c *Client) ListFeatureFlags(ctx context.Context) ([]FeatureFlag, error) {
+	resp, err := c.doRequest(ctx, "GET", "/api/feature-flags", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list feature flags: %w", newAPIError(resp))
+	}
+
+	flags := []FeatureFlag{}
+	if err := json.NewDecoder(resp.Body).Decode(&flags); err != nil {
+		return nil, fmt.Errorf("failed to decode feature flags: %w", err)
+	}
+
+	return flags, nil
+}
+
+// EnableFeatureFlag enables a feature flag on all nodes of the cluster. Enabling a
+// feature flag cannot be undone, the cluster can't run older RabbitMQ versions afterwards.
+func (c *Client) EnableFeatureFlag(ctx context.Context, name string) error {
+	encodedName := url.PathEscape(name)
+	resp, err := c.doRequest(ctx, "PUT", fmt.Sprintf("/api/feature-flags/%s/enable", encodedName), map[string]interface{}{})
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to enable feature flag %s: %w", name, newAPIError(resp))
+	}
+
+	return nil
+}
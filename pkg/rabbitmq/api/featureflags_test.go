@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//nolint:revive
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListNodes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/nodes" {
+			t.Errorf("Expected /api/nodes, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[{"name":"rabbit@a","running":true,"enabled_plugins":["rabbitmq_management","rabbitmq_shovel"],` +
+			`"applications":[{"name":"mnesia","version":"4.23"},{"name":"rabbit","version":"4.1.0"}]}]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	result, err := client.ListNodes(context.Background())
+	if err != nil {
+		t.Fatalf("ListNodes failed: %v", err)
+	}
+	if len(result) != 1 || !result[0].Running || len(result[0].EnabledPlugins) != 2 {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if version := result[0].RabbitMQVersion(); version != "4.1.0" {
+		t.Errorf("Expected RabbitMQ version 4.1.0, got %s", version)
+	}
+}
+
+func TestListFeatureFlags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/feature-flags" {
+			t.Errorf("Expected /api/feature-flags, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[{"name":"quorum_queue","state":"enabled","stability":"required"},` +
+			`{"name":"khepri_db","state":"disabled","stability":"experimental"}]`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	result, err := client.ListFeatureFlags(context.Background())
+	if err != nil {
+		t.Fatalf("ListFeatureFlags failed: %v", err)
+	}
+	if len(result) != 2 || result[1].State != FeatureFlagStateDisabled || result[1].Stability != "experimental" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
+func TestEnableFeatureFlag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			t.Errorf("Expected PUT request, got %s", r.Method)
+		}
+		if r.URL.Path == "/api/feature-flags/unknown/enable" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path != "/api/feature-flags/message_containers/enable" {
+			t.Errorf("Expected /api/feature-flags/message_containers/enable, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	if err := client.EnableFeatureFlag(context.Background(), "message_containers"); err != nil {
+		t.Errorf("EnableFeatureFlag failed: %v", err)
+	}
+	if err := client.EnableFeatureFlag(context.Background(), "unknown"); err == nil {
+		t.Error("Expected EnableFeatureFlag to fail for an unknown feature flag")
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//nolint:revive
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// HealthCheckStatusOK - the health check passed
+	HealthCheckStatusOK = "ok"
+	// HealthCheckStatusFailed - the health check failed, the reason and details are in the result
+	HealthCheckStatusFailed = "failed"
+)
+
+// HealthCheck is the result of a health check
+type HealthCheck struct {
+	Status string             `json:"status"`
+	Reason string             `json:"reason,omitempty"`
+	Alarms []Alarm            `json:"alarms,omitempty"`
+	Queues []HealthCheckQueue `json:"queues,omitempty"`
+}
+
+// OK returns true if the health check passed
+func (h *HealthCheck) OK() bool {
+	return h.Status == HealthCheckStatusOK
+}
+
+// Alarm is a resource alarm in effect on a node, e.g. a memory or disk alarm
+type Alarm struct {
+	Node     string `json:"node"`
+	Resource string `json:"resource"`
+}
+
+// HealthCheckQueue is a queue reported by a failed health check
+type HealthCheckQueue struct {
+	Name  string `json:"name"`
+	Vhost string `json:"virtual_host"`
+}
+
+// CheckAlarms checks for resource alarms in effect on any node of the cluster
+func (c *Client) CheckAlarms(ctx context.Context) (*HealthCheck, error) {
+	return c.checkHealth(ctx, "alarms")
+}
+
+// CheckLocalAlarms checks for resource alarms in effect on the node serving the request
+func (c *Client) CheckLocalAlarms(ctx context.Context) (*HealthCheck, error) {
+	return c.checkHealth(ctx, "local-alarms")
+}
+
+// CheckNodeIsQuorumCritical checks for quorum queues which would lose their majority if the
+// node serving the request got stopped
+func (c *Client) CheckNodeIsQuorumCritical(ctx context.Context) (*HealthCheck, error) {
+	return c.checkHealth(ctx, "node-is-quorum-critical")
+}
+
+// checkHealth runs a health check. A failed health check is not an error, it responds with
+// 503 and the details of the failure. The request is sent once, as 503 is an expected
+// response which must not be retried like other server errors.
+func (c *Client) checkHealth(ctx context.Context, check string) (*HealthCheck, error) {
+	resp, err := c.send(ctx, "GET", fmt.Sprintf("/api/health/checks/%s", check), nil, DefaultAPITimeout)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer closeBody(resp)
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusServiceUnavailable {
+		return nil, fmt.Errorf("failed to run health check %s: %w", check, newAPIError(resp))
+	}
+
+	result := &HealthCheck{}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, fmt.Errorf("failed to decode health check %s: %w", check, err)
+	}
+
+	return result, nil
+}
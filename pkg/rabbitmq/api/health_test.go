@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//nolint:revive
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCheckAlarms(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/health/checks/alarms" {
+			t.Errorf("Expected /api/health/checks/alarms, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	result, err := client.CheckAlarms(context.Background())
+	if err != nil {
+		t.Fatalf("CheckAlarms failed: %v", err)
+	}
+	if !result.OK() {
+		t.Errorf("Expected the health check to pass, got %+v", result)
+	}
+}
+
+func TestCheckLocalAlarmsFailed(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path != "/api/health/checks/local-alarms" {
+			t.Errorf("Expected /api/health/checks/local-alarms, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"status":"failed","reason":"There are alarms in effect on the node",` +
+			`"alarms":[{"node":"rabbit@a","resource":"memory"}]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	result, err := client.CheckLocalAlarms(context.Background())
+	if err != nil {
+		t.Fatalf("CheckLocalAlarms failed: %v", err)
+	}
+	if result.OK() || len(result.Alarms) != 1 || result.Alarms[0].Resource != "memory" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("Expected a failed health check not to be retried, got %d requests", n)
+	}
+}
+
+func TestCheckNodeIsQuorumCritical(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/health/checks/node-is-quorum-critical" {
+			t.Errorf("Expected /api/health/checks/node-is-quorum-critical, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"status":"failed","reason":"There are quorum queues that would lose their quorum",` +
+			`"queues":[{"name":"q1","readable_name":"queue 'q1' in vhost '/'","virtual_host":"/"}]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	result, err := client.CheckNodeIsQuorumCritical(context.Background())
+	if err != nil {
+		t.Fatalf("CheckNodeIsQuorumCritical failed: %v", err)
+	}
+	if result.OK() || len(result.Queues) != 1 || result.Queues[0].Name != "q1" || result.Queues[0].Vhost != "/" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
+func TestCheckHealthError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	_, err := client.CheckAlarms(context.Background())
+	if !IsUnauthorized(err) {
+		t.Errorf("Expected an unauthorized error, got %v", err)
+	}
+}
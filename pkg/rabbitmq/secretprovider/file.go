@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretprovider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// FileProvider reads passwords from files mounted into the operator pod, e.g. by the
+// Secrets Store CSI driver or from a Secret volume of another namespace
+type FileProvider struct {
+	// BaseDir is the directory the secrets are mounted to, paths are relative to it
+	BaseDir string
+}
+
+// NewFileProvider returns a provider which reads passwords from files in baseDir
+func NewFileProvider(baseDir string) *FileProvider {
+	return &FileProvider{BaseDir: baseDir}
+}
+
+// GetSecret returns the content of the file at the path below the base directory. A trailing
+// newline is removed. The modification time of the file is used as version, mounted secrets
+// get replaced atomically on rotation, which changes it.
+func (p *FileProvider) GetSecret(_ context.Context, path string) (*Secret, error) {
+	if !filepath.IsLocal(path) {
+		return nil, fmt.Errorf("secret path %q must be relative to the secrets directory", path)
+	}
+
+	fullPath := filepath.Join(p.BaseDir, path)
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret %s: %w", path, err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("secret %s is a directory", path)
+	}
+	content, err := os.ReadFile(fullPath) //nolint:gosec // the path is confined to the secrets directory
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret %s: %w", path, err)
+	}
+
+	password := strings.TrimSuffix(strings.TrimSuffix(string(content), "\n"), "\r")
+	if password == "" {
+		return nil, fmt.Errorf("secret %s is empty", path)
+	}
+
+	return &Secret{
+		Password: password,
+		Version:  strconv.FormatInt(info.ModTime().UnixNano(), 10),
+	}, nil
+}
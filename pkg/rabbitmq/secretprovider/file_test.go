@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretprovider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileProviderGetSecret(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "nova"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "nova", "password")
+	if err := os.WriteFile(path, []byte("secret-password\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := NewFileProvider(dir)
+	secret, err := provider.GetSecret(context.Background(), "nova/password")
+	if err != nil {
+		t.Fatalf("GetSecret failed: %v", err)
+	}
+	if secret.Password != "secret-password" {
+		t.Errorf("Expected the password without trailing newline, got %q", secret.Password)
+	}
+
+	// Rotating the password changes the version
+	if err := os.WriteFile(path, []byte("rotated-password"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	rotated, err := provider.GetSecret(context.Background(), "nova/password")
+	if err != nil {
+		t.Fatalf("GetSecret failed: %v", err)
+	}
+	if rotated.Password != "rotated-password" || rotated.Version == secret.Version {
+		t.Errorf("Expected the rotated password with a new version, got %+v", rotated)
+	}
+}
+
+func TestFileProviderGetSecretErrors(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "empty"), []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := NewFileProvider(filepath.Join(dir, "secrets"))
+	for _, path := range []string{"../empty", "/etc/passwd", "", "missing"} {
+		if _, err := provider.GetSecret(context.Background(), path); err == nil {
+			t.Errorf("Expected an error for path %q", path)
+		}
+	}
+
+	provider = NewFileProvider(dir)
+	if _, err := provider.GetSecret(context.Background(), "empty"); err == nil {
+		t.Error("Expected an error for an empty secret")
+	}
+	if _, err := provider.GetSecret(context.Background(), "."); err == nil {
+		t.Error("Expected an error for a directory")
+	}
+}
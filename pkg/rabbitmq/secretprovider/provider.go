@@ -0,0 +1,38 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package secretprovider reads passwords of RabbitMQ users from external secret providers,
+// so that they don't need to be stored in a Secret of the namespace
+package secretprovider
+
+import (
+	"context"
+)
+
+// Secret is a password read from a secret provider
+type Secret struct {
+	// Password of the user
+	Password string
+	// Version changes whenever the password changes, it is used to tell when the password
+	// needs to be applied to RabbitMQ again
+	Version string
+}
+
+// Provider reads passwords from an external secret provider
+type Provider interface {
+	// GetSecret returns the password at the path of the provider
+	GetSecret(ctx context.Context, path string) (*Secret, error)
+}
@@ -17,16 +17,24 @@ limitations under the License.
 package functional_test
 
 import (
+	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	. "github.com/onsi/gomega" //revive:disable:dot-imports
 
 	"github.com/google/uuid"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -69,8 +77,147 @@ var (
 	mockRabbitMQHost string
 	// mockRabbitMQPort is the port of the mock server
 	mockRabbitMQPort string
+	// mockRabbitMQQueues is the JSON list of queues of all vhosts returned by the mock server
+	mockRabbitMQQueues atomic.Value
+	// mockRabbitMQImportedDefinitions is the last JSON body posted to /api/definitions of the mock server
+	mockRabbitMQImportedDefinitions atomic.Value
+	// mockRabbitMQEnabledFeatureFlags are the feature flags enabled through the mock server
+	mockRabbitMQEnabledFeatureFlags sync.Map
+	// mockRabbitMQAlarms is the JSON list of resource alarms reported by the health checks of the mock server
+	mockRabbitMQAlarms atomic.Value
+	// mockRabbitMQObjects are the JSON bodies returned by the mock server for GET requests, keyed by escaped path
+	mockRabbitMQObjects sync.Map
+	// mockRabbitMQRequests are the requests received by the mock server as "METHOD escaped-path"
+	mockRabbitMQRequests []string
+	// mockRabbitMQRequestsLock guards mockRabbitMQRequests and mockRabbitMQCommands
+	mockRabbitMQRequestsLock sync.Mutex
+	// mockRabbitMQCommands are the commands run in the pods of the RabbitMQ clusters as "pod: command"
+	mockRabbitMQCommands []string
+	// mockRabbitMQNodePods are the pods whose node was reached through the mock server
+	mockRabbitMQNodePods []string
 )
 
+// mockRabbitMQDefinitions are the definitions exported by the mock RabbitMQ Management API
+const mockRabbitMQDefinitions = `{"rabbit_version":"4.1.0",` +
+	`"users":[{"name":"nova","tags":[]},{"name":"neutron","tags":[]}],` +
+	`"vhosts":[{"name":"nova"},{"name":"neutron"}],` +
+	`"permissions":[{"user":"nova","vhost":"nova","configure":".*","write":".*","read":".*"},` +
+	`{"user":"neutron","vhost":"neutron","configure":".*","write":".*","read":".*"}],` +
+	`"queues":[{"vhost":"nova","name":"scheduler"},{"vhost":"neutron","name":"q-agent"}]}`
+
+// GetMockRabbitMQImportedDefinitions returns the definitions last imported into the mock
+// RabbitMQ Management API, reset by StopMockRabbitMQAPI
+func GetMockRabbitMQImportedDefinitions() string {
+	definitions, _ := mockRabbitMQImportedDefinitions.Load().(string)
+	return definitions
+}
+
+// IsMockRabbitMQFeatureFlagEnabled returns true if the feature flag got enabled through the
+// mock RabbitMQ Management API, reset by StopMockRabbitMQAPI
+func IsMockRabbitMQFeatureFlagEnabled(name string) bool {
+	_, ok := mockRabbitMQEnabledFeatureFlags.Load(name)
+	return ok
+}
+
+// SetMockRabbitMQQueues sets the JSON list of queues of all vhosts returned by the mock
+// RabbitMQ Management API, reset to an empty list by StopMockRabbitMQAPI
+func SetMockRabbitMQQueues(queues string) {
+	mockRabbitMQQueues.Store(queues)
+}
+
+// SetMockRabbitMQAlarms sets the JSON list of resource alarms reported by the health checks of
+// the mock RabbitMQ Management API, reset to no alarms by StopMockRabbitMQAPI
+func SetMockRabbitMQAlarms(alarms string) {
+	mockRabbitMQAlarms.Store(alarms)
+}
+
+// SetMockRabbitMQObject sets the JSON body the mock RabbitMQ Management API returns for GET
+// requests of the escaped path, e.g. to simulate changes made outside of the operator. An empty
+// body removes the object again. All objects are removed by StopMockRabbitMQAPI
+func SetMockRabbitMQObject(path string, body string) {
+	if body == "" {
+		mockRabbitMQObjects.Delete(path)
+		return
+	}
+	mockRabbitMQObjects.Store(path, body)
+}
+
+// GetMockRabbitMQRequests returns the requests received by the mock RabbitMQ Management API
+// as "METHOD escaped-path", reset by StopMockRabbitMQAPI
+func GetMockRabbitMQRequests() []string {
+	mockRabbitMQRequestsLock.Lock()
+	defer mockRabbitMQRequestsLock.Unlock()
+	return append([]string{}, mockRabbitMQRequests...)
+}
+
+// ExecMockRabbitMQCommand replaces running commands in the RabbitMQ pods, envtest doesn't run
+// pods. The command is recorded and rabbitmqctl forget_cluster_node removes the node from the
+// nodes returned by the mock RabbitMQ Management API, if they are set with SetMockRabbitMQObject
+func ExecMockRabbitMQCommand(_ context.Context, _, pod, _ string, command []string) error {
+	mockRabbitMQRequestsLock.Lock()
+	mockRabbitMQCommands = append(mockRabbitMQCommands, pod+": "+strings.Join(command, " "))
+	mockRabbitMQRequestsLock.Unlock()
+
+	if len(command) != 3 || command[0] != "rabbitmqctl" || command[1] != "forget_cluster_node" {
+		return nil
+	}
+	body, ok := mockRabbitMQObjects.Load("/api/nodes")
+	if !ok {
+		return nil
+	}
+	nodes := []map[string]any{}
+	if err := json.Unmarshal([]byte(body.(string)), &nodes); err != nil {
+		return err
+	}
+	remaining := []map[string]any{}
+	for _, node := range nodes {
+		if node["name"] != command[2] {
+			remaining = append(remaining, node)
+		}
+	}
+	data, err := json.Marshal(remaining)
+	if err != nil {
+		return err
+	}
+	mockRabbitMQObjects.Store("/api/nodes", string(data))
+	return nil
+}
+
+// MockRabbitMQNodeHost replaces the hostname of the pod of a single node, which doesn't resolve
+// in the tests, by the host of the mock RabbitMQ Management API. The pod is recorded.
+func MockRabbitMQNodeHost(_ *rabbitmqclusterv2.RabbitmqCluster, podName string) string {
+	mockRabbitMQRequestsLock.Lock()
+	defer mockRabbitMQRequestsLock.Unlock()
+	if !slices.Contains(mockRabbitMQNodePods, podName) {
+		mockRabbitMQNodePods = append(mockRabbitMQNodePods, podName)
+	}
+	return mockRabbitMQHost
+}
+
+// GetMockRabbitMQNodePods returns the pods whose node was reached through MockRabbitMQNodeHost,
+// reset by StopMockRabbitMQAPI
+func GetMockRabbitMQNodePods() []string {
+	mockRabbitMQRequestsLock.Lock()
+	defer mockRabbitMQRequestsLock.Unlock()
+	return append([]string{}, mockRabbitMQNodePods...)
+}
+
+// GetMockRabbitMQCommands returns the commands run by ExecMockRabbitMQCommand as
+// "pod: command", reset by StopMockRabbitMQAPI
+func GetMockRabbitMQCommands() []string {
+	mockRabbitMQRequestsLock.Lock()
+	defer mockRabbitMQRequestsLock.Unlock()
+	return append([]string{}, mockRabbitMQCommands...)
+}
+
+// MockRabbitMQPasswordHash returns the password hash RabbitMQ stores for the password with the
+// default rabbit_password_hashing_sha256 algorithm, base64(salt + sha256(salt + password))
+func MockRabbitMQPasswordHash(password string) string {
+	salt := []byte{0x90, 0x8d, 0xc6, 0x0a}
+	sum := sha256.Sum256(append(append([]byte{}, salt...), password...))
+	return base64.StdEncoding.EncodeToString(append(salt, sum[:]...))
+}
+
 // SetupMockRabbitMQAPI starts a mock RabbitMQ Management API server for tests
 // Call this in BeforeEach and defer StopMockRabbitMQAPI() to clean up
 func SetupMockRabbitMQAPI() {
@@ -89,12 +236,35 @@ func StopMockRabbitMQAPI() {
 		mockRabbitMQHost = ""
 		mockRabbitMQPort = ""
 	}
+	mockRabbitMQQueues.Store("[]")
+	mockRabbitMQImportedDefinitions.Store("")
+	mockRabbitMQEnabledFeatureFlags.Clear()
+	mockRabbitMQAlarms.Store("")
+	mockRabbitMQObjects.Clear()
+	mockRabbitMQRequestsLock.Lock()
+	mockRabbitMQRequests = nil
+	mockRabbitMQCommands = nil
+	mockRabbitMQNodePods = nil
+	mockRabbitMQRequestsLock.Unlock()
 }
 
 // StartMockRabbitMQAPI starts an HTTP test server that mocks the RabbitMQ Management API
 // Returns the server, host, and port to use in secrets
 func StartMockRabbitMQAPI() (*httptest.Server, string, string) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mockRabbitMQRequestsLock.Lock()
+		mockRabbitMQRequests = append(mockRabbitMQRequests, r.Method+" "+r.URL.EscapedPath())
+		mockRabbitMQRequestsLock.Unlock()
+
+		// Objects set by the tests take precedence over the fixed responses below
+		if r.Method == http.MethodGet {
+			if body, ok := mockRabbitMQObjects.Load(r.URL.EscapedPath()); ok {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(body.(string)))
+				return
+			}
+		}
+
 		// Mock all RabbitMQ Management API endpoints
 		// Return 204 No Content for PUT operations (create/update)
 		// Return 204 No Content for DELETE operations
@@ -103,18 +273,137 @@ func StartMockRabbitMQAPI() (*httptest.Server, string, string) {
 			w.WriteHeader(http.StatusNoContent)
 		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/users/"):
 			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/users":
+			// Objects in RabbitMQ without a CR, used to test the orphan sweep
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`[{"name":"user","tags":["administrator"]},{"name":"orphaned-user","tags":[]}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/vhosts":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`[{"name":"/"},{"name":"orphaned-vhost"}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/policies":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`[{"vhost":"/","name":"orphaned-policy","pattern":".*","apply-to":"all","definition":{},"priority":0}]`))
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/vhosts/") && strings.HasSuffix(r.URL.Path, "/connections"):
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("[]"))
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/connections/"):
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/vhosts/existing-vhost":
+			// Vhost which already exists in RabbitMQ, used to test adoption
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"name":"existing-vhost","description":"","tags":[]}`))
 		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/api/vhosts/"):
 			w.WriteHeader(http.StatusNoContent)
 		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/vhosts/"):
 			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/vhost-limits/"):
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("[]"))
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/api/vhost-limits/"):
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/vhost-limits/"):
+			w.WriteHeader(http.StatusNoContent)
 		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/api/permissions/"):
 			w.WriteHeader(http.StatusNoContent)
 		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/permissions/"):
 			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/topic-permissions/"):
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("[]"))
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/api/topic-permissions/"):
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/topic-permissions/"):
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/user-limits/"):
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("[]"))
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/api/user-limits/"):
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/user-limits/"):
+			w.WriteHeader(http.StatusNoContent)
 		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/api/policies/"):
 			w.WriteHeader(http.StatusNoContent)
 		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/policies/"):
 			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/api/queues/"):
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/queues":
+			queues, _ := mockRabbitMQQueues.Load().(string)
+			if queues == "" {
+				queues = "[]"
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(queues))
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/queues/quorum/replicas/on/"):
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("[]"))
+		case r.Method == http.MethodGet && r.URL.EscapedPath() == "/api/queues/%2F":
+			// Queues of the default vhost, used to test the migration to quorum queues
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`[{"name":"classic-queue","vhost":"/","type":"classic","durable":true,"consumers":0,"messages":0},` +
+				`{"name":"quorum-queue","vhost":"/","type":"quorum","durable":true,"consumers":1,"messages":0}]`))
+		case r.Method == http.MethodGet && strings.Count(r.URL.EscapedPath(), "/") == 3 && strings.HasPrefix(r.URL.Path, "/api/queues/"):
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("[]"))
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/queues/"):
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"messages":0}`))
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/queues/"):
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/api/exchanges/"):
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/exchanges/"):
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/bindings/"):
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("[]"))
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/api/bindings/"):
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/bindings/"):
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/definitions":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(mockRabbitMQDefinitions))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/definitions":
+			body, _ := io.ReadAll(r.Body)
+			mockRabbitMQImportedDefinitions.Store(string(body))
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/nodes":
+			// A single node, the cluster of the tests has one replica by default
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`[{"name":"rabbit@rabbitmq-server-0","running":true,` +
+				`"enabled_plugins":["rabbitmq_management","rabbitmq_peer_discovery_k8s","rabbitmq_prometheus","rabbitmq_shovel"],` +
+				`"applications":[{"name":"rabbit","version":"4.1.0"}],"partitions":[]}]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/feature-flags":
+			// message_containers is a stable feature flag which is disabled until enabled through the API
+			state := "disabled"
+			if IsMockRabbitMQFeatureFlagEnabled("message_containers") {
+				state = "enabled"
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprintf(w, `[{"name":"quorum_queue","state":"enabled","stability":"required"},`+
+				`{"name":"message_containers","state":"%s","stability":"stable"},`+
+				`{"name":"khepri_db","state":"disabled","stability":"experimental"}]`, state)
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/api/feature-flags/"):
+			name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/feature-flags/"), "/enable")
+			mockRabbitMQEnabledFeatureFlags.Store(name, true)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && (r.URL.Path == "/api/health/checks/alarms" || r.URL.Path == "/api/health/checks/local-alarms"):
+			alarms, _ := mockRabbitMQAlarms.Load().(string)
+			if alarms == "" {
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"status":"ok"}`))
+				return
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = fmt.Fprintf(w, `{"status":"failed","reason":"There are alarms in effect in the cluster","alarms":%s}`, alarms)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/health/checks/node-is-quorum-critical":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"status":"ok"}`))
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/api/parameters/"):
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/parameters/"):
+			w.WriteHeader(http.StatusNoContent)
 		default:
 			w.WriteHeader(http.StatusNotFound)
 		}
@@ -1317,3 +1606,169 @@ func RabbitMQPolicyConditionGetter(name types.NamespacedName) condition.Conditio
 	instance := GetRabbitMQPolicy(name)
 	return instance.Status.Conditions
 }
+
+func CreateRabbitMQQueue(name types.NamespacedName, spec map[string]any) client.Object {
+	raw := map[string]any{
+		"apiVersion": "rabbitmq.openstack.org/v1beta1",
+		"kind":       "RabbitMQQueue",
+		"metadata": map[string]any{
+			"name":      name.Name,
+			"namespace": name.Namespace,
+		},
+		"spec": spec,
+	}
+	return th.CreateUnstructured(raw)
+}
+
+func GetRabbitMQQueue(name types.NamespacedName) *rabbitmqv1.RabbitMQQueue {
+	instance := &rabbitmqv1.RabbitMQQueue{}
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, name, instance)).Should(Succeed())
+	}, timeout, interval).Should(Succeed())
+	return instance
+}
+
+func CreateRabbitMQExchange(name types.NamespacedName, spec map[string]any) client.Object {
+	raw := map[string]any{
+		"apiVersion": "rabbitmq.openstack.org/v1beta1",
+		"kind":       "RabbitMQExchange",
+		"metadata": map[string]any{
+			"name":      name.Name,
+			"namespace": name.Namespace,
+		},
+		"spec": spec,
+	}
+	return th.CreateUnstructured(raw)
+}
+
+func GetRabbitMQExchange(name types.NamespacedName) *rabbitmqv1.RabbitMQExchange {
+	instance := &rabbitmqv1.RabbitMQExchange{}
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, name, instance)).Should(Succeed())
+	}, timeout, interval).Should(Succeed())
+	return instance
+}
+
+func CreateRabbitMQBinding(name types.NamespacedName, spec map[string]any) client.Object {
+	raw := map[string]any{
+		"apiVersion": "rabbitmq.openstack.org/v1beta1",
+		"kind":       "RabbitMQBinding",
+		"metadata": map[string]any{
+			"name":      name.Name,
+			"namespace": name.Namespace,
+		},
+		"spec": spec,
+	}
+	return th.CreateUnstructured(raw)
+}
+
+func GetRabbitMQBinding(name types.NamespacedName) *rabbitmqv1.RabbitMQBinding {
+	instance := &rabbitmqv1.RabbitMQBinding{}
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, name, instance)).Should(Succeed())
+	}, timeout, interval).Should(Succeed())
+	return instance
+}
+
+func CreateRabbitMQShovel(name types.NamespacedName, spec map[string]any) client.Object {
+	raw := map[string]any{
+		"apiVersion": "rabbitmq.openstack.org/v1beta1",
+		"kind":       "RabbitMQShovel",
+		"metadata": map[string]any{
+			"name":      name.Name,
+			"namespace": name.Namespace,
+		},
+		"spec": spec,
+	}
+	return th.CreateUnstructured(raw)
+}
+
+func GetRabbitMQShovel(name types.NamespacedName) *rabbitmqv1.RabbitMQShovel {
+	instance := &rabbitmqv1.RabbitMQShovel{}
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, name, instance)).Should(Succeed())
+	}, timeout, interval).Should(Succeed())
+	return instance
+}
+
+func CreateRabbitMQFederationUpstream(name types.NamespacedName, spec map[string]any) client.Object {
+	raw := map[string]any{
+		"apiVersion": "rabbitmq.openstack.org/v1beta1",
+		"kind":       "RabbitMQFederationUpstream",
+		"metadata": map[string]any{
+			"name":      name.Name,
+			"namespace": name.Namespace,
+		},
+		"spec": spec,
+	}
+	return th.CreateUnstructured(raw)
+}
+
+func GetRabbitMQFederationUpstream(name types.NamespacedName) *rabbitmqv1.RabbitMQFederationUpstream {
+	instance := &rabbitmqv1.RabbitMQFederationUpstream{}
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, name, instance)).Should(Succeed())
+	}, timeout, interval).Should(Succeed())
+	return instance
+}
+
+func CreateRabbitMQBackup(name types.NamespacedName, spec map[string]any) client.Object {
+	raw := map[string]any{
+		"apiVersion": "rabbitmq.openstack.org/v1beta1",
+		"kind":       "RabbitMQBackup",
+		"metadata": map[string]any{
+			"name":      name.Name,
+			"namespace": name.Namespace,
+		},
+		"spec": spec,
+	}
+	return th.CreateUnstructured(raw)
+}
+
+func GetRabbitMQBackup(name types.NamespacedName) *rabbitmqv1.RabbitMQBackup {
+	instance := &rabbitmqv1.RabbitMQBackup{}
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, name, instance)).Should(Succeed())
+	}, timeout, interval).Should(Succeed())
+	return instance
+}
+
+func CreateRabbitMQRestore(name types.NamespacedName, spec map[string]any) client.Object {
+	raw := map[string]any{
+		"apiVersion": "rabbitmq.openstack.org/v1beta1",
+		"kind":       "RabbitMQRestore",
+		"metadata": map[string]any{
+			"name":      name.Name,
+			"namespace": name.Namespace,
+		},
+		"spec": spec,
+	}
+	return th.CreateUnstructured(raw)
+}
+
+func GetRabbitMQRestore(name types.NamespacedName) *rabbitmqv1.RabbitMQRestore {
+	instance := &rabbitmqv1.RabbitMQRestore{}
+	Eventually(func(g Gomega) {
+		g.Expect(k8sClient.Get(ctx, name, instance)).Should(Succeed())
+	}, timeout, interval).Should(Succeed())
+	return instance
+}
+
+// SimulateBackupJobComplete marks a Job of a backup or restore as completed, envtest doesn't
+// run the pods of Jobs
+func SimulateBackupJobComplete(name types.NamespacedName) {
+	Eventually(func(g Gomega) {
+		job := &batchv1.Job{}
+		g.Expect(k8sClient.Get(ctx, name, job)).Should(Succeed())
+		now := metav1.Now()
+		job.Status.StartTime = &now
+		job.Status.CompletionTime = &now
+		job.Status.Succeeded = 1
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type:               batchv1.JobComplete,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: now,
+		}}
+		g.Expect(k8sClient.Status().Update(ctx, job)).Should(Succeed())
+	}, timeout, interval).Should(Succeed())
+}
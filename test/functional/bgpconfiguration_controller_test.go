@@ -17,11 +17,15 @@ limitations under the License.
 package functional_test
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
 	. "github.com/onsi/gomega"    //revive:disable:dot-imports
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	frrk8sv1 "github.com/metallb/frr-k8s/api/v1beta1"
 	networkv1 "github.com/openstack-k8s-operators/infra-operator/apis/network/v1beta1"
@@ -573,4 +577,81 @@ var _ = Describe("BGPConfiguration controller", func() {
 			})
 		})
 	})
+
+	When("a BGPConfiguration with neighborOverrides gets created", func() {
+		var podName types.NamespacedName
+		var metallbNS *corev1.Namespace
+
+		BeforeEach(func() {
+			metallbNS = th.CreateNamespace(frrCfgNamespace + "-" + namespace)
+			// create a FRR configuration for a node which defines a BFD profile
+			frrSpec := GetMetalLBFRRConfigurationSpec("worker-0")
+			frrSpec["bgp"].(map[string]any)["bfdProfiles"] = []map[string]any{
+				{
+					"name":             "bfd-fast",
+					"receiveInterval":  300,
+					"transmitInterval": 300,
+				},
+			}
+			meallbFRRCfg := CreateFRRConfiguration(
+				types.NamespacedName{Namespace: metallbNS.Name, Name: "worker-0"}, frrSpec)
+			Expect(meallbFRRCfg).To(Not(BeNil()))
+
+			nad := th.CreateNAD(types.NamespacedName{Namespace: namespace, Name: "internalapi"}, GetNADSpec())
+
+			bgpSpec := GetBGPConfigurationSpec(metallbNS.Name)
+			bgpSpec["neighborOverrides"] = map[string]any{
+				"bfdProfile":    "bfd-fast",
+				"holdTime":      "9s",
+				"keepaliveTime": "3s",
+			}
+			bgpcfg := CreateBGPConfiguration(namespace, bgpSpec)
+
+			podName = types.NamespacedName{Namespace: namespace, Name: uuid.New().String()}
+			th.CreatePod(podName, GetPodAnnotation(namespace), GetPodSpec("worker-0"))
+			th.SimulatePodPhaseRunning(podName)
+
+			DeferCleanup(th.DeleteInstance, bgpcfg)
+			DeferCleanup(th.DeleteInstance, nad)
+			DeferCleanup(th.DeleteInstance, meallbFRRCfg)
+		})
+
+		It("should have created a FRRConfiguration with the overrides applied to the neighbors", func() {
+			podFrrName := podName.Namespace + "-" + podName.Name
+			Eventually(func(g Gomega) {
+				frr := GetFRRConfiguration(types.NamespacedName{Namespace: metallbNS.Name, Name: podFrrName})
+				g.Expect(frr).To(Not(BeNil()))
+				g.Expect(frr.Spec.BGP.BFDProfiles).To(HaveLen(1))
+				g.Expect(frr.Spec.BGP.BFDProfiles[0].Name).To(Equal("bfd-fast"))
+				neighbor := frr.Spec.BGP.Routers[0].Neighbors[0]
+				g.Expect(neighbor.BFDProfile).To(Equal("bfd-fast"))
+				g.Expect(neighbor.HoldTime.Duration).To(Equal(9 * time.Second))
+				g.Expect(neighbor.KeepaliveTime.Duration).To(Equal(3 * time.Second))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("a BGPConfiguration with invalid neighborOverrides gets created", func() {
+		It("should be rejected by the webhook", func() {
+			bgpSpec := GetBGPConfigurationSpec(namespace)
+			bgpSpec["neighborOverrides"] = map[string]any{
+				"holdTime":      "9s",
+				"keepaliveTime": "10s",
+			}
+			raw := map[string]any{
+				"apiVersion": "network.openstack.org/v1beta1",
+				"kind":       "BGPConfiguration",
+				"metadata": map[string]any{
+					"name":      "bgp-invalid",
+					"namespace": namespace,
+				},
+				"spec": bgpSpec,
+			}
+			unstructuredObj := &unstructured.Unstructured{Object: raw}
+			_, err := controllerutil.CreateOrPatch(
+				th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("keepaliveTime 10s must be lower than holdTime 9s"))
+		})
+	})
 })
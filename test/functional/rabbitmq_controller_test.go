@@ -19,6 +19,7 @@ package functional_test
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
 	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
@@ -33,6 +34,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 )
 
 const (
@@ -114,6 +116,734 @@ var _ = Describe("RabbitMQ Controller", func() {
 		})
 	})
 
+	When("a RabbitMQ gets created with orphaned objects in RabbitMQ", func() {
+		BeforeEach(func() {
+			SetupMockRabbitMQAPI()
+			DeferCleanup(StopMockRabbitMQAPI)
+
+			rabbitmq := CreateRabbitMQ(rabbitmqName, GetDefaultRabbitMQSpec())
+			DeferCleanup(th.DeleteInstance, rabbitmq)
+		})
+
+		It("should report the orphaned objects in the status", func() {
+			SimulateRabbitMQClusterReady(rabbitmqName)
+
+			// The mock API returns the default user, the default vhost and one
+			// orphaned user, vhost and policy without a CR
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				g.Expect(instance.Status.Orphans).ToNot(BeNil())
+				g.Expect(instance.Status.Orphans.LastSweepTime).ToNot(BeNil())
+				g.Expect(instance.Status.Orphans.Users).To(Equal([]string{"orphaned-user"}))
+				g.Expect(instance.Status.Orphans.Vhosts).To(Equal([]string{"orphaned-vhost"}))
+				g.Expect(instance.Status.Orphans.Policies).To(Equal([]string{"//orphaned-policy"}))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("a RabbitMQ with orphan deletion gets created with orphaned objects in RabbitMQ", func() {
+		BeforeEach(func() {
+			SetupMockRabbitMQAPI()
+			DeferCleanup(StopMockRabbitMQAPI)
+
+			spec := GetDefaultRabbitMQSpec()
+			spec["orphanCleanup"] = map[string]any{"delete": true}
+			rabbitmq := CreateRabbitMQ(rabbitmqName, spec)
+			DeferCleanup(th.DeleteInstance, rabbitmq)
+		})
+
+		It("should delete the orphaned objects", func() {
+			SimulateRabbitMQClusterReady(rabbitmqName)
+
+			// The mock API accepts the deletion of all orphaned objects
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				g.Expect(instance.Status.Orphans).ToNot(BeNil())
+				g.Expect(instance.Status.Orphans.LastSweepTime).ToNot(BeNil())
+				g.Expect(instance.Status.Orphans.Users).To(BeEmpty())
+				g.Expect(instance.Status.Orphans.Vhosts).To(BeEmpty())
+				g.Expect(instance.Status.Orphans.Policies).To(BeEmpty())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("a RabbitMQ gets created with additional plugins", func() {
+		BeforeEach(func() {
+			SetupMockRabbitMQAPI()
+			DeferCleanup(StopMockRabbitMQAPI)
+
+			spec := GetDefaultRabbitMQSpec()
+			spec["plugins"] = []string{"rabbitmq_shovel"}
+			spec["prometheusDetailedMetrics"] = true
+			rabbitmq := CreateRabbitMQ(rabbitmqName, spec)
+			DeferCleanup(th.DeleteInstance, rabbitmq)
+		})
+
+		It("should enable the plugins and the stable feature flags", func() {
+			SimulateRabbitMQClusterReady(rabbitmqName)
+
+			Eventually(func(g Gomega) {
+				cluster := GetRabbitMQCluster(rabbitmqName)
+				g.Expect(cluster.Spec.Rabbitmq.AdditionalPlugins).To(ContainElement(rabbitmqclusterv2.Plugin("rabbitmq_shovel")))
+				g.Expect(cluster.Spec.Rabbitmq.AdditionalConfig).To(ContainSubstring("prometheus.return_per_object_metrics = true"))
+			}, timeout, interval).Should(Succeed())
+
+			// The mock API reports the plugins of a single node running the same version
+			// as the single replica, so the stable feature flag gets enabled right away
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				g.Expect(instance.Status.EnabledPlugins).To(ContainElement("rabbitmq_shovel"))
+				g.Expect(instance.Status.PendingFeatureFlags).To(BeEmpty())
+				g.Expect(IsMockRabbitMQFeatureFlagEnabled("message_containers")).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+			Expect(IsMockRabbitMQFeatureFlagEnabled("khepri_db")).To(BeFalse())
+		})
+	})
+
+	When("shovels and federation upstreams run in a RabbitMQ", func() {
+		BeforeEach(func() {
+			SetupMockRabbitMQAPI()
+			DeferCleanup(StopMockRabbitMQAPI)
+
+			rabbitmq := CreateRabbitMQ(rabbitmqName, GetDefaultRabbitMQSpec())
+			DeferCleanup(th.DeleteInstance, rabbitmq)
+
+			shovel := CreateRabbitMQShovel(types.NamespacedName{Name: "plugin-shovel", Namespace: namespace}, map[string]any{
+				"source": map[string]any{
+					"rabbitmqClusterName": rabbitmqName.Name,
+					"queue":               "nova",
+				},
+				"destination": map[string]any{
+					"rabbitmqClusterName": rabbitmqName.Name,
+					"queue":               "nova-copy",
+				},
+			})
+			DeferCleanup(th.DeleteInstance, shovel)
+			upstream := CreateRabbitMQFederationUpstream(types.NamespacedName{Name: "plugin-upstream", Namespace: namespace}, map[string]any{
+				"rabbitmqClusterName": rabbitmqName.Name,
+				"upstream": map[string]any{
+					"rabbitmqClusterName": "plugin-upstream-cluster",
+				},
+			})
+			DeferCleanup(th.DeleteInstance, upstream)
+		})
+
+		It("should enable the shovel and federation plugins", func() {
+			Eventually(func(g Gomega) {
+				cluster := GetRabbitMQCluster(rabbitmqName)
+				g.Expect(cluster.Spec.Rabbitmq.AdditionalPlugins).To(ContainElements(
+					rabbitmqclusterv2.Plugin("rabbitmq_shovel"),
+					rabbitmqclusterv2.Plugin("rabbitmq_federation")))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("a RabbitMQ gets created with tuning settings", func() {
+		BeforeEach(func() {
+			spec := GetDefaultRabbitMQSpec()
+			spec["tuning"] = map[string]any{
+				"vmMemoryHighWatermark": "0.4",
+				"channelMax":            128,
+				"consumerTimeout":       "30m",
+			}
+			spec["rabbitmq"] = map[string]any{
+				"additionalConfig": "channel_max = 64\nlog.console = false",
+			}
+			rabbitmq := CreateRabbitMQ(rabbitmqName, spec)
+			DeferCleanup(th.DeleteInstance, rabbitmq)
+		})
+
+		It("should render the settings with a single line per key", func() {
+			Eventually(func(g Gomega) {
+				config := GetRabbitMQCluster(rabbitmqName).Spec.Rabbitmq.AdditionalConfig
+				g.Expect(config).To(ContainSubstring("vm_memory_high_watermark.relative = 0.4"))
+				g.Expect(config).ToNot(ContainSubstring("vm_memory_high_watermark.relative = 0.6"))
+				g.Expect(config).To(ContainSubstring("consumer_timeout = 1800000"))
+				// Keys of the raw additionalConfig take precedence over the defaults and the tuning
+				g.Expect(strings.Count(config, "channel_max")).To(Equal(1))
+				g.Expect(strings.Count(config, "log.console")).To(Equal(1))
+				g.Expect(config).To(HaveSuffix("channel_max = 64\nlog.console = false"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("a RabbitMQ gets created with streams", func() {
+		var transportURLName types.NamespacedName
+
+		BeforeEach(func() {
+			SetupMockRabbitMQAPI()
+			DeferCleanup(StopMockRabbitMQAPI)
+
+			spec := GetDefaultRabbitMQSpec()
+			spec["streams"] = map[string]any{
+				"vhosts":         []string{"/"},
+				"maxLengthBytes": "20Gi",
+				"maxAge":         "7D",
+			}
+			rabbitmq := CreateRabbitMQ(rabbitmqName, spec)
+			DeferCleanup(th.DeleteInstance, rabbitmq)
+
+			transportURLName = types.NamespacedName{Name: "streams-transporturl", Namespace: namespace}
+			DeferCleanup(th.DeleteInstance, CreateTransportURL(transportURLName, map[string]any{
+				"rabbitmqClusterName": rabbitmqName.Name,
+				"secretFormats":       []string{"structured", "oslo-config"},
+			}))
+		})
+
+		It("should enable the stream plugin and apply the retention policy", func() {
+			Eventually(func(g Gomega) {
+				cluster := GetRabbitMQCluster(rabbitmqName)
+				g.Expect(cluster.Spec.Rabbitmq.AdditionalPlugins).To(ContainElement(rabbitmqclusterv2.Plugin("rabbitmq_stream")))
+			}, timeout, interval).Should(Succeed())
+
+			SimulateRabbitMQClusterReady(rabbitmqName)
+
+			Eventually(func(g Gomega) {
+				policy := GetRabbitMQPolicy(types.NamespacedName{Name: rabbitmqName.Name + "-streams", Namespace: namespace})
+				g.Expect(policy.Spec.Name).To(Equal("streams"))
+				g.Expect(policy.Spec.ApplyTo).To(Equal("streams"))
+				g.Expect(policy.Spec.VhostRef).To(BeEmpty())
+				g.Expect(string(policy.Spec.Definition.Raw)).To(MatchJSON(`{"max-age":"7D","max-length-bytes":21474836480}`))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should create a stream aware transport URL secret", func() {
+			SimulateRabbitMQClusterReady(rabbitmqName)
+
+			Eventually(func(g Gomega) {
+				s := th.GetSecret(types.NamespacedName{Name: "rabbitmq-transport-url-" + transportURLName.Name, Namespace: namespace})
+				g.Expect(s.Data).To(HaveKeyWithValue("streams", []byte("true")))
+				g.Expect(s.Data).To(HaveKeyWithValue("stream_port", []byte("5552")))
+				g.Expect(string(s.Data["stream_urls"])).To(HavePrefix("rabbitmq-stream://"))
+				g.Expect(string(s.Data["oslo_messaging.conf"])).To(ContainSubstring(
+					"rabbit_stream_fanout = true\nrabbit_qos_prefetch_count = 100\n"))
+				g.Expect(s.Data).ToNot(HaveKey("notification_streams"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("a RabbitMQ gets created with streams for the notifications vhost", func() {
+		var transportURLName types.NamespacedName
+
+		BeforeEach(func() {
+			SetupMockRabbitMQAPI()
+			DeferCleanup(StopMockRabbitMQAPI)
+
+			spec := GetDefaultRabbitMQSpec()
+			spec["streams"] = map[string]any{
+				"vhosts": []string{"notifications"},
+			}
+			rabbitmq := CreateRabbitMQ(rabbitmqName, spec)
+			DeferCleanup(th.DeleteInstance, rabbitmq)
+
+			transportURLName = types.NamespacedName{Name: "streams-transporturl", Namespace: namespace}
+			DeferCleanup(th.DeleteInstance, CreateTransportURL(transportURLName, map[string]any{
+				"rabbitmqClusterName": rabbitmqName.Name,
+				"secretFormats":       []string{"structured", "oslo-config"},
+				"notifications": map[string]any{
+					"username": "notifier",
+					"vhost":    "notifications",
+				},
+			}))
+		})
+
+		It("should only make the notifications of the transport URL secret stream aware", func() {
+			SimulateRabbitMQClusterReady(rabbitmqName)
+			SimulateRabbitMQUserReady(types.NamespacedName{
+				Name:      transportURLName.Name + "-notifications-notifier-user",
+				Namespace: namespace,
+			}, "notifications")
+
+			Eventually(func(g Gomega) {
+				s := th.GetSecret(types.NamespacedName{Name: "rabbitmq-transport-url-" + transportURLName.Name, Namespace: namespace})
+				g.Expect(s.Data).To(HaveKey("notification_transport_url"))
+				g.Expect(s.Data).To(HaveKeyWithValue("notification_streams", []byte("true")))
+				g.Expect(string(s.Data["notification_stream_urls"])).To(And(
+					HavePrefix("rabbitmq-stream://notifier:"),
+					HaveSuffix(":5552/notifications")))
+				g.Expect(s.Data).ToNot(HaveKey("streams"))
+				g.Expect(s.Data).ToNot(HaveKey("stream_urls"))
+				osloConfig := string(s.Data["oslo_messaging.conf"])
+				g.Expect(osloConfig).ToNot(ContainSubstring("rabbit_stream_fanout"))
+				g.Expect(osloConfig).ToNot(ContainSubstring("rabbit_qos_prefetch_count"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("a RabbitMQ gets created with a node which is not running yet", func() {
+		BeforeEach(func() {
+			SetupMockRabbitMQAPI()
+			DeferCleanup(StopMockRabbitMQAPI)
+
+			spec := GetDefaultRabbitMQSpec()
+			spec["replicas"] = 2
+			rabbitmq := CreateRabbitMQ(rabbitmqName, spec)
+			DeferCleanup(th.DeleteInstance, rabbitmq)
+		})
+
+		It("should keep the stable feature flags pending", func() {
+			SimulateRabbitMQClusterReady(rabbitmqName)
+
+			// The mock API reports a single node for two replicas
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				g.Expect(instance.Status.PendingFeatureFlags).To(Equal([]string{"message_containers"}))
+			}, timeout, interval).Should(Succeed())
+			Consistently(func(g Gomega) {
+				g.Expect(IsMockRabbitMQFeatureFlagEnabled("message_containers")).To(BeFalse())
+			}, timeout/10, interval).Should(Succeed())
+		})
+	})
+
+	When("a RabbitMQ gets created and its health checks pass", func() {
+		BeforeEach(func() {
+			SetupMockRabbitMQAPI()
+			DeferCleanup(StopMockRabbitMQAPI)
+
+			spec := GetDefaultRabbitMQSpec()
+			spec["replicas"] = 3
+			rabbitmq := CreateRabbitMQ(rabbitmqName, spec)
+			DeferCleanup(th.DeleteInstance, rabbitmq)
+		})
+
+		It("should report the broker as healthy", func() {
+			SimulateRabbitMQClusterReady(rabbitmqName)
+
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				g.Expect(instance.Status.Health).ToNot(BeNil())
+				g.Expect(instance.Status.Health.LastCheckTime).ToNot(BeNil())
+				g.Expect(instance.Status.Health.Alarms).To(BeEmpty())
+				g.Expect(instance.Status.Conditions.IsTrue(rabbitmqv1.BrokerHealthyCondition)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+
+			// The local alarms and quorum critical queues are checked on every node
+			Expect(GetMockRabbitMQNodePods()).To(ContainElements(
+				rabbitmqName.Name+"-server-0", rabbitmqName.Name+"-server-1", rabbitmqName.Name+"-server-2"))
+		})
+	})
+
+	When("a RabbitMQ gets created with a memory alarm in effect", func() {
+		var transportURLName types.NamespacedName
+
+		BeforeEach(func() {
+			SetupMockRabbitMQAPI()
+			DeferCleanup(StopMockRabbitMQAPI)
+			SetMockRabbitMQAlarms(`[{"node":"rabbit@rabbitmq-server-0","resource":"memory"}]`)
+
+			rabbitmq := CreateRabbitMQ(rabbitmqName, GetDefaultRabbitMQSpec())
+			DeferCleanup(th.DeleteInstance, rabbitmq)
+
+			transportURLName = types.NamespacedName{Name: "alarm-transporturl", Namespace: namespace}
+			DeferCleanup(th.DeleteInstance, CreateTransportURL(transportURLName, map[string]any{
+				"rabbitmqClusterName": rabbitmqName.Name,
+			}))
+		})
+
+		It("should report the alarm in the RabbitMQ and the TransportURL", func() {
+			SimulateRabbitMQClusterReady(rabbitmqName)
+
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				g.Expect(instance.Status.Health).ToNot(BeNil())
+				g.Expect(instance.Status.Health.Alarms).To(Equal([]string{"rabbit@rabbitmq-server-0/memory"}))
+				g.Expect(instance.Status.Conditions.IsFalse(rabbitmqv1.BrokerHealthyCondition)).To(BeTrue())
+				g.Expect(instance.Status.Conditions.Get(rabbitmqv1.BrokerHealthyCondition).Message).To(
+					ContainSubstring("rabbit@rabbitmq-server-0/memory"))
+			}, timeout, interval).Should(Succeed())
+
+			// The alarm is informational, the deployment stays ready
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				g.Expect(instance.Status.Conditions.IsTrue(condition.DeploymentReadyCondition)).To(BeTrue())
+				g.Expect(instance.IsReady()).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				tr := th.GetTransportURL(transportURLName)
+				g.Expect(tr.Status.Conditions.IsFalse(rabbitmqv1.BrokerHealthyCondition)).To(BeTrue())
+				g.Expect(tr.Status.Conditions.IsTrue(condition.ReadyCondition)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("the QueueType of a RabbitMQ changes from Mirrored to Quorum", func() {
+		BeforeEach(func() {
+			SetupMockRabbitMQAPI()
+			DeferCleanup(StopMockRabbitMQAPI)
+
+			spec := GetDefaultRabbitMQSpec()
+			spec["queueType"] = "Quorum"
+			rabbitmq := CreateRabbitMQ(rabbitmqName, spec)
+			DeferCleanup(th.DeleteInstance, rabbitmq)
+
+			// Simulate a cluster which used mirrored queues before
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				instance.Status.QueueType = rabbitmqv1.QueueTypeMirrored
+				g.Expect(k8sClient.Status().Update(th.Ctx, instance)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should migrate the classic queues before switching to quorum queues", func() {
+			SetMockRabbitMQObject("/api/vhosts/%2F/connections", `[{"name":"nova-connection","vhost":"/","user":"nova"}]`)
+			SimulateRabbitMQClusterReady(rabbitmqName)
+
+			// The mock API returns one drained classic queue in the default vhost
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				g.Expect(instance.Status.QueueMigration).ToNot(BeNil())
+				g.Expect(instance.Status.QueueMigration.Phase).To(Equal(rabbitmqv1.QueueMigrationPhaseRescanning))
+				g.Expect(instance.Status.QueueMigration.SwitchTime).ToNot(BeNil())
+				g.Expect(instance.Status.QueueMigration.CompletionTime).To(BeNil())
+				vhost := instance.Status.QueueMigration.GetVhost("/")
+				g.Expect(vhost.Phase).To(Equal(rabbitmqv1.QueueMigrationPhaseCompleted))
+				g.Expect(vhost.Migrated).To(BeNumerically(">=", 1))
+				g.Expect(vhost.Pending).To(BeEmpty())
+				g.Expect(instance.Status.QueueType).To(Equal(rabbitmqv1.QueueTypeQuorum))
+			}, timeout, interval).Should(Succeed())
+
+			// Client connections are blocked while the queue gets replaced
+			Expect(GetMockRabbitMQRequests()).To(ContainElements(
+				"PUT /api/vhost-limits/%2F/max-connections",
+				"DELETE /api/connections/nova-connection",
+				"DELETE /api/queues/%2F/classic-queue",
+				"PUT /api/queues/%2F/classic-queue",
+				"DELETE /api/vhost-limits/%2F/max-connections",
+			))
+			requests := GetMockRabbitMQRequests()
+			blocked := slices.Index(requests, "PUT /api/vhost-limits/%2F/max-connections")
+			closed := slices.Index(requests, "DELETE /api/connections/nova-connection")
+			deleted := slices.Index(requests, "DELETE /api/queues/%2F/classic-queue")
+			recreated := slices.Index(requests, "PUT /api/queues/%2F/classic-queue")
+			unblocked := slices.Index(requests, "DELETE /api/vhost-limits/%2F/max-connections")
+			Expect(blocked).To(BeNumerically("<", closed))
+			Expect(closed).To(BeNumerically("<", deleted))
+			Expect(deleted).To(BeNumerically("<", recreated))
+			Expect(recreated).To(BeNumerically("<", unblocked))
+		})
+
+		It("should migrate classic queues declared again after the switch", func() {
+			SimulateRabbitMQClusterReady(rabbitmqName)
+
+			var migrated int32
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				g.Expect(instance.Status.QueueMigration.IsRescanning()).To(BeTrue())
+				migrated = instance.Status.QueueMigration.GetVhost("/").Migrated
+			}, timeout, interval).Should(Succeed())
+
+			// The mock API keeps returning the classic queue, as if a client which was not
+			// switched yet declared it again
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				g.Expect(instance.Status.QueueMigration.IsRescanning()).To(BeTrue())
+				g.Expect(instance.Status.QueueMigration.GetVhost("/").Migrated).To(BeNumerically(">", migrated))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("the ContainerImage of a RabbitMQ changes", func() {
+		BeforeEach(func() {
+			SetupMockRabbitMQAPI()
+			DeferCleanup(StopMockRabbitMQAPI)
+
+			// The mock API reports RabbitMQ 4.1.0 on the node
+			spec := GetDefaultRabbitMQSpec()
+			spec["containerImage"] = "quay.io/test/rabbitmq:4.1.0"
+			rabbitmq := CreateRabbitMQ(rabbitmqName, spec)
+			DeferCleanup(th.DeleteInstance, rabbitmq)
+
+			SimulateRabbitMQClusterReady(rabbitmqName)
+			Eventually(func(g Gomega) {
+				g.Expect(GetRabbitMQ(rabbitmqName).Status.PendingFeatureFlags).To(BeEmpty())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		updateImage := func(image string) {
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				instance.Spec.ContainerImage = image
+				g.Expect(k8sClient.Update(th.Ctx, instance)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			// The pods are kept on the previous image by the partition of the StatefulSet
+			Eventually(func(g Gomega) {
+				cluster := GetRabbitMQCluster(rabbitmqName)
+				g.Expect(cluster.Spec.Image).To(Equal(image))
+				updateStrategy := cluster.Spec.Override.StatefulSet.Spec.UpdateStrategy
+				g.Expect(updateStrategy).ToNot(BeNil())
+				g.Expect(updateStrategy.RollingUpdate.Partition).To(Equal(ptr.To[int32](1)))
+			}, timeout, interval).Should(Succeed())
+			SimulateRabbitMQClusterReady(rabbitmqName)
+		}
+
+		It("should start the rolling restart once the pre-flight checks pass", func() {
+			updateImage("quay.io/test/rabbitmq:4.2.0")
+
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				g.Expect(instance.Status.Upgrade).ToNot(BeNil())
+				g.Expect(instance.Status.Upgrade.Phase).To(Equal(rabbitmqv1.UpgradePhaseRollingRestart))
+				g.Expect(instance.Status.Upgrade.FromImage).To(Equal("quay.io/test/rabbitmq:4.1.0"))
+				g.Expect(instance.Status.Upgrade.FromVersion).To(Equal("4.1.0"))
+				g.Expect(instance.Status.Upgrade.ToVersion).To(Equal("4.2.0"))
+				g.Expect(instance.Status.Upgrade.Nodes).To(Equal([]rabbitmqv1.RabbitMqNodeVersion{
+					{Name: "rabbit@rabbitmq-server-0", Version: "4.1.0"},
+				}))
+				g.Expect(instance.Status.Conditions.IsFalse(rabbitmqv1.UpgradeReadyCondition)).To(BeTrue())
+				// The upgrade doesn't affect the readiness of the deployment
+				g.Expect(instance.Status.Conditions.IsTrue(condition.ReadyCondition)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should keep the new image once a node got restarted and resume the upgrade with the annotation", func() {
+			updateImage("quay.io/test/rabbitmq:4.2.0")
+
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				g.Expect(instance.Status.Upgrade).ToNot(BeNil())
+				g.Expect(instance.Status.Upgrade.Partition).To(Equal(int32(0)))
+			}, timeout, interval).Should(Succeed())
+
+			// Restoring the previous image would roll back the restarted node
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				instance.Spec.ContainerImage = "quay.io/test/rabbitmq:4.1.0"
+				g.Expect(k8sClient.Update(th.Ctx, instance)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				g.Expect(instance.Status.Upgrade).ToNot(BeNil())
+				g.Expect(instance.Status.Upgrade.Phase).To(Equal(rabbitmqv1.UpgradePhasePaused))
+				g.Expect(instance.Status.Upgrade.Message).To(ContainSubstring(
+					"restoring quay.io/test/rabbitmq:4.1.0 is not supported once nodes got restarted"))
+				g.Expect(instance.Status.Conditions.IsFalse(rabbitmqv1.UpgradeReadyCondition)).To(BeTrue())
+				// A paused upgrade makes the deployment not ready
+				g.Expect(instance.Status.Conditions.IsFalse(condition.ReadyCondition)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+			Consistently(func(g Gomega) {
+				cluster := GetRabbitMQCluster(rabbitmqName)
+				g.Expect(cluster.Spec.Image).To(Equal("quay.io/test/rabbitmq:4.2.0"))
+				g.Expect(cluster.Spec.Override.StatefulSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(ptr.To[int32](0)))
+			}, timeout/10, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				instance.Spec.ContainerImage = "quay.io/test/rabbitmq:4.2.0"
+				if instance.Annotations == nil {
+					instance.Annotations = map[string]string{}
+				}
+				instance.Annotations[rabbitmqv1.UpgradeResumeAnnotation] = ""
+				g.Expect(k8sClient.Update(th.Ctx, instance)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				g.Expect(instance.Annotations).ToNot(HaveKey(rabbitmqv1.UpgradeResumeAnnotation))
+				g.Expect(instance.Status.Upgrade).ToNot(BeNil())
+				g.Expect(instance.Status.Upgrade.Phase).To(Equal(rabbitmqv1.UpgradePhaseRollingRestart))
+				g.Expect(instance.Status.Upgrade.Message).To(BeEmpty())
+				g.Expect(instance.Status.Conditions.IsTrue(condition.ReadyCondition)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should pause the upgrade to an unsupported version", func() {
+			updateImage("quay.io/test/rabbitmq:4.3.0")
+
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				g.Expect(instance.Status.Upgrade).ToNot(BeNil())
+				g.Expect(instance.Status.Upgrade.Phase).To(Equal(rabbitmqv1.UpgradePhasePaused))
+				g.Expect(instance.Status.Upgrade.Message).To(ContainSubstring("from 4.1.0 to 4.3.0 is not supported"))
+				g.Expect(instance.Status.Conditions.IsFalse(rabbitmqv1.UpgradeReadyCondition)).To(BeTrue())
+				readyCondition := instance.Status.Conditions.Get(condition.ReadyCondition)
+				g.Expect(readyCondition).ToNot(BeNil())
+				g.Expect(readyCondition.Status).To(Equal(corev1.ConditionFalse))
+				g.Expect(readyCondition.Severity).To(Equal(condition.SeverityWarning))
+			}, timeout, interval).Should(Succeed())
+			Consistently(func(g Gomega) {
+				cluster := GetRabbitMQCluster(rabbitmqName)
+				g.Expect(cluster.Spec.Override.StatefulSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(ptr.To[int32](1)))
+			}, timeout/10, interval).Should(Succeed())
+
+			// Restoring the previous image cancels the upgrade
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				instance.Spec.ContainerImage = "quay.io/test/rabbitmq:4.1.0"
+				g.Expect(k8sClient.Update(th.Ctx, instance)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+			Eventually(func(g Gomega) {
+				g.Expect(GetRabbitMQ(rabbitmqName).Status.Upgrade).To(BeNil())
+				g.Expect(GetRabbitMQCluster(rabbitmqName).Spec.Override.StatefulSet.Spec.UpdateStrategy).To(BeNil())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("the replicas of a RabbitMQ get reduced", func() {
+		BeforeEach(func() {
+			SetupMockRabbitMQAPI()
+			DeferCleanup(StopMockRabbitMQAPI)
+
+			spec := GetDefaultRabbitMQSpec()
+			spec["replicas"] = 3
+			rabbitmq := CreateRabbitMQ(rabbitmqName, spec)
+			DeferCleanup(th.DeleteInstance, rabbitmq)
+		})
+
+		scaleDown := func(replicas int32) {
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				instance.Spec.Replicas = &replicas
+				g.Expect(k8sClient.Update(th.Ctx, instance)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+		}
+
+		// name of the RabbitMQ node in the pod with the ordinal
+		nodeName := func(ordinal int) string {
+			return fmt.Sprintf("rabbit@%s-server-%d.%s-nodes.%s", rabbitmqName.Name, ordinal, rabbitmqName.Name, namespace)
+		}
+
+		It("should remove the quorum queue members on the departing nodes before reducing the replicas", func() {
+			SetMockRabbitMQQueues(fmt.Sprintf(`[{"name":"nova","vhost":"/","type":"quorum","members":["%s","%s","%s"],"online":["%s","%s","%s"]}]`,
+				nodeName(0), nodeName(1), nodeName(2), nodeName(0), nodeName(1), nodeName(2)))
+
+			SimulateRabbitMQClusterReady(rabbitmqName)
+			Eventually(func(g Gomega) {
+				g.Expect(*GetRabbitMQCluster(rabbitmqName).Spec.Replicas).To(Equal(int32(3)))
+			}, timeout, interval).Should(Succeed())
+
+			scaleDown(1)
+
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				g.Expect(instance.Status.ScaleDown).To(BeNil())
+				g.Expect(instance.Status.Conditions.IsTrue(rabbitmqv1.ScaleDownReadyCondition)).To(BeTrue())
+				g.Expect(*GetRabbitMQCluster(rabbitmqName).Spec.Replicas).To(Equal(int32(1)))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should forget the departed nodes so the feature flags get enabled", func() {
+			// The nodes of the departing pods are stopped, they stay members of the cluster
+			// until they are forgotten
+			SetMockRabbitMQObject("/api/nodes", fmt.Sprintf(`[`+
+				`{"name":"%s","running":true,"applications":[{"name":"rabbit","version":"4.1.0"}]},`+
+				`{"name":"%s","running":false,"applications":[]},`+
+				`{"name":"%s","running":false,"applications":[]}]`,
+				nodeName(0), nodeName(1), nodeName(2)))
+
+			SimulateRabbitMQClusterReady(rabbitmqName)
+			Eventually(func(g Gomega) {
+				g.Expect(GetRabbitMQ(rabbitmqName).Status.PendingFeatureFlags).To(Equal([]string{"message_containers"}))
+			}, timeout, interval).Should(Succeed())
+			Expect(IsMockRabbitMQFeatureFlagEnabled("message_containers")).To(BeFalse())
+
+			scaleDown(1)
+
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				g.Expect(instance.Status.ScaleDown).To(BeNil())
+				g.Expect(instance.Status.Conditions.IsTrue(rabbitmqv1.ScaleDownReadyCondition)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+			Expect(GetMockRabbitMQCommands()).To(Equal([]string{
+				fmt.Sprintf("%s-server-0: rabbitmqctl forget_cluster_node %s", rabbitmqName.Name, nodeName(1)),
+				fmt.Sprintf("%s-server-0: rabbitmqctl forget_cluster_node %s", rabbitmqName.Name, nodeName(2)),
+			}))
+
+			// Only the remaining node is left, which runs the version of the cluster
+			Eventually(func(g Gomega) {
+				g.Expect(GetRabbitMQ(rabbitmqName).Status.PendingFeatureFlags).To(BeEmpty())
+				g.Expect(IsMockRabbitMQFeatureFlagEnabled("message_containers")).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should keep the replicas while quorum queues would lose their majority", func() {
+			// The only member remaining after the scale down is offline
+			SetMockRabbitMQQueues(fmt.Sprintf(`[{"name":"nova","vhost":"/","type":"quorum","members":["%s","%s","%s"],"online":["%s","%s"]}]`,
+				nodeName(0), nodeName(1), nodeName(2), nodeName(1), nodeName(2)))
+
+			SimulateRabbitMQClusterReady(rabbitmqName)
+			Eventually(func(g Gomega) {
+				g.Expect(*GetRabbitMQCluster(rabbitmqName).Spec.Replicas).To(Equal(int32(3)))
+			}, timeout, interval).Should(Succeed())
+
+			scaleDown(1)
+
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				g.Expect(instance.Status.ScaleDown).ToNot(BeNil())
+				g.Expect(instance.Status.ScaleDown.Replicas).To(Equal(int32(3)))
+				g.Expect(instance.Status.ScaleDown.TargetReplicas).To(Equal(int32(1)))
+				g.Expect(instance.Status.ScaleDown.Phase).To(Equal(rabbitmqv1.ScaleDownPhaseShrinkingQueues))
+				g.Expect(instance.Status.ScaleDown.ShrunkNodes).To(BeEmpty())
+				scaleDownCondition := instance.Status.Conditions.Get(rabbitmqv1.ScaleDownReadyCondition)
+				g.Expect(scaleDownCondition).ToNot(BeNil())
+				g.Expect(scaleDownCondition.Status).To(Equal(corev1.ConditionFalse))
+				g.Expect(scaleDownCondition.Message).To(Equal(
+					fmt.Sprintf(rabbitmqv1.ScaleDownQuorumAtRiskMessage, "//nova")))
+			}, timeout, interval).Should(Succeed())
+
+			Consistently(func(g Gomega) {
+				g.Expect(*GetRabbitMQCluster(rabbitmqName).Spec.Replicas).To(Equal(int32(3)))
+			}, "3s", interval).Should(Succeed())
+		})
+	})
+
+	When("the replicas of a RabbitMQ with per-pod services get reduced", func() {
+		BeforeEach(func() {
+			SetupMockRabbitMQAPI()
+			DeferCleanup(StopMockRabbitMQAPI)
+
+			services := []map[string]any{}
+			for i := 0; i < 3; i++ {
+				services = append(services, map[string]any{
+					"metadata": map[string]any{
+						"annotations": map[string]any{
+							"metallb.universe.tf/loadBalancerIPs": fmt.Sprintf("192.0.2.1%d", i+1),
+						},
+					},
+					"spec": map[string]any{
+						"type": string(corev1.ServiceTypeClusterIP),
+					},
+				})
+			}
+			spec := GetDefaultRabbitMQSpec()
+			spec["replicas"] = 3
+			spec["podOverride"] = map[string]any{"services": services}
+			rabbitmq := CreateRabbitMQ(rabbitmqName, spec)
+			DeferCleanup(th.DeleteInstance, rabbitmq)
+		})
+
+		It("should scale down without reducing the services and delete the services of the departed pods", func() {
+			SimulateRabbitMQClusterReady(rabbitmqName)
+			Eventually(func(g Gomega) {
+				g.Expect(GetRabbitMQ(rabbitmqName).Status.ServiceHostnames).To(HaveLen(3))
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				instance.Spec.Replicas = ptr.To[int32](1)
+				g.Expect(k8sClient.Update(th.Ctx, instance)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				instance := GetRabbitMQ(rabbitmqName)
+				g.Expect(instance.Status.ScaleDown).To(BeNil())
+				g.Expect(instance.Status.Conditions.IsTrue(rabbitmqv1.ScaleDownReadyCondition)).To(BeTrue())
+				g.Expect(instance.Status.ServiceHostnames).To(HaveLen(1))
+				g.Expect(*GetRabbitMQCluster(rabbitmqName).Spec.Replicas).To(Equal(int32(1)))
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				svc := &corev1.Service{}
+				g.Expect(k8sClient.Get(ctx, types.NamespacedName{Name: rabbitmqName.Name + "-server-0", Namespace: namespace}, svc)).Should(Succeed())
+				for _, i := range []int{1, 2} {
+					err := k8sClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("%s-server-%d", rabbitmqName.Name, i), Namespace: namespace}, svc)
+					g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+				}
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
 	When("RabbitMQ gets created with TLS enabled", func() {
 		var certSecret *corev1.Secret
 		BeforeEach(func() {
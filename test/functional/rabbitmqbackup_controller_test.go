@@ -0,0 +1,235 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functional_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive
+	. "github.com/onsi/gomega"    //nolint:revive
+	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("RabbitMQBackup controller", func() {
+	var clusterName types.NamespacedName
+	var backupName types.NamespacedName
+	var restoreName types.NamespacedName
+
+	BeforeEach(func() {
+		clusterName = types.NamespacedName{Name: "rabbitmq-backup", Namespace: namespace}
+		backupName = types.NamespacedName{Name: "test-backup", Namespace: namespace}
+		restoreName = types.NamespacedName{Name: "test-restore", Namespace: namespace}
+
+		// Set up mock RabbitMQ Management API so controller can make API calls
+		SetupMockRabbitMQAPI()
+		DeferCleanup(StopMockRabbitMQAPI)
+
+		CreateRabbitMQCluster(clusterName, GetDefaultRabbitMQClusterSpec(false))
+		SimulateRabbitMQClusterReady(clusterName)
+		DeferCleanup(DeleteRabbitMQCluster, clusterName)
+	})
+
+	When("a RabbitMQBackup stored in Secrets is created", func() {
+		BeforeEach(func() {
+			backup := CreateRabbitMQBackup(backupName, map[string]any{
+				"rabbitmqClusterName": clusterName.Name,
+			})
+			DeferCleanup(th.DeleteInstance, backup)
+		})
+
+		It("should store the definitions in a Secret and become ready", func() {
+			Eventually(func(g Gomega) {
+				b := GetRabbitMQBackup(backupName)
+				g.Expect(b.Status.Conditions.IsTrue(rabbitmqv1.RabbitMQBackupReadyCondition)).To(BeTrue())
+				g.Expect(b.Status.Conditions.IsTrue(condition.ReadyCondition)).To(BeTrue())
+				g.Expect(b.Status.Backups).To(HaveLen(1))
+				g.Expect(b.Status.LastBackupTime).NotTo(BeNil())
+				g.Expect(b.Status.NextBackupTime).To(BeNil())
+			}, timeout, interval).Should(Succeed())
+
+			b := GetRabbitMQBackup(backupName)
+			secret := th.GetSecret(types.NamespacedName{Name: b.Status.Backups[0].Name, Namespace: namespace})
+			Expect(string(secret.Data[rabbitmqv1.RabbitMQBackupDefinitionsKey])).To(Equal(mockRabbitMQDefinitions))
+			Expect(secret.Labels).To(HaveKeyWithValue("rabbitmqbackup.openstack.org/name", backupName.Name))
+			Expect(secret.OwnerReferences).To(HaveLen(1))
+			Expect(secret.OwnerReferences[0].Name).To(Equal(backupName.Name))
+		})
+
+		It("should reject changing the storage", func() {
+			Eventually(func(g Gomega) {
+				b := GetRabbitMQBackup(backupName)
+				b.Spec.Storage.Type = rabbitmqv1.RabbitMQBackupStoragePVC
+				b.Spec.Storage.ClaimName = "backups"
+				err := th.K8sClient.Update(th.Ctx, b)
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(k8s_errors.IsInvalid(err)).To(BeTrue())
+				g.Expect(err.Error()).To(ContainSubstring("storage cannot be changed after creation"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should restore the definitions of a single vhost", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(GetRabbitMQBackup(backupName).Status.LastBackupTime).NotTo(BeNil())
+			}, timeout, interval).Should(Succeed())
+
+			restore := CreateRabbitMQRestore(restoreName, map[string]any{
+				"rabbitmqClusterName": clusterName.Name,
+				"backupRef":           backupName.Name,
+				"vhosts":              []string{"nova"},
+			})
+			DeferCleanup(th.DeleteInstance, restore)
+
+			Eventually(func(g Gomega) {
+				r := GetRabbitMQRestore(restoreName)
+				g.Expect(r.Status.Conditions.IsTrue(rabbitmqv1.RabbitMQRestoreReadyCondition)).To(BeTrue())
+				g.Expect(r.Status.Conditions.IsTrue(condition.ReadyCondition)).To(BeTrue())
+				g.Expect(r.Status.RestoredBackup).To(Equal(GetRabbitMQBackup(backupName).Status.Backups[0].Name))
+				g.Expect(r.Status.CompletionTime).NotTo(BeNil())
+			}, timeout, interval).Should(Succeed())
+
+			imported := map[string][]map[string]any{}
+			Expect(json.Unmarshal([]byte(GetMockRabbitMQImportedDefinitions()), &imported)).To(Succeed())
+			Expect(imported["vhosts"]).To(ConsistOf(HaveKeyWithValue("name", "nova")))
+			Expect(imported["users"]).To(ConsistOf(HaveKeyWithValue("name", "nova")))
+			Expect(imported["queues"]).To(ConsistOf(HaveKeyWithValue("name", "scheduler")))
+		})
+
+		It("should fail to restore a backup which does not exist", func() {
+			restore := CreateRabbitMQRestore(restoreName, map[string]any{
+				"rabbitmqClusterName": clusterName.Name,
+				"backupRef":           backupName.Name,
+				"backup":              "test-backup-20000101000000",
+			})
+			DeferCleanup(th.DeleteInstance, restore)
+
+			Eventually(func(g Gomega) {
+				r := GetRabbitMQRestore(restoreName)
+				c := r.Status.Conditions.Get(rabbitmqv1.RabbitMQRestoreReadyCondition)
+				g.Expect(c).NotTo(BeNil())
+				g.Expect(c.Reason).To(Equal(condition.ErrorReason))
+				g.Expect(c.Message).To(ContainSubstring("backup test-backup-20000101000000 not found"))
+			}, timeout, interval).Should(Succeed())
+			Expect(GetMockRabbitMQImportedDefinitions()).To(BeEmpty())
+		})
+	})
+
+	When("a RabbitMQBackup stored on a PersistentVolumeClaim is created", func() {
+		BeforeEach(func() {
+			backup := CreateRabbitMQBackup(backupName, map[string]any{
+				"rabbitmqClusterName": clusterName.Name,
+				"storage": map[string]any{
+					"type":      "PVC",
+					"claimName": "rabbitmq-backups",
+				},
+				"retention": 3,
+			})
+			DeferCleanup(th.DeleteInstance, backup)
+		})
+
+		It("should write the backup with a Job and wait for it", func() {
+			var backup string
+			Eventually(func(g Gomega) {
+				b := GetRabbitMQBackup(backupName)
+				g.Expect(b.Status.Backups).To(HaveLen(1))
+				g.Expect(b.Status.LastBackupTime).To(BeNil())
+				g.Expect(b.Status.Conditions.IsFalse(rabbitmqv1.RabbitMQBackupReadyCondition)).To(BeTrue())
+				backup = b.Status.Backups[0].Name
+			}, timeout, interval).Should(Succeed())
+
+			job := &batchv1.Job{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: backup, Namespace: namespace}, job)).To(Succeed())
+			volumes := job.Spec.Template.Spec.Volumes
+			Expect(volumes).To(ContainElement(HaveField("VolumeSource.PersistentVolumeClaim.ClaimName", "rabbitmq-backups")))
+			Expect(volumes).To(ContainElement(HaveField("VolumeSource.Secret.SecretName", backup)))
+			Expect(job.Spec.Template.Spec.Containers[0].Command[2]).To(ContainSubstring("tail -n +4"))
+
+			// The definitions are passed to the Job in a Secret owned by the Job
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: backup, Namespace: namespace}, secret)).To(Succeed())
+			Expect(secret.OwnerReferences).To(HaveLen(1))
+			Expect(secret.OwnerReferences[0].Kind).To(Equal("Job"))
+		})
+
+		It("should read the backup with a Job writing a Secret and keep the default user", func() {
+			var backup string
+			Eventually(func(g Gomega) {
+				b := GetRabbitMQBackup(backupName)
+				g.Expect(b.Status.Backups).To(HaveLen(1))
+				backup = b.Status.Backups[0].Name
+			}, timeout, interval).Should(Succeed())
+			SimulateBackupJobComplete(types.NamespacedName{Name: backup, Namespace: namespace})
+			Eventually(func(g Gomega) {
+				g.Expect(GetRabbitMQBackup(backupName).Status.LastBackupTime).NotTo(BeNil())
+			}, timeout, interval).Should(Succeed())
+
+			restore := CreateRabbitMQRestore(restoreName, map[string]any{
+				"rabbitmqClusterName": clusterName.Name,
+				"backupRef":           backupName.Name,
+			})
+			DeferCleanup(th.DeleteInstance, restore)
+
+			// The Job may only patch the Secret owned by the restore
+			jobName := types.NamespacedName{Name: restoreName.Name + "-restore", Namespace: namespace}
+			job := &batchv1.Job{}
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, jobName, job)).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+			Expect(job.Spec.Template.Spec.ServiceAccountName).To(Equal("rabbitmqrestore-" + restoreName.Name))
+			Expect(job.Spec.Template.Spec.Containers[0].Command[2]).To(ContainSubstring(backup + ".json"))
+			Expect(job.Spec.Template.Spec.Containers[0].Command[2]).To(ContainSubstring("/secrets/" + jobName.Name))
+
+			role := &rbacv1.Role{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "rabbitmqrestore-" + restoreName.Name + "-role", Namespace: namespace}, role)).To(Succeed())
+			Expect(role.Rules).To(ContainElement(And(
+				HaveField("Resources", ConsistOf("secrets")),
+				HaveField("ResourceNames", ConsistOf(jobName.Name)),
+				HaveField("Verbs", ConsistOf("get", "patch")))))
+
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, jobName, secret)).To(Succeed())
+			Expect(secret.OwnerReferences).To(HaveLen(1))
+			Expect(secret.OwnerReferences[0].Kind).To(Equal("RabbitMQRestore"))
+
+			// The backup was taken on a cluster with a default user of the same name
+			secret.Data = map[string][]byte{
+				rabbitmqv1.RabbitMQBackupDefinitionsKey: []byte(`{"users":[{"name":"user","tags":["administrator"]},{"name":"nova","tags":[]}],` +
+					`"vhosts":[{"name":"nova"}],` +
+					`"permissions":[{"user":"user","vhost":"nova","configure":".*","write":".*","read":".*"},` +
+					`{"user":"nova","vhost":"nova","configure":".*","write":".*","read":".*"}]}`),
+			}
+			Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+			SimulateBackupJobComplete(jobName)
+
+			Eventually(func(g Gomega) {
+				r := GetRabbitMQRestore(restoreName)
+				g.Expect(r.Status.Conditions.IsTrue(condition.ReadyCondition)).To(BeTrue())
+				g.Expect(r.Status.RestoredBackup).To(Equal(backup))
+			}, timeout, interval).Should(Succeed())
+
+			imported := map[string][]map[string]any{}
+			Expect(json.Unmarshal([]byte(GetMockRabbitMQImportedDefinitions()), &imported)).To(Succeed())
+			Expect(imported["users"]).To(ConsistOf(HaveKeyWithValue("name", "nova")))
+			Expect(imported["permissions"]).To(ConsistOf(HaveKeyWithValue("user", "nova")))
+		})
+	})
+})
@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functional_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //nolint:revive
+	. "github.com/onsi/gomega"    //nolint:revive
+	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("RabbitMQBinding controller", func() {
+	var rabbitmqClusterName types.NamespacedName
+	var bindingName types.NamespacedName
+
+	BeforeEach(func() {
+		rabbitmqClusterName = types.NamespacedName{Name: "rabbitmq-binding-mock", Namespace: namespace}
+		bindingName = types.NamespacedName{Name: "test-binding", Namespace: namespace}
+
+		// Set up mock RabbitMQ Management API so controller can make API calls
+		SetupMockRabbitMQAPI()
+		DeferCleanup(StopMockRabbitMQAPI)
+
+		CreateRabbitMQCluster(rabbitmqClusterName, GetDefaultRabbitMQClusterSpec(false))
+		SimulateRabbitMQClusterReady(rabbitmqClusterName)
+		DeferCleanup(DeleteRabbitMQCluster, rabbitmqClusterName)
+	})
+
+	When("a RabbitMQBinding is created", func() {
+		BeforeEach(func() {
+			binding := CreateRabbitMQBinding(bindingName, map[string]any{
+				"rabbitmqClusterName": rabbitmqClusterName.Name,
+				"source":              "notifications",
+				"destination":         "notifications.info",
+				"routingKey":          "notifications.info",
+			})
+			DeferCleanup(th.DeleteInstance, binding)
+		})
+
+		It("should default the destination type", func() {
+			binding := GetRabbitMQBinding(bindingName)
+			Expect(binding.Spec.DestinationType).To(Equal("queue"))
+		})
+
+		It("should create the binding via RabbitMQ Management API and become ready", func() {
+			Eventually(func(g Gomega) {
+				b := GetRabbitMQBinding(bindingName)
+				g.Expect(b.Status.Conditions.IsTrue(rabbitmqv1.RabbitMQBindingReadyCondition)).To(BeTrue())
+				g.Expect(b.Status.Conditions.IsTrue(condition.ReadyCondition)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should reject changing the routing key", func() {
+			Eventually(func(g Gomega) {
+				b := GetRabbitMQBinding(bindingName)
+				b.Spec.RoutingKey = "notifications.error"
+				err := th.K8sClient.Update(th.Ctx, b)
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(k8s_errors.IsInvalid(err)).To(BeTrue())
+				g.Expect(err.Error()).To(ContainSubstring("routingKey cannot be changed after creation"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functional_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //nolint:revive
+	. "github.com/onsi/gomega"    //nolint:revive
+	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("RabbitMQExchange controller", func() {
+	var rabbitmqClusterName types.NamespacedName
+	var exchangeName types.NamespacedName
+
+	BeforeEach(func() {
+		rabbitmqClusterName = types.NamespacedName{Name: "rabbitmq-exchange-mock", Namespace: namespace}
+		exchangeName = types.NamespacedName{Name: "test-exchange", Namespace: namespace}
+
+		// Set up mock RabbitMQ Management API so controller can make API calls
+		SetupMockRabbitMQAPI()
+		DeferCleanup(StopMockRabbitMQAPI)
+
+		CreateRabbitMQCluster(rabbitmqClusterName, GetDefaultRabbitMQClusterSpec(false))
+		SimulateRabbitMQClusterReady(rabbitmqClusterName)
+		DeferCleanup(DeleteRabbitMQCluster, rabbitmqClusterName)
+	})
+
+	When("a RabbitMQExchange is created", func() {
+		BeforeEach(func() {
+			exchange := CreateRabbitMQExchange(exchangeName, map[string]any{
+				"rabbitmqClusterName": rabbitmqClusterName.Name,
+				"type":                "topic",
+			})
+			DeferCleanup(th.DeleteInstance, exchange)
+		})
+
+		It("should default the exchange name", func() {
+			exchange := GetRabbitMQExchange(exchangeName)
+			Expect(exchange.Spec.Name).To(Equal(exchangeName.Name))
+			Expect(exchange.Spec.Type).To(Equal("topic"))
+			Expect(exchange.Spec.Durable).To(BeTrue())
+		})
+
+		It("should create the exchange via RabbitMQ Management API and become ready", func() {
+			Eventually(func(g Gomega) {
+				e := GetRabbitMQExchange(exchangeName)
+				g.Expect(e.Status.Conditions.IsTrue(rabbitmqv1.RabbitMQExchangeReadyCondition)).To(BeTrue())
+				g.Expect(e.Status.Conditions.IsTrue(condition.ReadyCondition)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should reject changing the exchange type", func() {
+			Eventually(func(g Gomega) {
+				e := GetRabbitMQExchange(exchangeName)
+				e.Spec.Type = "fanout"
+				err := th.K8sClient.Update(th.Ctx, e)
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(k8s_errors.IsInvalid(err)).To(BeTrue())
+				g.Expect(err.Error()).To(ContainSubstring("exchange type cannot be changed after creation"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+})
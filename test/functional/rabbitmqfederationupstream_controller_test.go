@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functional_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //nolint:revive
+	. "github.com/onsi/gomega"    //nolint:revive
+	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("RabbitMQFederationUpstream controller", func() {
+	var downstreamClusterName types.NamespacedName
+	var upstreamClusterName types.NamespacedName
+	var federationUpstreamName types.NamespacedName
+
+	BeforeEach(func() {
+		downstreamClusterName = types.NamespacedName{Name: "rabbitmq-federation-down", Namespace: namespace}
+		upstreamClusterName = types.NamespacedName{Name: "rabbitmq-federation-up", Namespace: namespace}
+		federationUpstreamName = types.NamespacedName{Name: "test-upstream", Namespace: namespace}
+
+		// Set up mock RabbitMQ Management API so controller can make API calls
+		SetupMockRabbitMQAPI()
+		DeferCleanup(StopMockRabbitMQAPI)
+
+		for _, name := range []types.NamespacedName{downstreamClusterName, upstreamClusterName} {
+			CreateRabbitMQCluster(name, GetDefaultRabbitMQClusterSpec(false))
+			SimulateRabbitMQClusterReady(name)
+			DeferCleanup(DeleteRabbitMQCluster, name)
+		}
+	})
+
+	When("a RabbitMQFederationUpstream is created", func() {
+		BeforeEach(func() {
+			upstream := CreateRabbitMQFederationUpstream(federationUpstreamName, map[string]any{
+				"rabbitmqClusterName": downstreamClusterName.Name,
+				"upstream": map[string]any{
+					"rabbitmqClusterName": upstreamClusterName.Name,
+				},
+				"maxHops": 1,
+			})
+			DeferCleanup(th.DeleteInstance, upstream)
+		})
+
+		It("should default the upstream name and ack mode", func() {
+			upstream := GetRabbitMQFederationUpstream(federationUpstreamName)
+			Expect(upstream.Spec.Name).To(Equal(federationUpstreamName.Name))
+			Expect(upstream.Spec.AckMode).To(Equal("on-confirm"))
+		})
+
+		It("should create the upstream via RabbitMQ Management API and become ready", func() {
+			Eventually(func(g Gomega) {
+				u := GetRabbitMQFederationUpstream(federationUpstreamName)
+				g.Expect(u.Status.Conditions.IsTrue(rabbitmqv1.RabbitMQFederationUpstreamReadyCondition)).To(BeTrue())
+				g.Expect(u.Status.Conditions.IsTrue(condition.ReadyCondition)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should connect to the upstream with a dedicated user", func() {
+			Eventually(func(g Gomega) {
+				user := GetRabbitMQUser(types.NamespacedName{Name: federationUpstreamName.Name + "-federation-user", Namespace: namespace})
+				g.Expect(user.Spec.RabbitmqClusterName).To(Equal(upstreamClusterName.Name))
+				g.Expect(user.Spec.Username).To(Equal("federation-test-upstream"))
+				g.Expect(user.Spec.Permissions.Configure).To(Equal("^(federation: .*)$"))
+				g.Expect(user.Spec.Permissions.Write).To(Equal("^(federation: .*)$"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should reject changing the downstream cluster", func() {
+			Eventually(func(g Gomega) {
+				u := GetRabbitMQFederationUpstream(federationUpstreamName)
+				u.Spec.RabbitmqClusterName = upstreamClusterName.Name
+				err := th.K8sClient.Update(th.Ctx, u)
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(k8s_errors.IsInvalid(err)).To(BeTrue())
+				g.Expect(err.Error()).To(ContainSubstring("rabbitmqClusterName cannot be changed after creation"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	It("should reject an upstream pointing to the downstream cluster and vhost", func() {
+		Expect(th.K8sClient.Create(th.Ctx, &rabbitmqv1.RabbitMQFederationUpstream{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      federationUpstreamName.Name,
+				Namespace: federationUpstreamName.Namespace,
+			},
+			Spec: rabbitmqv1.RabbitMQFederationUpstreamSpec{
+				RabbitmqClusterName: downstreamClusterName.Name,
+				Upstream: rabbitmqv1.RabbitMQFederationUpstreamCluster{
+					RabbitmqClusterName: downstreamClusterName.Name,
+				},
+			},
+		})).To(MatchError(ContainSubstring("upstream must be a different cluster or vhost than the downstream")))
+	})
+})
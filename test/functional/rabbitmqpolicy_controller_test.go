@@ -23,6 +23,7 @@ import (
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	rabbitmqclusterv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 )
 
 var _ = Describe("RabbitMQPolicy controller", func() {
@@ -190,6 +191,39 @@ var _ = Describe("RabbitMQPolicy controller", func() {
 				g.Expect(p.Status.Conditions.IsTrue(condition.ReadyCondition)).To(BeTrue())
 			}, "3s", interval).Should(Succeed())
 		})
+
+		It("should only report drift when remediation is disabled", func() {
+			Eventually(func(g Gomega) {
+				p := GetRabbitMQPolicy(mockPolicyName)
+				g.Expect(p.Status.Conditions.IsTrue(rabbitmqv1.RabbitMQPolicyReadyCondition)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+
+			// The spec change gets applied, afterwards the mock API doesn't return
+			// the policy, which is reported as drift instead of being restored
+			Eventually(func(g Gomega) {
+				p := GetRabbitMQPolicy(mockPolicyName)
+				p.Spec.DriftDetection = &rabbitmqv1.DriftDetection{Remediate: ptr.To(false)}
+				g.Expect(th.K8sClient.Update(th.Ctx, p)).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				p := GetRabbitMQPolicy(mockPolicyName)
+				g.Expect(p.Status.LastAppliedHash).NotTo(BeEmpty())
+				if p.Labels == nil {
+					p.Labels = make(map[string]string)
+				}
+				p.Labels["test-reconcile"] = "trigger"
+				g.Expect(th.K8sClient.Update(th.Ctx, p)).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				p := GetRabbitMQPolicy(mockPolicyName)
+				g.Expect(p.Status.Conditions.IsTrue(rabbitmqv1.DriftDetectedCondition)).To(BeTrue())
+				g.Expect(p.Status.Conditions.Get(rabbitmqv1.DriftDetectedCondition).Message).To(ContainSubstring("policy does not exist"))
+				g.Expect(p.Status.Conditions.IsFalse(rabbitmqv1.RabbitMQPolicyReadyCondition)).To(BeTrue())
+				g.Expect(p.Status.Conditions.IsFalse(condition.ReadyCondition)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
 	})
 
 	When("a RabbitMQPolicy is deleted while cluster is being deleted", func() {
@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functional_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //nolint:revive
+	. "github.com/onsi/gomega"    //nolint:revive
+	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("RabbitMQQueue controller", func() {
+	var rabbitmqClusterName types.NamespacedName
+	var queueName types.NamespacedName
+
+	BeforeEach(func() {
+		rabbitmqClusterName = types.NamespacedName{Name: "rabbitmq-queue-mock", Namespace: namespace}
+		queueName = types.NamespacedName{Name: "test-queue", Namespace: namespace}
+
+		// Set up mock RabbitMQ Management API so controller can make API calls
+		SetupMockRabbitMQAPI()
+		DeferCleanup(StopMockRabbitMQAPI)
+
+		CreateRabbitMQCluster(rabbitmqClusterName, GetDefaultRabbitMQClusterSpec(false))
+		SimulateRabbitMQClusterReady(rabbitmqClusterName)
+		DeferCleanup(DeleteRabbitMQCluster, rabbitmqClusterName)
+	})
+
+	When("a RabbitMQQueue is created", func() {
+		BeforeEach(func() {
+			queue := CreateRabbitMQQueue(queueName, map[string]any{
+				"rabbitmqClusterName": rabbitmqClusterName.Name,
+				"type":                "quorum",
+				"arguments": map[string]any{
+					"x-max-length": 10000,
+				},
+			})
+			DeferCleanup(th.DeleteInstance, queue)
+		})
+
+		It("should default the queue name and durable flag", func() {
+			queue := GetRabbitMQQueue(queueName)
+			Expect(queue.Spec.Name).To(Equal(queueName.Name))
+			Expect(queue.Spec.Durable).To(BeTrue())
+			Expect(queue.Spec.ForceDelete).To(BeFalse())
+		})
+
+		It("should create the queue via RabbitMQ Management API and become ready", func() {
+			Eventually(func(g Gomega) {
+				q := GetRabbitMQQueue(queueName)
+				g.Expect(q.Status.Conditions.IsTrue(rabbitmqv1.RabbitMQQueueReadyCondition)).To(BeTrue())
+				g.Expect(q.Status.Conditions.IsTrue(condition.ReadyCondition)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should reject changing the queue type", func() {
+			Eventually(func(g Gomega) {
+				q := GetRabbitMQQueue(queueName)
+				q.Spec.Type = "classic"
+				err := th.K8sClient.Update(th.Ctx, q)
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(k8s_errors.IsInvalid(err)).To(BeTrue())
+				g.Expect(err.Error()).To(ContainSubstring("queue type cannot be changed after creation"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should allow changing forceDelete", func() {
+			Eventually(func(g Gomega) {
+				q := GetRabbitMQQueue(queueName)
+				q.Spec.ForceDelete = true
+				g.Expect(th.K8sClient.Update(th.Ctx, q)).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should delete the empty queue from RabbitMQ", func() {
+			Eventually(func(g Gomega) {
+				q := GetRabbitMQQueue(queueName)
+				g.Expect(q.Status.Conditions.IsTrue(rabbitmqv1.RabbitMQQueueReadyCondition)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+
+			Expect(th.K8sClient.Delete(th.Ctx, GetRabbitMQQueue(queueName))).To(Succeed())
+
+			Eventually(func(g Gomega) {
+				q := &rabbitmqv1.RabbitMQQueue{}
+				err := th.K8sClient.Get(th.Ctx, queueName, q)
+				g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("a RabbitMQQueue uses a reserved name", func() {
+		It("should be rejected by the webhook", func() {
+			raw := map[string]any{
+				"apiVersion": "rabbitmq.openstack.org/v1beta1",
+				"kind":       "RabbitMQQueue",
+				"metadata": map[string]any{
+					"name":      queueName.Name,
+					"namespace": queueName.Namespace,
+				},
+				"spec": map[string]any{
+					"rabbitmqClusterName": rabbitmqClusterName.Name,
+					"name":                "amq.test",
+				},
+			}
+			unstructuredObj := &unstructured.Unstructured{Object: raw}
+			err := th.K8sClient.Create(th.Ctx, unstructuredObj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must not start with the reserved prefix"))
+		})
+	})
+})
@@ -0,0 +1,133 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functional_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //nolint:revive
+	. "github.com/onsi/gomega"    //nolint:revive
+	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("RabbitMQShovel controller", func() {
+	var sourceClusterName types.NamespacedName
+	var destClusterName types.NamespacedName
+	var shovelName types.NamespacedName
+
+	BeforeEach(func() {
+		sourceClusterName = types.NamespacedName{Name: "rabbitmq-shovel-src", Namespace: namespace}
+		destClusterName = types.NamespacedName{Name: "rabbitmq-shovel-dest", Namespace: namespace}
+		shovelName = types.NamespacedName{Name: "test-shovel", Namespace: namespace}
+
+		// Set up mock RabbitMQ Management API so controller can make API calls
+		SetupMockRabbitMQAPI()
+		DeferCleanup(StopMockRabbitMQAPI)
+
+		for _, name := range []types.NamespacedName{sourceClusterName, destClusterName} {
+			CreateRabbitMQCluster(name, GetDefaultRabbitMQClusterSpec(false))
+			SimulateRabbitMQClusterReady(name)
+			DeferCleanup(DeleteRabbitMQCluster, name)
+		}
+	})
+
+	When("a RabbitMQShovel is created", func() {
+		BeforeEach(func() {
+			shovel := CreateRabbitMQShovel(shovelName, map[string]any{
+				"source": map[string]any{
+					"rabbitmqClusterName": sourceClusterName.Name,
+					"queue":               "nova",
+				},
+				"destination": map[string]any{
+					"rabbitmqClusterName": destClusterName.Name,
+					"queue":               "nova",
+				},
+			})
+			DeferCleanup(th.DeleteInstance, shovel)
+		})
+
+		It("should default the shovel name and ack mode", func() {
+			shovel := GetRabbitMQShovel(shovelName)
+			Expect(shovel.Spec.Name).To(Equal(shovelName.Name))
+			Expect(shovel.Spec.AckMode).To(Equal("on-confirm"))
+			Expect(shovel.Spec.DeleteAfter).To(Equal("never"))
+		})
+
+		It("should create the shovel via RabbitMQ Management API and become ready", func() {
+			Eventually(func(g Gomega) {
+				s := GetRabbitMQShovel(shovelName)
+				g.Expect(s.Status.Conditions.IsTrue(rabbitmqv1.RabbitMQShovelReadyCondition)).To(BeTrue())
+				g.Expect(s.Status.Conditions.IsTrue(condition.ReadyCondition)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should connect with dedicated users restricted to the shovel queues", func() {
+			srcUserName := types.NamespacedName{Name: shovelName.Name + "-shovel-source-user", Namespace: namespace}
+			destUserName := types.NamespacedName{Name: shovelName.Name + "-shovel-destination-user", Namespace: namespace}
+			Eventually(func(g Gomega) {
+				src := GetRabbitMQUser(srcUserName)
+				g.Expect(src.Spec.RabbitmqClusterName).To(Equal(sourceClusterName.Name))
+				g.Expect(src.Spec.Username).To(Equal("shovel-test-shovel-source"))
+				g.Expect(src.Spec.Permissions.Read).To(Equal(`^(amq\.gen-.*|nova)$`))
+				g.Expect(src.Spec.Permissions.Write).To(Equal(`^(amq\.gen-.*)$`))
+				g.Expect(src.OwnerReferences).To(HaveLen(1))
+				g.Expect(src.OwnerReferences[0].Name).To(Equal(shovelName.Name))
+
+				dest := GetRabbitMQUser(destUserName)
+				g.Expect(dest.Spec.RabbitmqClusterName).To(Equal(destClusterName.Name))
+				g.Expect(dest.Spec.Permissions.Configure).To(Equal("^(nova)$"))
+				g.Expect(dest.Spec.Permissions.Write).To(Equal(`^(amq\.default|nova)$`))
+				g.Expect(dest.Spec.Permissions.Read).To(Equal("^$"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should reject changing the source cluster", func() {
+			Eventually(func(g Gomega) {
+				s := GetRabbitMQShovel(shovelName)
+				s.Spec.Source.RabbitmqClusterName = destClusterName.Name
+				err := th.K8sClient.Update(th.Ctx, s)
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(k8s_errors.IsInvalid(err)).To(BeTrue())
+				g.Expect(err.Error()).To(ContainSubstring("source cluster cannot be changed after creation"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("a RabbitMQShovel references a cluster which does not exist", func() {
+		BeforeEach(func() {
+			shovel := CreateRabbitMQShovel(shovelName, map[string]any{
+				"source": map[string]any{
+					"rabbitmqClusterName": sourceClusterName.Name,
+					"queue":               "nova",
+				},
+				"destination": map[string]any{
+					"rabbitmqClusterName": "missing",
+					"queue":               "nova",
+				},
+			})
+			DeferCleanup(th.DeleteInstance, shovel)
+		})
+
+		It("should not become ready", func() {
+			Consistently(func(g Gomega) {
+				s := GetRabbitMQShovel(shovelName)
+				g.Expect(s.Status.Conditions.IsTrue(rabbitmqv1.RabbitMQShovelReadyCondition)).To(BeFalse())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+})
@@ -18,13 +18,17 @@ package functional_test
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2" //nolint:revive
 	. "github.com/onsi/gomega"    //nolint:revive
 	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
+	rabbitmqapi "github.com/openstack-k8s-operators/infra-operator/pkg/rabbitmq/api"
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	rabbitmqclusterv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
@@ -126,6 +130,66 @@ var _ = Describe("RabbitMQUser controller", func() {
 		})
 	})
 
+	When("a RabbitMQUser with topic permissions and limits is created", func() {
+		BeforeEach(func() {
+			spec := map[string]any{
+				"rabbitmqClusterName": rabbitmqClusterName.Name,
+				"vhostRef":            vhostName.Name,
+				"topicPermissions": []map[string]any{
+					{
+						"exchange": "amq.topic",
+						"write":    "^notifications\\.",
+					},
+				},
+				"limits": map[string]any{
+					"maxConnections": 100,
+					"maxChannels":    200,
+				},
+			}
+			user := CreateRabbitMQUser(userName, spec)
+			DeferCleanup(th.DeleteInstance, user)
+		})
+
+		It("should have topic permissions and limits in spec", func() {
+			user := GetRabbitMQUser(userName)
+			Expect(user.Spec.TopicPermissions).To(HaveLen(1))
+			Expect(user.Spec.TopicPermissions[0].Exchange).To(Equal("amq.topic"))
+			Expect(user.Spec.TopicPermissions[0].Write).To(Equal("^notifications\\."))
+			Expect(user.Spec.TopicPermissions[0].Read).To(Equal(".*"))
+			Expect(*user.Spec.Limits.MaxConnections).To(Equal(int64(100)))
+			Expect(*user.Spec.Limits.MaxChannels).To(Equal(int64(200)))
+		})
+	})
+
+	When("a RabbitMQUser has an invalid topic permission regex", func() {
+		It("should reject creation with validation error", func() {
+			spec := map[string]any{
+				"rabbitmqClusterName": rabbitmqClusterName.Name,
+				"vhostRef":            vhostName.Name,
+				"topicPermissions": []map[string]any{
+					{
+						"exchange": "amq.topic",
+						"read":     "[invalid",
+					},
+				},
+			}
+			raw := map[string]any{
+				"apiVersion": "rabbitmq.openstack.org/v1beta1",
+				"kind":       "RabbitMQUser",
+				"metadata": map[string]any{
+					"name":      "bad-topic-user",
+					"namespace": namespace,
+				},
+				"spec": spec,
+			}
+
+			unstructuredObj := &unstructured.Unstructured{Object: raw}
+			err := th.K8sClient.Create(th.Ctx, unstructuredObj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.topicPermissions[0].read"))
+		})
+	})
+
 	When("a RabbitMQUser references non-existent vhost", func() {
 		It("should reject creation with validation error", func() {
 			userWithBadVhost := types.NamespacedName{Name: "bad-vhost-user", Namespace: namespace}
@@ -1578,6 +1642,366 @@ var _ = Describe("RabbitMQUser controller", func() {
 		})
 	})
 
+	When("a RabbitMQUser which already exists in RabbitMQ is adopted", func() {
+		var mockClusterName types.NamespacedName
+		var mockVhostName types.NamespacedName
+		var adoptedUserName types.NamespacedName
+		var newUserName types.NamespacedName
+
+		BeforeEach(func() {
+			mockClusterName = types.NamespacedName{Name: "rabbitmq-user-adoption", Namespace: namespace}
+			mockVhostName = types.NamespacedName{Name: "vhost-user-adoption", Namespace: namespace}
+			adoptedUserName = types.NamespacedName{Name: "user-adopted", Namespace: namespace}
+			newUserName = types.NamespacedName{Name: "user-not-adopted", Namespace: namespace}
+
+			SetupMockRabbitMQAPI()
+			DeferCleanup(StopMockRabbitMQAPI)
+
+			CreateRabbitMQCluster(mockClusterName, GetDefaultRabbitMQClusterSpec(false))
+			SimulateRabbitMQClusterReady(mockClusterName)
+			DeferCleanup(DeleteRabbitMQCluster, mockClusterName)
+
+			vhost := CreateRabbitMQVhost(mockVhostName, map[string]any{
+				"rabbitmqClusterName": mockClusterName.Name,
+				"name":                "adoption-vhost",
+			})
+			DeferCleanup(th.DeleteInstance, vhost)
+			SimulateRabbitMQVhostReady(mockVhostName)
+
+			// The mock API returns the existing-user only
+			SetMockRabbitMQObject("/api/users/existing-user",
+				`{"name":"existing-user","password_hash":"","tags":[]}`)
+
+			user := CreateRabbitMQUser(adoptedUserName, map[string]any{
+				"rabbitmqClusterName": mockClusterName.Name,
+				"vhostRef":            mockVhostName.Name,
+				"username":            "existing-user",
+				"adoption":            map[string]any{"enabled": true},
+			})
+			DeferCleanup(th.DeleteInstance, user)
+
+			user = CreateRabbitMQUser(newUserName, map[string]any{
+				"rabbitmqClusterName": mockClusterName.Name,
+				"vhostRef":            mockVhostName.Name,
+				"username":            "new-user",
+				"adoption":            map[string]any{"enabled": true},
+			})
+			DeferCleanup(th.DeleteInstance, user)
+		})
+
+		It("should record in the status whether the user was adopted", func() {
+			Eventually(func(g Gomega) {
+				u := GetRabbitMQUser(adoptedUserName)
+				g.Expect(u.Status.Conditions.IsTrue(rabbitmqv1.RabbitMQUserReadyCondition)).To(BeTrue())
+				g.Expect(u.Status.Adopted).To(HaveValue(BeTrue()))
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				u := GetRabbitMQUser(newUserName)
+				g.Expect(u.Status.Conditions.IsTrue(rabbitmqv1.RabbitMQUserReadyCondition)).To(BeTrue())
+				g.Expect(u.Status.Adopted).To(HaveValue(BeFalse()))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should keep the adopted user in RabbitMQ when deleted", func() {
+			Eventually(func(g Gomega) {
+				u := GetRabbitMQUser(adoptedUserName)
+				g.Expect(u.Status.Adopted).To(HaveValue(BeTrue()))
+			}, timeout, interval).Should(Succeed())
+
+			// DeleteInstance waits until the finalizer is removed without deleting the user in RabbitMQ
+			th.DeleteInstance(GetRabbitMQUser(adoptedUserName))
+			Expect(GetMockRabbitMQRequests()).NotTo(ContainElement("DELETE /api/users/existing-user"))
+
+			// The user created by the operator gets deleted
+			Eventually(func(g Gomega) {
+				u := GetRabbitMQUser(newUserName)
+				g.Expect(u.Status.Adopted).To(HaveValue(BeFalse()))
+			}, timeout, interval).Should(Succeed())
+			th.DeleteInstance(GetRabbitMQUser(newUserName))
+			Expect(GetMockRabbitMQRequests()).To(ContainElement("DELETE /api/users/new-user"))
+		})
+	})
+
+	When("a RabbitMQUser with drift detection is changed in RabbitMQ", func() {
+		var mockClusterName types.NamespacedName
+		var mockVhostName types.NamespacedName
+		var mockUserName types.NamespacedName
+
+		// setUser sets the user and its permissions the mock API returns
+		setUser := func(password string, tags string, configure string) {
+			SetMockRabbitMQObject("/api/users/drift-user", fmt.Sprintf(
+				`{"name":"drift-user","password_hash":%q,"hashing_algorithm":"rabbit_password_hashing_sha256","tags":%s}`,
+				MockRabbitMQPasswordHash(password), tags))
+			SetMockRabbitMQObject("/api/permissions/drift-vhost/drift-user", fmt.Sprintf(
+				`{"user":"drift-user","vhost":"drift-vhost","configure":%q,"write":".*","read":".*"}`, configure))
+		}
+
+		// expectDrift waits until the drift is reported instead of being remediated
+		expectDrift := func(drift string) {
+			Eventually(func(g Gomega) {
+				u := GetRabbitMQUser(mockUserName)
+				g.Expect(u.Status.Conditions.IsTrue(rabbitmqv1.DriftDetectedCondition)).To(BeTrue())
+				g.Expect(u.Status.Conditions.Get(rabbitmqv1.DriftDetectedCondition).Message).To(ContainSubstring(drift))
+				g.Expect(u.Status.Conditions.IsFalse(rabbitmqv1.RabbitMQUserReadyCondition)).To(BeTrue())
+				g.Expect(u.Status.Conditions.IsFalse(condition.ReadyCondition)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		}
+
+		BeforeEach(func() {
+			mockClusterName = types.NamespacedName{Name: "rabbitmq-user-drift", Namespace: namespace}
+			mockVhostName = types.NamespacedName{Name: "vhost-user-drift", Namespace: namespace}
+			mockUserName = types.NamespacedName{Name: "user-drift", Namespace: namespace}
+
+			SetupMockRabbitMQAPI()
+			DeferCleanup(StopMockRabbitMQAPI)
+
+			CreateRabbitMQCluster(mockClusterName, GetDefaultRabbitMQClusterSpec(false))
+			SimulateRabbitMQClusterReady(mockClusterName)
+			DeferCleanup(DeleteRabbitMQCluster, mockClusterName)
+
+			vhost := CreateRabbitMQVhost(mockVhostName, map[string]any{
+				"rabbitmqClusterName": mockClusterName.Name,
+				"name":                "drift-vhost",
+			})
+			DeferCleanup(th.DeleteInstance, vhost)
+			SimulateRabbitMQVhostReady(mockVhostName)
+
+			credentialSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "user-drift-credentials",
+					Namespace: namespace,
+				},
+				Data: map[string][]byte{
+					"username": []byte("drift-user"),
+					"password": []byte("drift-password"),
+				},
+			}
+			Expect(th.K8sClient.Create(th.Ctx, credentialSecret)).To(Succeed())
+			DeferCleanup(th.K8sClient.Delete, th.Ctx, credentialSecret)
+
+			// RabbitMQ matches the spec until the tests change it
+			setUser("drift-password", "[]", ".*")
+
+			user := CreateRabbitMQUser(mockUserName, map[string]any{
+				"rabbitmqClusterName": mockClusterName.Name,
+				"vhostRef":            mockVhostName.Name,
+				"secret":              credentialSecret.Name,
+				"driftDetection": map[string]any{
+					"interval":  "1s",
+					"remediate": false,
+				},
+			})
+			DeferCleanup(th.DeleteInstance, user)
+
+			Eventually(func(g Gomega) {
+				u := GetRabbitMQUser(mockUserName)
+				g.Expect(u.Status.LastAppliedHash).NotTo(BeEmpty())
+				g.Expect(u.Status.Conditions.IsTrue(rabbitmqv1.RabbitMQUserReadyCondition)).To(BeTrue())
+				g.Expect(u.Status.Conditions.Has(rabbitmqv1.DriftDetectedCondition)).To(BeFalse())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should report a changed password", func() {
+			setUser("changed-password", "[]", ".*")
+			expectDrift("password")
+
+			// The drift is cleared once RabbitMQ matches the spec again
+			setUser("drift-password", "[]", ".*")
+			Eventually(func(g Gomega) {
+				u := GetRabbitMQUser(mockUserName)
+				g.Expect(u.Status.Conditions.Has(rabbitmqv1.DriftDetectedCondition)).To(BeFalse())
+				g.Expect(u.Status.Conditions.IsTrue(rabbitmqv1.RabbitMQUserReadyCondition)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should report changed tags", func() {
+			setUser("drift-password", `["administrator"]`, ".*")
+			expectDrift("tags")
+		})
+
+		It("should report changed permissions", func() {
+			setUser("drift-password", "[]", "^$")
+			expectDrift("configure permission")
+		})
+
+		It("should report a user deleted in RabbitMQ", func() {
+			SetMockRabbitMQObject("/api/users/drift-user", "")
+			expectDrift("user does not exist")
+		})
+
+		It("should report changed limits and topic permissions", func() {
+			SetMockRabbitMQObject("/api/user-limits/drift-user", `[{"user":"drift-user","value":{"max-connections":10}}]`)
+			expectDrift("limit max-connections")
+
+			SetMockRabbitMQObject("/api/user-limits/drift-user", "")
+			SetMockRabbitMQObject("/api/topic-permissions/drift-vhost/drift-user",
+				`[{"user":"drift-user","vhost":"drift-vhost","exchange":"amq.topic","write":".*","read":".*"}]`)
+			expectDrift("topic permissions amq.topic")
+		})
+
+		It("should not change the limits and topic permissions while they match the spec", func() {
+			Consistently(func(g Gomega) {
+				for _, request := range GetMockRabbitMQRequests() {
+					g.Expect(request).NotTo(HavePrefix("PUT /api/user-limits/"))
+					g.Expect(request).NotTo(HavePrefix("DELETE /api/user-limits/"))
+					g.Expect(request).NotTo(HavePrefix("PUT /api/topic-permissions/"))
+					g.Expect(request).NotTo(HavePrefix("DELETE /api/topic-permissions/"))
+				}
+			}, "3s", interval).Should(Succeed())
+		})
+	})
+
+	When("a RabbitMQUser with hashed credentials is created", func() {
+		var mockClusterName types.NamespacedName
+		var mockUserName types.NamespacedName
+
+		BeforeEach(func() {
+			mockClusterName = types.NamespacedName{Name: "rabbitmq-user-hashed", Namespace: namespace}
+			mockUserName = types.NamespacedName{Name: "user-hashed", Namespace: namespace}
+
+			SetupMockRabbitMQAPI()
+			DeferCleanup(StopMockRabbitMQAPI)
+
+			CreateRabbitMQCluster(mockClusterName, GetDefaultRabbitMQClusterSpec(false))
+			SimulateRabbitMQClusterReady(mockClusterName)
+			DeferCleanup(DeleteRabbitMQCluster, mockClusterName)
+
+			// Created with "rabbitmqctl hash_password"
+			hashSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "user-hashed-password",
+					Namespace: namespace,
+				},
+				Data: map[string][]byte{
+					"password_hash": []byte("xwsDU1aX43iUzadPvDvBwEBLlxSrE6Spaw/BXOdnzYT2ehu2"),
+				},
+			}
+			Expect(th.K8sClient.Create(th.Ctx, hashSecret)).To(Succeed())
+			DeferCleanup(th.K8sClient.Delete, th.Ctx, hashSecret)
+
+			user := CreateRabbitMQUser(mockUserName, map[string]any{
+				"rabbitmqClusterName": mockClusterName.Name,
+				"hashedCredentials": map[string]any{
+					"secret": "user-hashed-password",
+				},
+			})
+			DeferCleanup(th.DeleteInstance, user)
+		})
+
+		It("should create the user with the password hash and become ready", func() {
+			Eventually(func(g Gomega) {
+				u := GetRabbitMQUser(mockUserName)
+				g.Expect(u.Spec.HashedCredentials.HashingAlgorithm).To(Equal(rabbitmqapi.HashingAlgorithmSHA256))
+				g.Expect(u.Status.SecretName).To(Equal("user-hashed-password"))
+				g.Expect(u.Status.Conditions.IsTrue(rabbitmqv1.RabbitMQUserReadyCondition)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+
+			// No secret with a generated password gets created
+			secret := &corev1.Secret{}
+			err := th.K8sClient.Get(th.Ctx, types.NamespacedName{
+				Name:      fmt.Sprintf("rabbitmq-user-%s", mockUserName.Name),
+				Namespace: namespace,
+			}, secret)
+			Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	When("a RabbitMQUser with an external secret is created", func() {
+		var mockClusterName types.NamespacedName
+		var mockUserName types.NamespacedName
+
+		BeforeEach(func() {
+			mockClusterName = types.NamespacedName{Name: "rabbitmq-user-external", Namespace: namespace}
+			mockUserName = types.NamespacedName{Name: "user-external", Namespace: namespace}
+
+			SetupMockRabbitMQAPI()
+			DeferCleanup(StopMockRabbitMQAPI)
+
+			CreateRabbitMQCluster(mockClusterName, GetDefaultRabbitMQClusterSpec(false))
+			SimulateRabbitMQClusterReady(mockClusterName)
+			DeferCleanup(DeleteRabbitMQCluster, mockClusterName)
+
+			passwordPath := filepath.Join(userSecretsDir, namespace, "user-external")
+			Expect(os.MkdirAll(filepath.Dir(passwordPath), 0o700)).To(Succeed())
+			Expect(os.WriteFile(passwordPath, []byte("external-password\n"), 0o600)).To(Succeed())
+			DeferCleanup(os.Remove, passwordPath)
+
+			user := CreateRabbitMQUser(mockUserName, map[string]any{
+				"rabbitmqClusterName": mockClusterName.Name,
+				"externalSecret": map[string]any{
+					"path": namespace + "/user-external",
+				},
+			})
+			DeferCleanup(th.DeleteInstance, user)
+		})
+
+		It("should create the user with the password of the provider and become ready", func() {
+			Eventually(func(g Gomega) {
+				u := GetRabbitMQUser(mockUserName)
+				g.Expect(u.Spec.ExternalSecret.Provider).To(Equal(rabbitmqv1.ExternalSecretProviderFile))
+				g.Expect(u.Status.Username).To(Equal(mockUserName.Name))
+				g.Expect(u.Status.SecretName).To(BeEmpty())
+				g.Expect(u.Status.Conditions.IsTrue(rabbitmqv1.RabbitMQUserReadyCondition)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("a RabbitMQUser has invalid password sources", func() {
+		createUser := func(name string, spec map[string]any) error {
+			spec["rabbitmqClusterName"] = rabbitmqClusterName.Name
+			raw := map[string]any{
+				"apiVersion": "rabbitmq.openstack.org/v1beta1",
+				"kind":       "RabbitMQUser",
+				"metadata": map[string]any{
+					"name":      name,
+					"namespace": namespace,
+				},
+				"spec": spec,
+			}
+			return th.K8sClient.Create(th.Ctx, &unstructured.Unstructured{Object: raw})
+		}
+
+		BeforeEach(func() {
+			hashSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "invalid-password-hash",
+					Namespace: namespace,
+				},
+				Data: map[string][]byte{
+					"password_hash": []byte("not a hash"),
+				},
+			}
+			Expect(th.K8sClient.Create(th.Ctx, hashSecret)).To(Succeed())
+			DeferCleanup(th.K8sClient.Delete, th.Ctx, hashSecret)
+		})
+
+		It("should reject more than one password source", func() {
+			err := createUser("multiple-sources-user", map[string]any{
+				"hashedCredentials": map[string]any{"secret": "invalid-password-hash"},
+				"externalSecret":    map[string]any{"path": "user"},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("only one of secret, hashedCredentials and externalSecret can be set"))
+		})
+
+		It("should reject an invalid password hash", func() {
+			err := createUser("invalid-hash-user", map[string]any{
+				"hashedCredentials": map[string]any{"secret": "invalid-password-hash"},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid password hash"))
+		})
+
+		It("should reject an external secret path outside of the secrets directory", func() {
+			err := createUser("invalid-path-user", map[string]any{
+				"externalSecret": map[string]any{"path": "../other/password"},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.externalSecret.path"))
+		})
+	})
+
 	When("RabbitMQ cluster is deleted and recreated", func() {
 		var recreateClusterName types.NamespacedName
 		var recreateVhostName types.NamespacedName
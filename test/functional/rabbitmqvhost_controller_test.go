@@ -22,6 +22,7 @@ import (
 	rabbitmqv1 "github.com/openstack-k8s-operators/infra-operator/apis/rabbitmq/v1beta1"
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	rabbitmqclusterv2 "github.com/rabbitmq/cluster-operator/v2/api/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -438,6 +439,212 @@ var _ = Describe("RabbitMQVhost controller", func() {
 		})
 	})
 
+	When("a RabbitMQVhost with limits and default queue type is created with mock RabbitMQ API", func() {
+		var mockClusterName types.NamespacedName
+		var mockVhostName types.NamespacedName
+
+		BeforeEach(func() {
+			mockClusterName = types.NamespacedName{Name: "rabbitmq-vhost-settings", Namespace: namespace}
+			mockVhostName = types.NamespacedName{Name: "vhost-settings-test", Namespace: namespace}
+
+			SetupMockRabbitMQAPI()
+			DeferCleanup(StopMockRabbitMQAPI)
+
+			CreateRabbitMQCluster(mockClusterName, GetDefaultRabbitMQClusterSpec(false))
+			SimulateRabbitMQClusterReady(mockClusterName)
+			DeferCleanup(DeleteRabbitMQCluster, mockClusterName)
+
+			vhost := CreateRabbitMQVhost(mockVhostName, map[string]any{
+				"rabbitmqClusterName": mockClusterName.Name,
+				"name":                "nova",
+				"description":         "nova vhost",
+				"tags":                []string{"openstack"},
+				"defaultQueueType":    "quorum",
+				"limits": map[string]any{
+					"maxConnections": 100,
+					"maxQueues":      500,
+				},
+			})
+			DeferCleanup(th.DeleteInstance, vhost)
+		})
+
+		It("should apply the settings and become ready", func() {
+			Eventually(func(g Gomega) {
+				v := GetRabbitMQVhost(mockVhostName)
+				g.Expect(v.Spec.DefaultQueueType).To(Equal("quorum"))
+				g.Expect(*v.Spec.Limits.MaxQueues).To(Equal(int64(500)))
+				g.Expect(v.Status.Conditions.IsTrue(rabbitmqv1.RabbitMQVhostReadyCondition)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("a RabbitMQVhost with drift detection is changed in RabbitMQ", func() {
+		var mockClusterName types.NamespacedName
+		var mockVhostName types.NamespacedName
+
+		// expectDrift waits until the drift is reported instead of being remediated
+		expectDrift := func(drift string) {
+			Eventually(func(g Gomega) {
+				v := GetRabbitMQVhost(mockVhostName)
+				g.Expect(v.Status.Conditions.IsTrue(rabbitmqv1.DriftDetectedCondition)).To(BeTrue())
+				g.Expect(v.Status.Conditions.Get(rabbitmqv1.DriftDetectedCondition).Message).To(ContainSubstring(drift))
+				g.Expect(v.Status.Conditions.IsFalse(rabbitmqv1.RabbitMQVhostReadyCondition)).To(BeTrue())
+				g.Expect(v.Status.Conditions.IsFalse(condition.ReadyCondition)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		}
+
+		BeforeEach(func() {
+			mockClusterName = types.NamespacedName{Name: "rabbitmq-vhost-drift", Namespace: namespace}
+			mockVhostName = types.NamespacedName{Name: "vhost-drift", Namespace: namespace}
+
+			SetupMockRabbitMQAPI()
+			DeferCleanup(StopMockRabbitMQAPI)
+
+			CreateRabbitMQCluster(mockClusterName, GetDefaultRabbitMQClusterSpec(false))
+			SimulateRabbitMQClusterReady(mockClusterName)
+			DeferCleanup(DeleteRabbitMQCluster, mockClusterName)
+
+			// RabbitMQ matches the spec until the tests change it
+			SetMockRabbitMQObject("/api/vhosts/drift-vhost",
+				`{"name":"drift-vhost","description":"drift vhost","tags":["openstack"]}`)
+			SetMockRabbitMQObject("/api/vhost-limits/drift-vhost",
+				`[{"vhost":"drift-vhost","value":{"max-queues":500}}]`)
+
+			vhost := CreateRabbitMQVhost(mockVhostName, map[string]any{
+				"rabbitmqClusterName": mockClusterName.Name,
+				"name":                "drift-vhost",
+				"description":         "drift vhost",
+				"tags":                []string{"openstack"},
+				"limits": map[string]any{
+					"maxQueues": 500,
+				},
+				"driftDetection": map[string]any{
+					"interval":  "1s",
+					"remediate": false,
+				},
+			})
+			DeferCleanup(th.DeleteInstance, vhost)
+
+			Eventually(func(g Gomega) {
+				v := GetRabbitMQVhost(mockVhostName)
+				g.Expect(v.Status.LastAppliedHash).NotTo(BeEmpty())
+				g.Expect(v.Status.Conditions.IsTrue(rabbitmqv1.RabbitMQVhostReadyCondition)).To(BeTrue())
+				g.Expect(v.Status.Conditions.Has(rabbitmqv1.DriftDetectedCondition)).To(BeFalse())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should report changed metadata", func() {
+			SetMockRabbitMQObject("/api/vhosts/drift-vhost",
+				`{"name":"drift-vhost","description":"changed","tags":["openstack"]}`)
+			expectDrift("description")
+
+			// The drift is cleared once RabbitMQ matches the spec again
+			SetMockRabbitMQObject("/api/vhosts/drift-vhost",
+				`{"name":"drift-vhost","description":"drift vhost","tags":["openstack"]}`)
+			Eventually(func(g Gomega) {
+				v := GetRabbitMQVhost(mockVhostName)
+				g.Expect(v.Status.Conditions.Has(rabbitmqv1.DriftDetectedCondition)).To(BeFalse())
+				g.Expect(v.Status.Conditions.IsTrue(rabbitmqv1.RabbitMQVhostReadyCondition)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should report changed and additional limits", func() {
+			SetMockRabbitMQObject("/api/vhost-limits/drift-vhost",
+				`[{"vhost":"drift-vhost","value":{"max-queues":100,"max-connections":10}}]`)
+			expectDrift("limit max-connections, limit max-queues")
+		})
+	})
+
+	When("a RabbitMQVhost which already exists in RabbitMQ is adopted", func() {
+		var mockClusterName types.NamespacedName
+		var adoptedVhostName types.NamespacedName
+		var newVhostName types.NamespacedName
+
+		BeforeEach(func() {
+			mockClusterName = types.NamespacedName{Name: "rabbitmq-vhost-adoption", Namespace: namespace}
+			adoptedVhostName = types.NamespacedName{Name: "vhost-adopted", Namespace: namespace}
+			newVhostName = types.NamespacedName{Name: "vhost-not-adopted", Namespace: namespace}
+
+			SetupMockRabbitMQAPI()
+			DeferCleanup(StopMockRabbitMQAPI)
+
+			CreateRabbitMQCluster(mockClusterName, GetDefaultRabbitMQClusterSpec(false))
+			SimulateRabbitMQClusterReady(mockClusterName)
+			DeferCleanup(DeleteRabbitMQCluster, mockClusterName)
+
+			// The mock API returns the existing-vhost only
+			vhost := CreateRabbitMQVhost(adoptedVhostName, map[string]any{
+				"rabbitmqClusterName": mockClusterName.Name,
+				"name":                "existing-vhost",
+				"adoption":            map[string]any{"enabled": true},
+			})
+			DeferCleanup(th.DeleteInstance, vhost)
+
+			vhost = CreateRabbitMQVhost(newVhostName, map[string]any{
+				"rabbitmqClusterName": mockClusterName.Name,
+				"name":                "new-vhost",
+				"adoption":            map[string]any{"enabled": true},
+			})
+			DeferCleanup(th.DeleteInstance, vhost)
+		})
+
+		It("should record in the status whether the vhost was adopted", func() {
+			Eventually(func(g Gomega) {
+				v := GetRabbitMQVhost(adoptedVhostName)
+				g.Expect(v.Status.Conditions.IsTrue(rabbitmqv1.RabbitMQVhostReadyCondition)).To(BeTrue())
+				g.Expect(v.Status.Adopted).To(HaveValue(BeTrue()))
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				v := GetRabbitMQVhost(newVhostName)
+				g.Expect(v.Status.Conditions.IsTrue(rabbitmqv1.RabbitMQVhostReadyCondition)).To(BeTrue())
+				g.Expect(v.Status.Adopted).To(HaveValue(BeFalse()))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("should keep the adopted vhost in RabbitMQ when deleted", func() {
+			Eventually(func(g Gomega) {
+				v := GetRabbitMQVhost(adoptedVhostName)
+				g.Expect(v.Status.Adopted).To(HaveValue(BeTrue()))
+			}, timeout, interval).Should(Succeed())
+
+			// DeleteInstance waits until the finalizer is removed without deleting the vhost in RabbitMQ
+			th.DeleteInstance(GetRabbitMQVhost(adoptedVhostName))
+			Expect(GetMockRabbitMQRequests()).NotTo(ContainElement("DELETE /api/vhosts/existing-vhost"))
+
+			// The vhost created by the operator gets deleted
+			Eventually(func(g Gomega) {
+				v := GetRabbitMQVhost(newVhostName)
+				g.Expect(v.Status.Adopted).To(HaveValue(BeFalse()))
+			}, timeout, interval).Should(Succeed())
+			th.DeleteInstance(GetRabbitMQVhost(newVhostName))
+			Expect(GetMockRabbitMQRequests()).To(ContainElement("DELETE /api/vhosts/new-vhost"))
+		})
+	})
+
+	When("a RabbitMQVhost with an invalid tag is created", func() {
+		It("should reject creation with validation error", func() {
+			raw := map[string]any{
+				"apiVersion": "rabbitmq.openstack.org/v1beta1",
+				"kind":       "RabbitMQVhost",
+				"metadata": map[string]any{
+					"name":      "bad-tag-vhost",
+					"namespace": namespace,
+				},
+				"spec": map[string]any{
+					"rabbitmqClusterName": rabbitmqClusterName.Name,
+					"name":                "bad-tag",
+					"tags":                []string{"a,b"},
+				},
+			}
+
+			unstructuredObj := &unstructured.Unstructured{Object: raw}
+			err := th.K8sClient.Create(th.Ctx, unstructuredObj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("tag must not be empty or contain a comma"))
+		})
+	})
+
 	When("RabbitMQ cluster is deleted and recreated", func() {
 		var recreateClusterName types.NamespacedName
 		var recreateVhostName types.NamespacedName
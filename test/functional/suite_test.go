@@ -55,6 +55,7 @@ import (
 	webhookmemcachedv1beta1 "github.com/openstack-k8s-operators/infra-operator/internal/webhook/memcached/v1beta1"
 	webhooknetworkv1beta1 "github.com/openstack-k8s-operators/infra-operator/internal/webhook/network/v1beta1"
 	webhookrabbitmqv1beta1 "github.com/openstack-k8s-operators/infra-operator/internal/webhook/rabbitmq/v1beta1"
+	"github.com/openstack-k8s-operators/infra-operator/pkg/rabbitmq/secretprovider"
 
 	ocp_configv1 "github.com/openshift/api/config/v1"
 	infra_test "github.com/openstack-k8s-operators/infra-operator/apis/test/helpers"
@@ -75,6 +76,8 @@ var (
 	logger    logr.Logger
 	namespace string
 	th        *infra_test.TestHelper
+	// userSecretsDir - directory of the file external secret provider of RabbitMQUsers
+	userSecretsDir string
 )
 
 func TestAPIs(t *testing.T) {
@@ -213,6 +216,8 @@ var _ = BeforeSuite(func() {
 	Expect(err).NotTo(HaveOccurred())
 	err = webhooknetworkv1beta1.SetupDNSMasqWebhookWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
+	err = webhooknetworkv1beta1.SetupBGPConfigurationWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
 	err = webhookmemcachedv1beta1.SetupMemcachedWebhookWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 	err = webhookrabbitmqv1beta1.SetupRabbitMqWebhookWithManager(k8sManager)
@@ -223,6 +228,20 @@ var _ = BeforeSuite(func() {
 	Expect(err).NotTo(HaveOccurred())
 	err = webhookrabbitmqv1beta1.SetupRabbitMQVhostWebhookWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
+	err = webhookrabbitmqv1beta1.SetupRabbitMQQueueWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = webhookrabbitmqv1beta1.SetupRabbitMQExchangeWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = webhookrabbitmqv1beta1.SetupRabbitMQBindingWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = webhookrabbitmqv1beta1.SetupRabbitMQShovelWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = webhookrabbitmqv1beta1.SetupRabbitMQFederationUpstreamWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = webhookrabbitmqv1beta1.SetupRabbitMQBackupWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+	err = webhookrabbitmqv1beta1.SetupRabbitMQRestoreWebhookWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
 
 	err = (&network_ctrl.DNSMasqReconciler{
 		Client:  k8sManager.GetClient(),
@@ -274,9 +293,11 @@ var _ = BeforeSuite(func() {
 	Expect(err).ToNot(HaveOccurred())
 
 	err = (&rabbitmq_ctrl.Reconciler{
-		Client:  k8sManager.GetClient(),
-		Scheme:  k8sManager.GetScheme(),
-		Kclient: kclient,
+		Client:    k8sManager.GetClient(),
+		Scheme:    k8sManager.GetScheme(),
+		Kclient:   kclient,
+		ExecInPod: ExecMockRabbitMQCommand,
+		NodeHost:  MockRabbitMQNodeHost,
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -287,10 +308,14 @@ var _ = BeforeSuite(func() {
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	userSecretsDir = GinkgoT().TempDir()
 	err = (&rabbitmq_ctrl.RabbitMQUserReconciler{
 		Client:  k8sManager.GetClient(),
 		Scheme:  k8sManager.GetScheme(),
 		Kclient: kclient,
+		SecretProviders: map[string]secretprovider.Provider{
+			rabbitmqv1.ExternalSecretProviderFile: secretprovider.NewFileProvider(userSecretsDir),
+		},
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -301,6 +326,55 @@ var _ = BeforeSuite(func() {
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (&rabbitmq_ctrl.RabbitMQQueueReconciler{
+		Client:  k8sManager.GetClient(),
+		Scheme:  k8sManager.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&rabbitmq_ctrl.RabbitMQExchangeReconciler{
+		Client:  k8sManager.GetClient(),
+		Scheme:  k8sManager.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&rabbitmq_ctrl.RabbitMQBindingReconciler{
+		Client:  k8sManager.GetClient(),
+		Scheme:  k8sManager.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&rabbitmq_ctrl.RabbitMQShovelReconciler{
+		Client:  k8sManager.GetClient(),
+		Scheme:  k8sManager.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&rabbitmq_ctrl.RabbitMQFederationUpstreamReconciler{
+		Client:  k8sManager.GetClient(),
+		Scheme:  k8sManager.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&rabbitmq_ctrl.RabbitMQBackupReconciler{
+		Client:  k8sManager.GetClient(),
+		Scheme:  k8sManager.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&rabbitmq_ctrl.RabbitMQRestoreReconciler{
+		Client:  k8sManager.GetClient(),
+		Scheme:  k8sManager.GetScheme(),
+		Kclient: kclient,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	th.CreateClusterNetworkConfig()
 
 	go func() {